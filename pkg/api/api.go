@@ -82,6 +82,11 @@ func NewAPI(c Config) (*API, error) {
 		return nil, err
 	}
 
+	// The SAML requests use the same client so its transport settings are kept.
+	if saml, ok := c.AuthWriter.(*auth.SAMLLogin); ok && saml.HTTPClient == nil {
+		saml.HTTPClient = c.Client
+	}
+
 	var api = API{AuthWriter: c.AuthWriter, V1API: client.New(transport, nil)}
 	if !c.SkipLogin {
 		if err := LoginUser(&api, c.ErrorDevice); err != nil {
@@ -156,3 +156,22 @@ func TestNewAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAPISAMLLoginClient(t *testing.T) {
+	saml, err := auth.NewSAMLLogin(func(string) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c = mock.NewClient()
+	if _, err := NewAPI(Config{
+		Client:     c,
+		AuthWriter: saml,
+		Host:       "https://localhost",
+		SkipLogin:  true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, c, saml.HTTPClient)
+}
@@ -39,8 +39,19 @@ const (
 	rawMetadataTextProducer                   = "set-es-cluster-metadata-raw"
 	updateUserTextProducer                    = "update-user"
 	updateCurrentUserTextProducer             = "update-current-user"
+	setTLSCertificateTextProducer             = "set-tls-certificate"
 )
 
+// patchTextProducers contains the operation IDs which patch the platform
+// components settings with a raw JSON string body.
+var patchTextProducers = map[string]bool{
+	"update-adminconsole-logging-settings": true,
+	"update-allocator-logging-settings":    true,
+	"update-allocator-settings":            true,
+	"update-constructor-logging-settings":  true,
+	"update-runner-logging-settings":       true,
+}
+
 // DefaultBasePath is used as the base prefix for the API.
 var DefaultBasePath = client.DefaultBasePath
 
@@ -52,17 +63,18 @@ var DefaultBasePath = client.DefaultBasePath
 // Strictly, there's no need for a `"key":false` to be present in the map, but
 // it does make it explicit and nicer to maintain.
 var globalPath = map[string]bool{
-	"clusters":      false,
-	"comments":      false,
-	"deployments":   true,
-	"phone-home":    true,
-	"platform":      false,
-	"stack":         false,
-	"user":          true,
-	"users":         true,
-	"billing":       true,
-	"organizations": true,
-	"saas":          true,
+	"clusters":             false,
+	"comments":             false,
+	"deployments":          true,
+	"phone-home":           true,
+	"platform":             false,
+	"stack":                false,
+	"user":                 true,
+	"users":                true,
+	"billing":              true,
+	"organizations":        true,
+	"saas":                 true,
+	"trusted-environments": true,
 }
 
 type newRuntimeFunc func(region string) *runtimeclient.Runtime
@@ -144,7 +156,9 @@ func overrideJSONProducer(r *runtimeclient.Runtime, opID string) func() {
 	if !(opID == updateUserTextProducer ||
 		opID == rawMetadataTextProducer ||
 		opID == updateCurrentUserTextProducer ||
-		opID == rawMetadataDeploymentResourceTextProducer) {
+		opID == rawMetadataDeploymentResourceTextProducer ||
+		opID == setTLSCertificateTextProducer ||
+		patchTextProducers[opID]) {
 		return func() {}
 	}
 
@@ -283,6 +283,17 @@ func TestCloudClientRuntime_getRuntime(t *testing.T) {
 			}},
 			want: &runtimeclient.Runtime{BasePath: "/api/v1"},
 		},
+		{
+			name: "/trusted-environments operation uses the regionless path",
+			fields: fields{
+				newRegionRuntime: mocknewRuntimeFunc,
+				runtime:          regionless,
+			},
+			args: args{op: &runtime.ClientOperation{
+				PathPattern: "/trusted-environments",
+			}},
+			want: &runtimeclient.Runtime{BasePath: "/api/v1"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -399,6 +410,45 @@ func Test_overrideJSONProducer(t *testing.T) {
 			},
 			want: `{"some":"content"}`,
 		},
+		{
+			name: "changes the producer when using set-tls-certificate",
+			args: args{
+				r: &runtimeclient.Runtime{
+					Producers: map[string]runtime.Producer{
+						runtime.JSONMime: runtime.JSONProducer(),
+					},
+				},
+				opID:    "set-tls-certificate",
+				content: "-----BEGIN CERTIFICATE-----\n",
+			},
+			want: "-----BEGIN CERTIFICATE-----\n",
+		},
+		{
+			name: "changes the producer when using update-allocator-logging-settings",
+			args: args{
+				r: &runtimeclient.Runtime{
+					Producers: map[string]runtime.Producer{
+						runtime.JSONMime: runtime.JSONProducer(),
+					},
+				},
+				opID:    "update-allocator-logging-settings",
+				content: `{"logging_levels":{"root":"DEBUG"}}`,
+			},
+			want: `{"logging_levels":{"root":"DEBUG"}}`,
+		},
+		{
+			name: "changes the producer when using update-allocator-settings",
+			args: args{
+				r: &runtimeclient.Runtime{
+					Producers: map[string]runtime.Producer{
+						runtime.JSONMime: runtime.JSONProducer(),
+					},
+				},
+				opID:    "update-allocator-settings",
+				content: `{"capacity":8192}`,
+			},
+			want: `{"capacity":8192}`,
+		},
 		{
 			name: "resets the producer even when changed",
 			args: args{
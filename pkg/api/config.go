@@ -46,7 +46,8 @@ type Config struct {
 	SkipTLSVerify bool
 
 	// SkipLogin skips validating the user / password with the instanced API
-	// when AuthWriter equals *auth.UserLogin.
+	// when AuthWriter equals *auth.UserLogin, or the SAML login flow when it
+	// equals *auth.SAMLLogin.
 	SkipLogin bool
 
 	// ErrorDevice is used to send errors to prevent cluttering the output.
@@ -0,0 +1,206 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentapi
+
+import (
+	"crypto/sha1" // nolint:gosec // SHA-1 fingerprints are only informative.
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+// GetCertificateAuthorityParams is consumed by GetCertificateAuthority.
+type GetCertificateAuthorityParams struct {
+	*api.API
+
+	// Required deployment ID.
+	ID string
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params GetCertificateAuthorityParams) Validate() error {
+	var merr = multierror.NewPrefixed("deployment certificate authority",
+		deputil.ValidateParams(&params),
+	)
+
+	return merr.ErrorOrNil()
+}
+
+// GetCertificateAuthority returns the certificate authority of a deployment,
+// which can be used to establish trust with the deployment's Elasticsearch
+// nodes, i.e. in cross cluster search.
+func GetCertificateAuthority(params GetCertificateAuthorityParams) (*models.CertificateAuthority, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.Deployments.GetDeploymentCertificateAuthority(
+		deployments.NewGetDeploymentCertificateAuthorityParams().
+			WithDeploymentID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// CertificateInfo contains the parsed information of one of the certificate
+// authority's public certificates.
+type CertificateInfo struct {
+	// Active is true when the certificate is the one being used to sign the
+	// current certificates of the Elasticsearch instances.
+	Active bool `json:"active"`
+
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+
+	// Colon separated hexadecimal fingerprints of the DER certificate.
+	SHA1Fingerprint   string `json:"sha1_fingerprint"`
+	SHA256Fingerprint string `json:"sha256_fingerprint"`
+
+	// Certificate is the parsed certificate.
+	Certificate *x509.Certificate `json:"-"`
+}
+
+// ExpiresWithin returns true when the certificate expires before now plus
+// the specified window, or if it has already expired.
+func (c CertificateInfo) ExpiresWithin(window time.Duration, now time.Time) bool {
+	return !c.NotAfter.After(now.Add(window))
+}
+
+// ParseCertificateAuthority parses all of the certificate authority's public
+// certificates, returning their parsed information.
+func ParseCertificateAuthority(ca *models.CertificateAuthority) ([]CertificateInfo, error) {
+	if ca == nil {
+		return nil, errors.New("certificate authority cannot be nil")
+	}
+
+	var merr = multierror.NewPrefixed("certificate authority")
+	var result = make([]CertificateInfo, 0, len(ca.PublicCertificates))
+	for i, c := range ca.PublicCertificates {
+		if c == nil || c.Pem == nil {
+			merr = merr.Append(fmt.Errorf("certificate %d: pem cannot be empty", i))
+			continue
+		}
+
+		cert, err := parsePEMCertificate(*c.Pem)
+		if err != nil {
+			merr = merr.Append(fmt.Errorf("certificate %d: %w", i, err))
+			continue
+		}
+
+		info := NewCertificateInfo(cert)
+		info.Active = c.Active != nil && *c.Active
+		result = append(result, info)
+	}
+
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// NewCertificateInfo returns the CertificateInfo for the certificate.
+func NewCertificateInfo(cert *x509.Certificate) CertificateInfo {
+	sha1Sum := sha1.Sum(cert.Raw) // nolint:gosec // fingerprint only.
+	sha256Sum := sha256.Sum256(cert.Raw)
+	return CertificateInfo{
+		Subject:           cert.Subject.String(),
+		Issuer:            cert.Issuer.String(),
+		NotBefore:         cert.NotBefore,
+		NotAfter:          cert.NotAfter,
+		SHA1Fingerprint:   formatFingerprint(sha1Sum[:]),
+		SHA256Fingerprint: formatFingerprint(sha256Sum[:]),
+		Certificate:       cert,
+	}
+}
+
+// ExpiringCertificates returns the certificates which expire before now plus
+// the specified window.
+func ExpiringCertificates(certs []CertificateInfo, window time.Duration, now time.Time) []CertificateInfo {
+	var expiring []CertificateInfo
+	for _, c := range certs {
+		if c.ExpiresWithin(window, now) {
+			expiring = append(expiring, c)
+		}
+	}
+	return expiring
+}
+
+// WriteCertificateAuthorityPEM writes the certificate authority's public
+// certificates as a PEM bundle to the writer, which can be used as a client
+// trust store. When activeOnly is set, only the active certificate is written.
+func WriteCertificateAuthorityPEM(w io.Writer, ca *models.CertificateAuthority, activeOnly bool) error {
+	certs, err := ParseCertificateAuthority(ca)
+	if err != nil {
+		return err
+	}
+
+	var written int
+	for _, c := range certs {
+		if activeOnly && !c.Active {
+			continue
+		}
+		if err := pem.Encode(w, &pem.Block{
+			Type: "CERTIFICATE", Bytes: c.Certificate.Raw,
+		}); err != nil {
+			return err
+		}
+		written++
+	}
+
+	if written == 0 {
+		return errors.New("certificate authority: no certificates to write")
+	}
+
+	return nil
+}
+
+func parsePEMCertificate(s string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("failed decoding pem certificate")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func formatFingerprint(sum []byte) string {
+	var parts = make([]string, 0, len(sum))
+	for _, b := range sum {
+		parts = append(parts, fmt.Sprintf("%02X", b))
+	}
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package deploymentapi
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func newTestCertificate(t *testing.T, cn string, notAfter time.Time) (string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), cert
+}
+
+func TestGetCertificateAuthority(t *testing.T) {
+	tests := []struct {
+		name   string
+		params GetCertificateAuthorityParams
+		want   *models.CertificateAuthority
+		err    error
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("deployment certificate authority",
+				multierror.NewPrefixed("deployment params",
+					errors.New("api reference is required for the operation"),
+					errors.New(`id "" is invalid`),
+				),
+			),
+		},
+		{
+			name: "fails due to API error",
+			params: GetCertificateAuthorityParams{
+				API: api.NewMock(mock.SampleInternalError()),
+				ID:  mock.ValidClusterID,
+			},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "succeeds",
+			params: GetCertificateAuthorityParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/certificate-authority",
+					},
+					mock.NewStringBody(`{"public_certificates":[{"active":true,"pem":"some"}],"recommended_trust_restriction":"*.node.*"}`),
+				)),
+				ID: mock.ValidClusterID,
+			},
+			want: &models.CertificateAuthority{
+				PublicCertificates: []*models.PublicCertificate{{
+					Active: ec.Bool(true),
+					Pem:    ec.String("some"),
+				}},
+				RecommendedTrustRestriction: ec.String("*.node.*"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetCertificateAuthority(tt.params)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCertificateAuthority(t *testing.T) {
+	var now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	activePEM, active := newTestCertificate(t, "active-ca", now.Add(10*24*time.Hour))
+	futurePEM, future := newTestCertificate(t, "future-ca", now.Add(700*24*time.Hour))
+	var ca = &models.CertificateAuthority{PublicCertificates: []*models.PublicCertificate{
+		{Active: ec.Bool(true), Pem: ec.String(activePEM)},
+		{Active: ec.Bool(false), Pem: ec.String(futurePEM)},
+	}}
+
+	got, err := ParseCertificateAuthority(ca)
+	if !assert.NoError(t, err) {
+		return
+	}
+	if !assert.Len(t, got, 2) {
+		return
+	}
+
+	assert.True(t, got[0].Active)
+	assert.False(t, got[1].Active)
+	assert.Equal(t, "CN=active-ca", got[0].Subject)
+	assert.Equal(t, "CN=active-ca", got[0].Issuer)
+	assert.Equal(t, active.NotAfter, got[0].NotAfter)
+	assert.Equal(t, future, got[1].Certificate)
+
+	sum := sha256.Sum256(active.Raw)
+	assert.Equal(t, formatFingerprint(sum[:]), got[0].SHA256Fingerprint)
+	assert.Len(t, got[0].SHA256Fingerprint, 32*3-1)
+	assert.Len(t, got[0].SHA1Fingerprint, 20*3-1)
+
+	expiring := ExpiringCertificates(got, 30*24*time.Hour, now)
+	assert.Len(t, expiring, 1)
+	assert.Equal(t, "CN=active-ca", expiring[0].Subject)
+	assert.Empty(t, ExpiringCertificates(got, 24*time.Hour, now))
+
+	_, err = ParseCertificateAuthority(&models.CertificateAuthority{
+		PublicCertificates: []*models.PublicCertificate{
+			{Pem: ec.String("invalid")}, {},
+		},
+	})
+	assert.EqualError(t, err, multierror.NewPrefixed("certificate authority",
+		errors.New("certificate 0: failed decoding pem certificate"),
+		errors.New("certificate 1: pem cannot be empty"),
+	).Error())
+
+	_, err = ParseCertificateAuthority(nil)
+	assert.EqualError(t, err, "certificate authority cannot be nil")
+}
+
+func TestWriteCertificateAuthorityPEM(t *testing.T) {
+	var now = time.Now()
+	activePEM, _ := newTestCertificate(t, "active-ca", now.Add(24*time.Hour))
+	futurePEM, _ := newTestCertificate(t, "future-ca", now.Add(48*time.Hour))
+	var ca = &models.CertificateAuthority{PublicCertificates: []*models.PublicCertificate{
+		{Active: ec.Bool(true), Pem: ec.String(activePEM)},
+		{Active: ec.Bool(false), Pem: ec.String(futurePEM)},
+	}}
+
+	var buf = new(bytes.Buffer)
+	assert.NoError(t, WriteCertificateAuthorityPEM(buf, ca, false))
+	assert.Equal(t, activePEM+futurePEM, buf.String())
+
+	buf.Reset()
+	assert.NoError(t, WriteCertificateAuthorityPEM(buf, ca, true))
+	assert.Equal(t, activePEM, buf.String())
+
+	assert.EqualError(t, WriteCertificateAuthorityPEM(buf,
+		&models.CertificateAuthority{}, false,
+	), "certificate authority: no certificates to write")
+}
@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+)
+
+const redactedSecret = "[REDACTED]"
+
+// SecretToken holds a secret value which is redacted when it's formatted or
+// printed, to prevent it from accidentally ending up in logs or outputs. The
+// value can only be obtained through the Value method.
+type SecretToken struct {
+	value string
+}
+
+// Value returns the secret value.
+func (t SecretToken) Value() string { return t.value }
+
+// String returns the redacted representation of the secret.
+func (t SecretToken) String() string { return redactedSecret }
+
+// GoString returns the redacted representation of the secret.
+func (t SecretToken) GoString() string { return redactedSecret }
+
+// MarshalJSON returns the redacted representation of the secret.
+func (t SecretToken) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redactedSecret + `"`), nil
+}
+
+// ResetApmSecretToken rotates the secret token of an APM resource, returning
+// the newly generated token. If no RefID is specified, it is autodiscovered.
+func ResetApmSecretToken(params Params) (SecretToken, error) {
+	params.Kind = util.Apm
+	if err := params.Validate(); err != nil {
+		return SecretToken{}, multierror.NewPrefixed("apm secret token reset", err)
+	}
+
+	res, err := params.V1API.Deployments.DeploymentApmResetSecretToken(
+		deployments.NewDeploymentApmResetSecretTokenParams().
+			WithDeploymentID(params.DeploymentID).
+			WithRefID(params.RefID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return SecretToken{}, apierror.Wrap(err)
+	}
+
+	if res.Payload == nil || res.Payload.SecretToken == nil {
+		return SecretToken{}, errors.New("apm secret token reset: the response did not contain a secret token")
+	}
+
+	return SecretToken{value: *res.Payload.SecretToken}, nil
+}
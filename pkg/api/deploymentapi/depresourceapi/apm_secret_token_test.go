@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestResetApmSecretToken(t *testing.T) {
+	type args struct {
+		params Params
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+		err  error
+	}{
+		{
+			name: "fails due to parameter validation",
+			args: args{params: Params{API: api.NewMock(), RefID: "main-apm"}},
+			err: multierror.NewPrefixed("apm secret token reset",
+				errors.New("deployment resource: id \"\" is invalid"),
+			),
+		},
+		{
+			name: "fails discovering the RefID",
+			args: args{params: Params{
+				API: api.NewMock(mock.New200StructResponse(models.DeploymentGetResponse{
+					ID:        ec.String(mock.ValidClusterID),
+					Resources: &models.DeploymentResources{},
+				})),
+				DeploymentID: mock.ValidClusterID,
+			}},
+			err: multierror.NewPrefixed("apm secret token reset",
+				errors.New("deployment resource: failed auto-discovering the resource ref id: deployment get: resource kind apm is not available"),
+			),
+		},
+		{
+			name: "fails due to API error",
+			args: args{params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-apm",
+			}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "fails when the response has no token",
+			args: args{params: Params{
+				API:          api.NewMock(mock.New202Response(mock.NewStringBody(`{}`))),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-apm",
+			}},
+			err: errors.New("apm secret token reset: the response did not contain a secret token"),
+		},
+		{
+			name: "succeeds discovering the RefID",
+			args: args{params: Params{
+				API: api.NewMock(
+					mock.New200StructResponse(models.DeploymentGetResponse{
+						ID: ec.String(mock.ValidClusterID),
+						Resources: &models.DeploymentResources{
+							Apm: []*models.ApmResourceInfo{{
+								ID:    ec.String(mock.ValidClusterID),
+								RefID: ec.String("my-apm"),
+							}},
+						},
+					}),
+					mock.New202ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "POST",
+							Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/apm/my-apm/_reset-token",
+						},
+						mock.NewStringBody(`{"secret_token": "new-token"}`),
+					),
+				),
+				DeploymentID: mock.ValidClusterID,
+			}},
+			want: "new-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResetApmSecretToken(tt.args.params)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got.Value())
+		})
+	}
+}
+
+func TestSecretTokenIsRedacted(t *testing.T) {
+	var token = SecretToken{value: "super-secret"}
+	assert.Equal(t, "[REDACTED]", token.String())
+	assert.Equal(t, "[REDACTED]", fmt.Sprintf("%v", token))
+	assert.Equal(t, "[REDACTED]", fmt.Sprintf("%#v", token))
+
+	b, err := json.Marshal(struct{ Token SecretToken }{Token: token})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Token":"[REDACTED]"}`, string(b))
+	assert.Equal(t, "super-secret", token.Value())
+}
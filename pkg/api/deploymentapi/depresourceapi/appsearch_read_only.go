@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// SetAppsearchReadOnlyParams is consumed by SetAppsearchReadOnly.
+type SetAppsearchReadOnlyParams struct {
+	Params
+
+	// Enables or disables the read-only mode.
+	Enabled bool
+}
+
+// GetAppsearchReadOnly returns whether the read-only mode is enabled on an
+// App Search resource. If no RefID is specified, it is autodiscovered.
+func GetAppsearchReadOnly(params Params) (bool, error) {
+	params.Kind = util.Appsearch
+	if err := params.Validate(); err != nil {
+		return false, multierror.NewPrefixed("appsearch read-only mode", err)
+	}
+
+	res, err := params.V1API.Deployments.GetAppsearchReadOnlyMode(
+		deployments.NewGetAppsearchReadOnlyModeParams().
+			WithDeploymentID(params.DeploymentID).
+			WithRefID(params.RefID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return false, apierror.Wrap(err)
+	}
+
+	return readOnlyEnabled(res.Payload), nil
+}
+
+// SetAppsearchReadOnly enables or disables the read-only mode on an App
+// Search resource and returns the resulting state. If no RefID is specified,
+// it is autodiscovered.
+func SetAppsearchReadOnly(params SetAppsearchReadOnlyParams) (bool, error) {
+	params.Kind = util.Appsearch
+	if err := params.Validate(); err != nil {
+		return false, multierror.NewPrefixed("appsearch read-only mode", err)
+	}
+
+	res, err := params.V1API.Deployments.SetAppsearchReadOnlyMode(
+		deployments.NewSetAppsearchReadOnlyModeParams().
+			WithDeploymentID(params.DeploymentID).
+			WithRefID(params.RefID).
+			WithBody(&models.ReadOnlyRequest{Enabled: ec.Bool(params.Enabled)}),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return false, apierror.Wrap(err)
+	}
+
+	return readOnlyEnabled(res.Payload), nil
+}
+
+func readOnlyEnabled(res *models.ReadOnlyResponse) bool {
+	return res != nil && res.Enabled != nil && *res.Enabled
+}
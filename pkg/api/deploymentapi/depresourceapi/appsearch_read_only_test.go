@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func TestGetAppsearchReadOnly(t *testing.T) {
+	type args struct {
+		params Params
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+		err  error
+	}{
+		{
+			name: "fails due to parameter validation",
+			args: args{params: Params{API: api.NewMock(), RefID: "main-appsearch"}},
+			err: multierror.NewPrefixed("appsearch read-only mode",
+				errors.New("deployment resource: id \"\" is invalid"),
+			),
+		},
+		{
+			name: "fails due to API error",
+			args: args{params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-appsearch",
+			}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "succeeds",
+			args: args{params: Params{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/appsearch/main-appsearch/read_only_mode",
+					},
+					mock.NewStringBody(`{"enabled": true}`),
+				)),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-appsearch",
+			}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAppsearchReadOnly(tt.args.params)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetAppsearchReadOnly(t *testing.T) {
+	type args struct {
+		params SetAppsearchReadOnlyParams
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+		err  error
+	}{
+		{
+			name: "fails due to API error",
+			args: args{params: SetAppsearchReadOnlyParams{Params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-appsearch",
+			}}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "enables read-only mode",
+			args: args{params: SetAppsearchReadOnlyParams{
+				Params: Params{
+					API: api.NewMock(mock.New200ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "PUT",
+							Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/appsearch/main-appsearch/read_only_mode",
+							Body:   mock.NewStringBody(`{"enabled":true}` + "\n"),
+						},
+						mock.NewStringBody(`{"enabled": true}`),
+					)),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-appsearch",
+				},
+				Enabled: true,
+			}},
+			want: true,
+		},
+		{
+			name: "disables read-only mode",
+			args: args{params: SetAppsearchReadOnlyParams{
+				Params: Params{
+					API: api.NewMock(mock.New200ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "PUT",
+							Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/appsearch/main-appsearch/read_only_mode",
+							Body:   mock.NewStringBody(`{"enabled":false}` + "\n"),
+						},
+						mock.NewStringBody(`{"enabled": false}`),
+					)),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-appsearch",
+				},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetAppsearchReadOnly(tt.args.params)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// EnableFeatureParams is consumed by EnableCcr, EnableSlm and EnableIlm.
+type EnableFeatureParams struct {
+	Params
+
+	// Optional plan tracking settings, ignored when ValidateOnly is set.
+	planutil.TrackParams
+
+	// When set, the feature is not enabled but warnings are returned if any
+	// element may lose availability during the migration.
+	ValidateOnly bool
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params *EnableFeatureParams) Validate() error {
+	// These features can only be enabled on Elasticsearch resources.
+	params.Kind = util.Elasticsearch
+
+	var merr = multierror.NewPrefixed("elasticsearch feature enable")
+	merr = merr.Append(params.Params.Validate())
+	merr = merr.Append(params.TrackParams.Validate())
+
+	return merr.ErrorOrNil()
+}
+
+// EnableIlmParams is consumed by EnableIlm.
+type EnableIlmParams struct {
+	EnableFeatureParams
+
+	// Required index patterns and the ILM policies which will be created
+	// for them.
+	IndexPatterns []*models.IndexPattern
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params *EnableIlmParams) Validate() error {
+	var merr = multierror.NewPrefixed("elasticsearch ilm enable")
+	if len(params.IndexPatterns) == 0 {
+		merr = merr.Append(errors.New("at least 1 index pattern must be provided"))
+	}
+
+	for _, p := range params.IndexPatterns {
+		if p == nil {
+			merr = merr.Append(errors.New("index pattern cannot be nil"))
+			continue
+		}
+		if err := p.Validate(nil); err != nil {
+			merr = merr.Append(err)
+		}
+	}
+
+	merr = merr.Append(params.EnableFeatureParams.Validate())
+
+	return merr.ErrorOrNil()
+}
+
+// EnableCcr migrates an Elasticsearch resource and its associated Kibana to
+// enable cross cluster replication. When tracking is enabled, it waits until
+// the resulting plan change has finished.
+func EnableCcr(params EnableFeatureParams) (*models.DeploymentResourceCommandResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.Deployments.EnableDeploymentResourceCcr(
+		deployments.NewEnableDeploymentResourceCcrParams().
+			WithDeploymentID(params.DeploymentID).
+			WithRefID(params.RefID).
+			WithValidateOnly(ec.Bool(params.ValidateOnly)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, trackFeatureChange(params)
+}
+
+// EnableSlm migrates an Elasticsearch resource to use snapshot lifecycle
+// management. When tracking is enabled, it waits until the resulting plan
+// change has finished.
+func EnableSlm(params EnableFeatureParams) (*models.DeploymentResourceCommandResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.Deployments.EnableDeploymentResourceSlm(
+		deployments.NewEnableDeploymentResourceSlmParams().
+			WithDeploymentID(params.DeploymentID).
+			WithRefID(params.RefID).
+			WithValidateOnly(ec.Bool(params.ValidateOnly)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, trackFeatureChange(params)
+}
+
+// EnableIlm migrates an Elasticsearch resource from index curation to index
+// lifecycle management, creating the ILM policies for the specified index
+// patterns. When tracking is enabled, it waits until the resulting plan change
+// has finished.
+func EnableIlm(params EnableIlmParams) (*models.DeploymentResourceCommandResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.Deployments.EnableDeploymentResourceIlm(
+		deployments.NewEnableDeploymentResourceIlmParams().
+			WithDeploymentID(params.DeploymentID).
+			WithRefID(params.RefID).
+			WithValidateOnly(ec.Bool(params.ValidateOnly)).
+			WithBody(&models.EnableIlmRequest{
+				IndexPatterns: params.IndexPatterns,
+			}),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, trackFeatureChange(params.EnableFeatureParams)
+}
+
+// trackFeatureChange tracks the plan triggered by a feature migration. Since
+// a validation request doesn't trigger any plan, it's never tracked.
+func trackFeatureChange(params EnableFeatureParams) error {
+	if params.ValidateOnly {
+		return nil
+	}
+
+	return trackChange(params.Params, params.TrackParams)
+}
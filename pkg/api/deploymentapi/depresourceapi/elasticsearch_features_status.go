@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+// remoteClusterClientRole is the node role which allows connecting to remote
+// clusters.
+const remoteClusterClientRole = "remote_cluster_client"
+
+// FeatureStatus is the status of an optional Elasticsearch feature.
+type FeatureStatus string
+
+const (
+	// FeatureEnabled is reported when the feature is enabled.
+	FeatureEnabled FeatureStatus = "enabled"
+	// FeatureDisabled is reported when the feature isn't enabled.
+	FeatureDisabled FeatureStatus = "disabled"
+	// FeatureUnknown is reported when the feature status can't be determined
+	// from the resource info.
+	FeatureUnknown FeatureStatus = "unknown"
+)
+
+// ElasticsearchFeatures reports the status of the optional Elasticsearch
+// features of a resource.
+type ElasticsearchFeatures struct {
+	Ccr FeatureStatus `json:"ccr"`
+	Ilm FeatureStatus `json:"ilm"`
+	Slm FeatureStatus `json:"slm"`
+}
+
+// NewElasticsearchFeatures computes the features status from the resource
+// info. The resource info must have been obtained with settings and plans.
+func NewElasticsearchFeatures(res *models.ElasticsearchResourceInfo) ElasticsearchFeatures {
+	return ElasticsearchFeatures{
+		Ccr: CcrStatus(res),
+		Ilm: IlmStatus(res),
+		Slm: SlmStatus(res),
+	}
+}
+
+// GetElasticsearchFeatures obtains the Elasticsearch resource information and
+// reports the status of its features.
+func GetElasticsearchFeatures(params Params) (*ElasticsearchFeatures, error) {
+	params.Kind = util.Elasticsearch
+	if err := params.Validate(); err != nil {
+		return nil, multierror.NewPrefixed("elasticsearch feature get", err)
+	}
+
+	res, err := deploymentapi.GetElasticsearch(deploymentapi.GetParams{
+		API:          params.API,
+		DeploymentID: params.DeploymentID,
+		RefID:        params.RefID,
+		QueryParams: deputil.QueryParams{
+			ShowPlans:    true,
+			ShowSettings: true,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	features := NewElasticsearchFeatures(res)
+	return &features, nil
+}
+
+// CcrStatus reports whether the resource nodes can connect to remote clusters,
+// which CCR requires, from the remote_cluster_client node role of its current
+// plan topology. Plans which don't specify node roles report FeatureUnknown.
+func CcrStatus(res *models.ElasticsearchResourceInfo) FeatureStatus {
+	var topology = currentTopology(res)
+	var status = FeatureUnknown
+	for _, element := range topology {
+		if element == nil || element.NodeRoles == nil {
+			continue
+		}
+
+		if slice.HasString(element.NodeRoles, remoteClusterClientRole) {
+			return FeatureEnabled
+		}
+		status = FeatureDisabled
+	}
+
+	return status
+}
+
+// SlmStatus reports whether the resource snapshots are managed through
+// snapshot lifecycle management, from its snapshot settings.
+func SlmStatus(res *models.ElasticsearchResourceInfo) FeatureStatus {
+	var settings = esSettings(res)
+	if settings == nil {
+		return FeatureUnknown
+	}
+
+	if settings.Snapshot != nil && settings.Snapshot.Slm != nil && *settings.Snapshot.Slm {
+		return FeatureEnabled
+	}
+	return FeatureDisabled
+}
+
+// IlmStatus reports whether the resource indices are managed with ILM. When
+// index curation is set, either in its settings or its current plan, ILM
+// isn't enabled. Otherwise, it's enabled when its current plan topology sets
+// the node attributes ILM uses to allocate the indices, and unknown when it
+// doesn't.
+func IlmStatus(res *models.ElasticsearchResourceInfo) FeatureStatus {
+	if res == nil || res.Info == nil {
+		return FeatureUnknown
+	}
+
+	if settings := res.Info.Settings; settings != nil && settings.Curation != nil {
+		if len(settings.Curation.Specs) > 0 {
+			return FeatureDisabled
+		}
+	}
+
+	if plan := currentPlan(res); plan != nil && plan.Elasticsearch != nil {
+		if plan.Elasticsearch.Curation != nil {
+			return FeatureDisabled
+		}
+	}
+
+	for _, element := range currentTopology(res) {
+		if element != nil && element.Elasticsearch != nil && len(element.Elasticsearch.NodeAttributes) > 0 {
+			return FeatureEnabled
+		}
+	}
+
+	return FeatureUnknown
+}
+
+func esSettings(res *models.ElasticsearchResourceInfo) *models.ElasticsearchClusterSettings {
+	if res == nil || res.Info == nil {
+		return nil
+	}
+	return res.Info.Settings
+}
+
+func currentPlan(res *models.ElasticsearchResourceInfo) *models.ElasticsearchClusterPlan {
+	if res == nil || res.Info == nil || res.Info.PlanInfo == nil || res.Info.PlanInfo.Current == nil {
+		return nil
+	}
+	return res.Info.PlanInfo.Current.Plan
+}
+
+func currentTopology(res *models.ElasticsearchResourceInfo) []*models.ElasticsearchClusterTopologyElement {
+	if plan := currentPlan(res); plan != nil {
+		return plan.ClusterTopology
+	}
+	return nil
+}
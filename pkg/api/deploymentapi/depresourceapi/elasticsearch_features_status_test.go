@@ -0,0 +1,201 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestNewElasticsearchFeatures(t *testing.T) {
+	var newPlan = func(plan *models.ElasticsearchClusterPlan) *models.ElasticsearchClusterPlansInfo {
+		return &models.ElasticsearchClusterPlansInfo{
+			Current: &models.ElasticsearchClusterPlanInfo{Plan: plan},
+		}
+	}
+	type args struct {
+		res *models.ElasticsearchResourceInfo
+	}
+	tests := []struct {
+		name string
+		args args
+		want ElasticsearchFeatures
+	}{
+		{
+			name: "nil resource has unknown features",
+			want: ElasticsearchFeatures{Ccr: FeatureUnknown, Ilm: FeatureUnknown, Slm: FeatureUnknown},
+		},
+		{
+			name: "resource without settings nor plan topology has unknown features",
+			args: args{res: &models.ElasticsearchResourceInfo{
+				Info: &models.ElasticsearchClusterInfo{
+					PlanInfo: newPlan(&models.ElasticsearchClusterPlan{
+						Elasticsearch: &models.ElasticsearchConfiguration{},
+					}),
+				},
+			}},
+			want: ElasticsearchFeatures{Ccr: FeatureUnknown, Ilm: FeatureUnknown, Slm: FeatureUnknown},
+		},
+		{
+			name: "resource with curation in its plan",
+			args: args{res: &models.ElasticsearchResourceInfo{
+				Info: &models.ElasticsearchClusterInfo{
+					PlanInfo: newPlan(&models.ElasticsearchClusterPlan{
+						Elasticsearch: &models.ElasticsearchConfiguration{
+							Curation: &models.ElasticsearchCuration{
+								FromInstanceConfigurationID: ec.String("hot"),
+								ToInstanceConfigurationID:   ec.String("warm"),
+							},
+						},
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{{
+							NodeRoles: []string{"master", "data_hot"},
+						}},
+					}),
+				},
+			}},
+			want: ElasticsearchFeatures{Ccr: FeatureDisabled, Ilm: FeatureDisabled, Slm: FeatureUnknown},
+		},
+		{
+			name: "resource with curation in its settings",
+			args: args{res: &models.ElasticsearchResourceInfo{
+				Info: &models.ElasticsearchClusterInfo{
+					Settings: &models.ElasticsearchClusterSettings{
+						Curation: &models.ClusterCurationSettings{
+							Specs: []*models.ClusterCurationSpec{{
+								IndexPattern: ec.String("logs-*"),
+							}},
+						},
+						Snapshot: &models.ClusterSnapshotSettings{Slm: ec.Bool(false)},
+					},
+				},
+			}},
+			want: ElasticsearchFeatures{Ccr: FeatureUnknown, Ilm: FeatureDisabled, Slm: FeatureDisabled},
+		},
+		{
+			name: "resource with all features enabled",
+			args: args{res: &models.ElasticsearchResourceInfo{
+				Info: &models.ElasticsearchClusterInfo{
+					Settings: &models.ElasticsearchClusterSettings{
+						Snapshot: &models.ClusterSnapshotSettings{Slm: ec.Bool(true)},
+					},
+					PlanInfo: newPlan(&models.ElasticsearchClusterPlan{
+						Elasticsearch: &models.ElasticsearchConfiguration{},
+						ClusterTopology: []*models.ElasticsearchClusterTopologyElement{
+							{
+								NodeRoles: []string{"master"},
+							},
+							{
+								NodeRoles: []string{"data_hot", "remote_cluster_client"},
+								Elasticsearch: &models.ElasticsearchConfiguration{
+									NodeAttributes: map[string]string{"data": "hot"},
+								},
+							},
+						},
+					}),
+				},
+			}},
+			want: ElasticsearchFeatures{Ccr: FeatureEnabled, Ilm: FeatureEnabled, Slm: FeatureEnabled},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewElasticsearchFeatures(tt.args.res))
+		})
+	}
+}
+
+func TestGetElasticsearchFeatures(t *testing.T) {
+	type args struct {
+		params Params
+	}
+	tests := []struct {
+		name string
+		args args
+		want *ElasticsearchFeatures
+		err  error
+	}{
+		{
+			name: "fails due to parameter validation",
+			args: args{params: Params{
+				API:   api.NewMock(),
+				RefID: "main-elasticsearch",
+			}},
+			err: multierror.NewPrefixed("elasticsearch feature get",
+				errors.New("deployment resource: id \"\" is invalid"),
+			),
+		},
+		{
+			name: "fails due to API error",
+			args: args{params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "succeeds",
+			args: args{params: Params{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch",
+						Query: url.Values{
+							"show_metadata":      {"false"},
+							"show_plan_defaults": {"false"},
+							"show_plan_logs":     {"false"},
+							"show_plans":         {"true"},
+							"show_settings":      {"true"},
+							"show_system_alerts": {"5"},
+						},
+					},
+					mock.NewStructBody(models.ElasticsearchResourceInfo{
+						Info: &models.ElasticsearchClusterInfo{
+							Settings: &models.ElasticsearchClusterSettings{
+								Snapshot: &models.ClusterSnapshotSettings{Slm: ec.Bool(true)},
+							},
+						},
+					}),
+				)),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}},
+			want: &ElasticsearchFeatures{Ccr: FeatureUnknown, Ilm: FeatureUnknown, Slm: FeatureEnabled},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetElasticsearchFeatures(tt.args.params)
+			if err != nil && !assert.EqualError(t, err, tt.err.Error()) {
+				t.Error(err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
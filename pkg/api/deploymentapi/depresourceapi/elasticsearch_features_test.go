@@ -0,0 +1,302 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+	planmock "github.com/elastic/cloud-sdk-go/pkg/plan/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var (
+	featurePendingPlan = planmock.Generate(planmock.GenerateConfig{
+		ID: mock.ValidClusterID,
+		Elasticsearch: []planmock.GeneratedResourceConfig{{
+			ID: "cde7b6b605424a54ce9d56316eab13a1",
+			PendingLog: planmock.NewPlanStepLog(
+				planmock.NewPlanStep("step-1", "success"),
+				planmock.NewPlanStep("step-2", "pending"),
+			),
+		}},
+	})
+	featureCurrentPlan = planmock.Generate(planmock.GenerateConfig{
+		ID: mock.ValidClusterID,
+		Elasticsearch: []planmock.GeneratedResourceConfig{{
+			ID: "cde7b6b605424a54ce9d56316eab13a1",
+			CurrentLog: planmock.NewPlanStepLog(
+				planmock.NewPlanStep("step-1", "success"),
+				planmock.NewPlanStep("step-2", "success"),
+				planmock.NewPlanStep("plan-completed", "success"),
+			),
+		}},
+	})
+	durationRegexp     = regexp.MustCompile(`(?mi).\(.*plan duration.*`)
+	featureTrackOutput = fmt.Sprintf(
+		"Deployment [%s] - [Elasticsearch][cde7b6b605424a54ce9d56316eab13a1]: running step \"step-2\"\n\x1b[92;mDeployment [%s] - [Elasticsearch][cde7b6b605424a54ce9d56316eab13a1]: finished running all the plan steps\x1b[0m\n",
+		mock.ValidClusterID, mock.ValidClusterID,
+	)
+)
+
+func TestEnableCcr(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	type args struct {
+		params EnableFeatureParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *models.DeploymentResourceCommandResponse
+		wantOut string
+		err     string
+	}{
+		{
+			name: "fails due to parameter validation",
+			args: args{params: EnableFeatureParams{
+				TrackParams: planutil.TrackParams{Track: true},
+			}},
+			err: multierror.NewPrefixed("elasticsearch feature enable",
+				errors.New("deployment resource: api reference is required for the operation"),
+				errors.New("deployment resource: id \"\" is invalid"),
+				errors.New("deployment resource: failed auto-discovering the resource ref id: deployment get: api reference is required for the operation"),
+				errors.New("deployment resource: failed auto-discovering the resource ref id: deployment get: id \"\" is invalid"),
+				errors.New("plan tracking: output device cannot be nil"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			args: args{params: EnableFeatureParams{Params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}}},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds validating",
+			args: args{params: EnableFeatureParams{
+				Params: Params{
+					API: api.NewMock(mock.New200ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "POST",
+							Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch/_enable-ccr",
+							Query:  map[string][]string{"validate_only": {"true"}},
+						},
+						mock.NewStringBody(`{}`),
+					)),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				ValidateOnly: true,
+				// Tracking is ignored when validating.
+				TrackParams: planutil.TrackParams{Track: true, Output: output.NewDevice(buf)},
+			}},
+			want: &models.DeploymentResourceCommandResponse{},
+		},
+		{
+			name: "succeeds and tracks the change",
+			args: args{params: EnableFeatureParams{
+				Params: Params{
+					API: api.NewMock(
+						mock.New200Response(mock.NewStringBody(`{}`)),
+						mock.New200StructResponse(featurePendingPlan),
+						mock.New200StructResponse(featureCurrentPlan),
+						mock.New200StructResponse(featureCurrentPlan),
+					),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				TrackParams: planutil.TrackParams{
+					Track:          true,
+					Output:         output.NewDevice(buf),
+					MaxPollRetries: 1,
+					TrackFrequency: time.Nanosecond,
+				},
+			}},
+			want:    &models.DeploymentResourceCommandResponse{},
+			wantOut: featureTrackOutput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer buf.Reset()
+			got, err := EnableCcr(tt.args.params)
+			if err != nil && !assert.EqualError(t, err, tt.err) {
+				t.Error(err)
+			}
+			if tt.err != "" && err == nil {
+				t.Errorf("expected error %s, got nil", tt.err)
+			}
+			assert.Equal(t, tt.want, got)
+			// Remove all of the duration timestamps.
+			assert.Equal(t, tt.wantOut, durationRegexp.ReplaceAllString(buf.String(), ""))
+		})
+	}
+}
+
+func TestEnableSlm(t *testing.T) {
+	type args struct {
+		params EnableFeatureParams
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.DeploymentResourceCommandResponse
+		err  error
+	}{
+		{
+			name: "fails due to API error",
+			args: args{params: EnableFeatureParams{Params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "succeeds",
+			args: args{params: EnableFeatureParams{Params: Params{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch/_enable-slm",
+						Query:  map[string][]string{"validate_only": {"false"}},
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}}},
+			want: &models.DeploymentResourceCommandResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EnableSlm(tt.args.params)
+			if err != nil && !assert.EqualError(t, err, tt.err.Error()) {
+				t.Error(err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEnableIlm(t *testing.T) {
+	var patterns = []*models.IndexPattern{{
+		IndexPattern: ec.String("logs-*"),
+		PolicyName:   ec.String("logs"),
+	}}
+	type args struct {
+		params EnableIlmParams
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.DeploymentResourceCommandResponse
+		err  error
+	}{
+		{
+			name: "fails due to parameter validation",
+			args: args{params: EnableIlmParams{
+				EnableFeatureParams: EnableFeatureParams{Params: Params{
+					API:          api.NewMock(),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				}},
+				IndexPatterns: []*models.IndexPattern{nil, {}},
+			}},
+			err: multierror.NewPrefixed("elasticsearch ilm enable",
+				errors.New("index pattern cannot be nil"),
+				errors.New("validation failure list:\nindex_pattern in body is required\npolicy_name in body is required"),
+			),
+		},
+		{
+			name: "fails when no index patterns are specified",
+			args: args{params: EnableIlmParams{
+				EnableFeatureParams: EnableFeatureParams{Params: Params{
+					API:          api.NewMock(),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				}},
+			}},
+			err: multierror.NewPrefixed("elasticsearch ilm enable",
+				errors.New("at least 1 index pattern must be provided"),
+			),
+		},
+		{
+			name: "fails due to API error",
+			args: args{params: EnableIlmParams{
+				EnableFeatureParams: EnableFeatureParams{Params: Params{
+					API:          api.NewMock(mock.SampleInternalError()),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				}},
+				IndexPatterns: patterns,
+			}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "succeeds",
+			args: args{params: EnableIlmParams{
+				EnableFeatureParams: EnableFeatureParams{Params: Params{
+					API: api.NewMock(mock.New200ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "POST",
+							Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch/_enable-ilm",
+							Query:  map[string][]string{"validate_only": {"false"}},
+							Body:   mock.NewStringBody(`{"index_patterns":[{"index_pattern":"logs-*","policy_name":"logs"}]}` + "\n"),
+						},
+						mock.NewStringBody(`{}`),
+					)),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				}},
+				IndexPatterns: patterns,
+			}},
+			want: &models.DeploymentResourceCommandResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EnableIlm(tt.args.params)
+			if err != nil && !assert.EqualError(t, err, tt.err.Error()) {
+				t.Error(err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,388 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/instanceconfigapi"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+// SetOverridesParams is consumed by SetOverrides.
+type SetOverridesParams struct {
+	Params
+
+	// Optional instance IDs to apply the overrides to. When empty, the
+	// overrides are applied to all of the resource instances.
+	InstanceIDs []string
+
+	// Only used when InstanceIDs are specified. When set, missing instances
+	// are ignored instead of failing the operation.
+	IgnoreMissing *bool
+
+	// Region where the instance configurations of the resource instances are
+	// obtained from, to validate the overrides against their limits.
+	Region string
+
+	// Capacity override in MB, validated against the instance configuration
+	// discrete sizes.
+	Capacity int32
+
+	// StorageMultiplier override, validated against the instance
+	// configuration discrete sizes when these are storage based.
+	StorageMultiplier float64
+
+	// Restarts the instances after the overrides have been set, required
+	// for the capacity override to take effect.
+	RestartAfterUpdate bool
+}
+
+// Validate ensures the parameters are usable by SetOverrides.
+func (params *SetOverridesParams) Validate() error {
+	var merr = multierror.NewPrefixed("deployment resource overrides")
+	if params.Kind == "" {
+		params.Kind = util.Elasticsearch
+	}
+
+	if params.Capacity == 0 && params.StorageMultiplier == 0 {
+		merr = merr.Append(errors.New("one of capacity or storage multiplier must be specified"))
+	}
+
+	if params.Capacity < 0 {
+		merr = merr.Append(fmt.Errorf("capacity %d cannot be negative", params.Capacity))
+	}
+
+	if params.StorageMultiplier < 0 {
+		merr = merr.Append(fmt.Errorf("storage multiplier %.1f cannot be negative", params.StorageMultiplier))
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	merr = merr.Append(params.Params.Validate())
+
+	return merr.ErrorOrNil()
+}
+
+// validateOverrides ensures the overrides are within the limits of the
+// instance configuration of each of the instances which are overridden.
+func validateOverrides(params SetOverridesParams, topology *models.ClusterTopologyInfo) error {
+	if topology == nil {
+		return nil
+	}
+
+	var merr = multierror.NewPrefixed("deployment resource overrides")
+	var configs = make(map[string]*models.InstanceConfiguration)
+	for _, instance := range topology.Instances {
+		if instance == nil || instance.InstanceName == nil {
+			continue
+		}
+
+		if len(params.InstanceIDs) > 0 && !slice.HasString(params.InstanceIDs, *instance.InstanceName) {
+			continue
+		}
+
+		if instance.InstanceConfiguration == nil || instance.InstanceConfiguration.ID == nil {
+			continue
+		}
+
+		var id = *instance.InstanceConfiguration.ID
+		if _, ok := configs[id]; !ok {
+			ic, err := instanceconfigapi.Get(instanceconfigapi.GetParams{
+				API:    params.API,
+				ID:     id,
+				Region: params.Region,
+			})
+			if err != nil {
+				return multierror.NewPrefixed("deployment resource overrides", err)
+			}
+			configs[id] = ic
+		}
+
+		var current int32
+		if instance.Memory != nil && instance.Memory.InstanceCapacity != nil {
+			current = *instance.Memory.InstanceCapacity
+		}
+
+		if err := validateInstanceOverrides(configs[id], params.Capacity, current, params.StorageMultiplier); err != nil {
+			merr = merr.Append(fmt.Errorf("instance %s: %w", *instance.InstanceName, err))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// validateInstanceOverrides ensures the instance capacity and storage
+// multiplier are within the discrete sizes of the instance configuration.
+// Memory based sizes constrain the capacity, while storage based sizes
+// constrain the storage, which is the capacity times the storage multiplier.
+// When either override isn't set, the current instance capacity or the
+// instance configuration storage multiplier are used.
+func validateInstanceOverrides(ic *models.InstanceConfiguration, capacity, current int32, multiplier float64) error {
+	if ic == nil || ic.DiscreteSizes == nil || len(ic.DiscreteSizes.Sizes) == 0 {
+		return nil
+	}
+
+	var min, max = ic.DiscreteSizes.Sizes[0], ic.DiscreteSizes.Sizes[0]
+	for _, size := range ic.DiscreteSizes.Sizes {
+		if size < min {
+			min = size
+		}
+		if size > max {
+			max = size
+		}
+	}
+
+	if ic.DiscreteSizes.Resource != models.DiscreteSizesResourceStorage {
+		if capacity > 0 && (capacity < min || capacity > max) {
+			return fmt.Errorf(
+				"capacity %d is outside of the instance configuration %s size range [%d, %d]",
+				capacity, ic.ID, min, max,
+			)
+		}
+		return nil
+	}
+
+	if capacity == 0 {
+		capacity = current
+	}
+
+	if multiplier == 0 {
+		multiplier = ic.StorageMultiplier
+	}
+
+	var storage = float64(capacity) * multiplier
+	if storage < float64(min) || storage > float64(max) {
+		return fmt.Errorf(
+			"storage %.0f (capacity %d * storage multiplier %.1f) is outside of the instance configuration %s storage size range [%d, %d]",
+			storage, capacity, multiplier, ic.ID, min, max,
+		)
+	}
+
+	return nil
+}
+
+// ClearOverridesParams is consumed by ClearOverrides.
+type ClearOverridesParams struct {
+	Params
+
+	// Optional instance IDs to clear the overrides from. When empty, the
+	// overrides are cleared from all of the resource instances.
+	InstanceIDs []string
+
+	// Only used when InstanceIDs are specified. When set, missing instances
+	// are ignored instead of failing the operation.
+	IgnoreMissing *bool
+
+	// Restarts the instances after the overrides have been cleared.
+	RestartAfterUpdate bool
+}
+
+// Validate ensures the parameters are usable by ClearOverrides.
+func (params *ClearOverridesParams) Validate() error {
+	if params.Kind == "" {
+		params.Kind = util.Elasticsearch
+	}
+
+	if err := params.Params.Validate(); err != nil {
+		return multierror.NewPrefixed("deployment resource overrides", err)
+	}
+
+	return nil
+}
+
+// InstanceOverride represents the effective overrides of a resource instance.
+type InstanceOverride struct {
+	// InstanceName is the name of the instance.
+	InstanceName string `json:"instance_name"`
+
+	// Capacity is the current capacity in MB of the instance.
+	Capacity int32 `json:"capacity"`
+
+	// PlannedCapacity is the capacity in MB defined by the resource plan,
+	// only populated when a capacity override is present.
+	PlannedCapacity int32 `json:"planned_capacity,omitempty"`
+
+	// Overrides set on the instance, nil when no overrides are present.
+	Overrides *models.InstanceOverrides `json:"overrides,omitempty"`
+}
+
+// SetOverrides sets the capacity and / or storage multiplier overrides on the
+// specified resource instances or all of them if none are specified. The
+// overrides are validated against the instance configuration of each of the
+// overridden instances before they're set.
+func SetOverrides(params SetOverridesParams) (*models.InstanceOverrides, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	topology, err := getTopology(params.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateOverrides(params, topology); err != nil {
+		return nil, err
+	}
+
+	return setOverrides(params.Params, params.InstanceIDs, params.IgnoreMissing,
+		params.RestartAfterUpdate, &models.InstanceOverrides{
+			Capacity:          params.Capacity,
+			StorageMultiplier: params.StorageMultiplier,
+		},
+	)
+}
+
+// ClearOverrides removes any capacity and storage multiplier overrides from
+// the specified resource instances or all of them if none are specified.
+func ClearOverrides(params ClearOverridesParams) (*models.InstanceOverrides, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	return setOverrides(params.Params, params.InstanceIDs, params.IgnoreMissing,
+		params.RestartAfterUpdate, new(models.InstanceOverrides),
+	)
+}
+
+func setOverrides(params Params, ids []string, ignoreMissing *bool, restart bool, body *models.InstanceOverrides) (*models.InstanceOverrides, error) {
+	if len(ids) == 0 {
+		res, err := params.V1API.Deployments.SetAllInstancesSettingsOverrides(
+			deployments.NewSetAllInstancesSettingsOverridesParams().
+				WithDeploymentID(params.DeploymentID).
+				WithResourceKind(params.Kind).
+				WithRefID(params.RefID).
+				WithRestartAfterUpdate(ec.Bool(restart)).
+				WithBody(body),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	}
+
+	res, err := params.V1API.Deployments.SetInstanceSettingsOverrides(
+		deployments.NewSetInstanceSettingsOverridesParams().
+			WithDeploymentID(params.DeploymentID).
+			WithResourceKind(params.Kind).
+			WithRefID(params.RefID).
+			WithInstanceIds(ids).
+			WithIgnoreMissing(ignoreMissing).
+			WithRestartAfterUpdate(ec.Bool(restart)).
+			WithBody(body),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// GetOverrides returns the effective overrides for each of the resource
+// instances.
+func GetOverrides(params Params) ([]InstanceOverride, error) {
+	if params.Kind == "" {
+		params.Kind = util.Elasticsearch
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, multierror.NewPrefixed("deployment resource overrides", err)
+	}
+
+	topology, err := getTopology(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if topology == nil {
+		return nil, nil
+	}
+
+	var result = make([]InstanceOverride, 0, len(topology.Instances))
+	for _, instance := range topology.Instances {
+		var override = InstanceOverride{Overrides: instance.InstanceOverrides}
+		if instance.InstanceName != nil {
+			override.InstanceName = *instance.InstanceName
+		}
+		if instance.Memory != nil {
+			if instance.Memory.InstanceCapacity != nil {
+				override.Capacity = *instance.Memory.InstanceCapacity
+			}
+			override.PlannedCapacity = instance.Memory.InstanceCapacityPlanned
+		}
+		result = append(result, override)
+	}
+
+	return result, nil
+}
+
+// getTopology returns the instances topology of the resource kind.
+func getTopology(params Params) (*models.ClusterTopologyInfo, error) {
+	var getParams = deploymentapi.GetParams{
+		API:          params.API,
+		DeploymentID: params.DeploymentID,
+		RefID:        params.RefID,
+	}
+
+	switch params.Kind {
+	case util.Elasticsearch:
+		res, err := deploymentapi.GetElasticsearch(getParams)
+		if err != nil || res.Info == nil {
+			return nil, err
+		}
+		return res.Info.Topology, nil
+	case util.Kibana:
+		res, err := deploymentapi.GetKibana(getParams)
+		if err != nil || res.Info == nil {
+			return nil, err
+		}
+		return res.Info.Topology, nil
+	case util.Apm:
+		res, err := deploymentapi.GetApm(getParams)
+		if err != nil || res.Info == nil {
+			return nil, err
+		}
+		return res.Info.Topology, nil
+	case util.Appsearch:
+		res, err := deploymentapi.GetAppSearch(getParams)
+		if err != nil || res.Info == nil {
+			return nil, err
+		}
+		return res.Info.Topology, nil
+	case util.EnterpriseSearch:
+		res, err := deploymentapi.GetEnterpriseSearch(getParams)
+		if err != nil || res.Info == nil {
+			return nil, err
+		}
+		return res.Info.Topology, nil
+	default:
+		return nil, fmt.Errorf("deployment resource overrides: resource kind %s is not supported", params.Kind)
+	}
+}
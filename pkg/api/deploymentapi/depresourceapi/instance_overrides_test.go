@@ -0,0 +1,428 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func overridesTopologyResponse(instanceConfig string) mock.Response {
+	var topology = &models.ClusterTopologyInfo{}
+	for _, name := range []string{"instance-0000000000", "instance-0000000001"} {
+		topology.Instances = append(topology.Instances, &models.ClusterInstanceInfo{
+			InstanceName: ec.String(name),
+			InstanceConfiguration: &models.ClusterInstanceConfigurationInfo{
+				ID: ec.String(instanceConfig),
+			},
+			Memory: &models.ClusterInstanceMemoryInfo{
+				InstanceCapacity: ec.Int32(4096),
+			},
+		})
+	}
+
+	return mock.New200StructResponse(models.ElasticsearchResourceInfo{
+		Info: &models.ElasticsearchClusterInfo{Topology: topology},
+	})
+}
+
+func overridesConfigResponse(id, resource string, multiplier float64) mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   "/api/v1/regions/us-east-1/platform/configuration/instances/" + id,
+		},
+		mock.NewStructBody(models.InstanceConfiguration{
+			ID:                id,
+			StorageMultiplier: multiplier,
+			DiscreteSizes: &models.DiscreteSizes{
+				Resource: resource,
+				Sizes:    []int32{1024, 2048, 4096},
+			},
+		}),
+	)
+}
+
+func TestSetOverrides(t *testing.T) {
+	type args struct {
+		params SetOverridesParams
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.InstanceOverrides
+		err  error
+	}{
+		{
+			name: "fails due to empty overrides",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API:          api.NewMock(),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Region: "us-east-1",
+			}},
+			err: multierror.NewPrefixed("deployment resource overrides",
+				errors.New("one of capacity or storage multiplier must be specified"),
+			),
+		},
+		{
+			name: "fails due to invalid overrides",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API:          api.NewMock(),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Capacity:          -512,
+				StorageMultiplier: -1,
+			}},
+			err: multierror.NewPrefixed("deployment resource overrides",
+				errors.New("capacity -512 cannot be negative"),
+				errors.New("storage multiplier -1.0 cannot be negative"),
+				errors.New("region not specified and is required for this operation"),
+			),
+		},
+		{
+			name: "fails due to API error",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API:          api.NewMock(mock.SampleInternalError()),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Region:   "us-east-1",
+				Capacity: 2048,
+			}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "fails obtaining the instance configuration",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API: api.NewMock(
+						overridesTopologyResponse("aws.data.highio.i3"),
+						mock.SampleInternalError(),
+					),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Region:   "us-east-1",
+				Capacity: 2048,
+			}},
+			err: multierror.NewPrefixed("deployment resource overrides",
+				mock.MultierrorInternalError,
+			),
+		},
+		{
+			name: "fails when the capacity is outside of the instance configuration sizes",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API: api.NewMock(
+						overridesTopologyResponse("aws.data.highio.i3"),
+						overridesConfigResponse("aws.data.highio.i3", "memory", 32),
+					),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Region:      "us-east-1",
+				InstanceIDs: []string{"instance-0000000001"},
+				Capacity:    8192,
+			}},
+			err: multierror.NewPrefixed("deployment resource overrides",
+				errors.New("instance instance-0000000001: capacity 8192 is outside of the instance configuration aws.data.highio.i3 size range [1024, 4096]"),
+			),
+		},
+		{
+			name: "fails when the storage is outside of the instance configuration sizes",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API: api.NewMock(
+						overridesTopologyResponse("aws.data.frozen"),
+						overridesConfigResponse("aws.data.frozen", "storage", 0.5),
+					),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Region:            "us-east-1",
+				StorageMultiplier: 2,
+			}},
+			err: multierror.NewPrefixed("deployment resource overrides",
+				errors.New("instance instance-0000000000: storage 8192 (capacity 4096 * storage multiplier 2.0) is outside of the instance configuration aws.data.frozen storage size range [1024, 4096]"),
+				errors.New("instance instance-0000000001: storage 8192 (capacity 4096 * storage multiplier 2.0) is outside of the instance configuration aws.data.frozen storage size range [1024, 4096]"),
+			),
+		},
+		{
+			name: "sets the overrides on all instances",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API: api.NewMock(
+						overridesTopologyResponse("aws.data.frozen"),
+						overridesConfigResponse("aws.data.frozen", "storage", 0.5),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Host:   api.DefaultMockHost,
+								Method: "PUT",
+								Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch/instances/overrides",
+								Query:  url.Values{"restart_after_update": {"true"}},
+								Body:   mock.NewStringBody(`{"capacity":2048,"storage_multiplier":1.5}` + "\n"),
+							},
+							mock.NewStringBody(`{"capacity":2048,"storage_multiplier":1.5}`),
+						),
+					),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Region:             "us-east-1",
+				Capacity:           2048,
+				StorageMultiplier:  1.5,
+				RestartAfterUpdate: true,
+			}},
+			want: &models.InstanceOverrides{Capacity: 2048, StorageMultiplier: 1.5},
+		},
+		{
+			name: "sets the overrides on some instances",
+			args: args{params: SetOverridesParams{
+				Params: Params{
+					API: api.NewMock(
+						overridesTopologyResponse("aws.data.highio.i3"),
+						overridesConfigResponse("aws.data.highio.i3", "memory", 32),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Host:   api.DefaultMockHost,
+								Method: "PUT",
+								Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch/instances/instance-0000000000,instance-0000000001/overrides",
+								Query: url.Values{
+									"ignore_missing":       {"true"},
+									"restart_after_update": {"false"},
+								},
+								Body: mock.NewStringBody(`{"capacity":2048}` + "\n"),
+							},
+							mock.NewStringBody(`{"capacity":2048}`),
+						),
+					),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Region:        "us-east-1",
+				InstanceIDs:   []string{"instance-0000000000", "instance-0000000001"},
+				IgnoreMissing: ec.Bool(true),
+				Capacity:      2048,
+			}},
+			want: &models.InstanceOverrides{Capacity: 2048},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetOverrides(tt.args.params)
+			if err != nil && !assert.EqualError(t, err, tt.err.Error()) {
+				t.Error(err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClearOverrides(t *testing.T) {
+	type args struct {
+		params ClearOverridesParams
+	}
+	tests := []struct {
+		name string
+		args args
+		want *models.InstanceOverrides
+		err  error
+	}{
+		{
+			name: "fails due to parameter validation",
+			args: args{params: ClearOverridesParams{Params: Params{
+				API:   api.NewMock(),
+				RefID: "main-elasticsearch",
+			}}},
+			err: multierror.NewPrefixed("deployment resource overrides",
+				errors.New("deployment resource: id \"\" is invalid"),
+			),
+		},
+		{
+			name: "clears the overrides on all instances",
+			args: args{params: ClearOverridesParams{Params: Params{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "PUT",
+						Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch/instances/overrides",
+						Query:  url.Values{"restart_after_update": {"false"}},
+						Body:   mock.NewStringBody(`{}` + "\n"),
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}}},
+			want: &models.InstanceOverrides{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ClearOverrides(tt.args.params)
+			if err != nil && !assert.EqualError(t, err, tt.err.Error()) {
+				t.Error(err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetOverrides(t *testing.T) {
+	type args struct {
+		params Params
+	}
+	tests := []struct {
+		name string
+		args args
+		want []InstanceOverride
+		err  error
+	}{
+		{
+			name: "fails due to API error",
+			args: args{params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "fails due to an unsupported kind",
+			args: args{params: Params{
+				API:          api.NewMock(),
+				DeploymentID: mock.ValidClusterID,
+				Kind:         "integrations_server",
+				RefID:        "main-integrations_server",
+			}},
+			err: errors.New("deployment resource overrides: resource kind integrations_server is not supported"),
+		},
+		{
+			name: "returns the effective overrides of a kibana resource",
+			args: args{params: Params{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/kibana/main-kibana",
+						Query: url.Values{
+							"show_metadata":      {"false"},
+							"show_plan_defaults": {"false"},
+							"show_plan_logs":     {"false"},
+							"show_plans":         {"false"},
+							"show_settings":      {"false"},
+						},
+					},
+					mock.NewStructBody(models.KibanaResourceInfo{
+						Info: &models.KibanaClusterInfo{
+							Topology: &models.ClusterTopologyInfo{
+								Instances: []*models.ClusterInstanceInfo{
+									{
+										InstanceName: ec.String("instance-0000000000"),
+										Memory: &models.ClusterInstanceMemoryInfo{
+											InstanceCapacity: ec.Int32(1024),
+										},
+									},
+								},
+							},
+						},
+					}),
+				)),
+				DeploymentID: mock.ValidClusterID,
+				Kind:         "kibana",
+				RefID:        "main-kibana",
+			}},
+			want: []InstanceOverride{
+				{InstanceName: "instance-0000000000", Capacity: 1024},
+			},
+		},
+		{
+			name: "returns the effective overrides",
+			args: args{params: Params{
+				API: api.NewMock(mock.New200StructResponse(models.ElasticsearchResourceInfo{
+					Info: &models.ElasticsearchClusterInfo{
+						Topology: &models.ClusterTopologyInfo{
+							Instances: []*models.ClusterInstanceInfo{
+								{
+									InstanceName: ec.String("instance-0000000000"),
+									Memory: &models.ClusterInstanceMemoryInfo{
+										InstanceCapacity:        ec.Int32(8192),
+										InstanceCapacityPlanned: 4096,
+									},
+									InstanceOverrides: &models.InstanceOverrides{
+										Capacity: 8192,
+									},
+								},
+								{
+									InstanceName: ec.String("instance-0000000001"),
+									Memory: &models.ClusterInstanceMemoryInfo{
+										InstanceCapacity: ec.Int32(4096),
+									},
+								},
+							},
+						},
+					},
+				})),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}},
+			want: []InstanceOverride{
+				{
+					InstanceName:    "instance-0000000000",
+					Capacity:        8192,
+					PlannedCapacity: 4096,
+					Overrides:       &models.InstanceOverrides{Capacity: 8192},
+				},
+				{
+					InstanceName: "instance-0000000001",
+					Capacity:     4096,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetOverrides(tt.args.params)
+			if err != nil && !assert.EqualError(t, err, tt.err.Error()) {
+				t.Error(err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
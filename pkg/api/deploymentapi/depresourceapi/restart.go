@@ -0,0 +1,145 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/deployments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// RestartParams is consumed by Restart.
+type RestartParams struct {
+	Params
+
+	// Optional plan tracking settings.
+	planutil.TrackParams
+
+	// Cancels any pending plans before restarting. When not set and the
+	// resource has a pending plan, the restart fails.
+	CancelPending bool
+
+	// Elasticsearch only values.
+
+	// GroupAttribute used to divide the instances into restart groups, see
+	// planutil.AllGroupAttribute, planutil.NameGroupAttribute and
+	// planutil.ZoneRestartGroupAttribute. It can also be set to a comma
+	// separated list of instance attributes. Defaults to restarting one
+	// zone at a time.
+	GroupAttribute string
+
+	// Skips taking a snapshot before restarting. Defaults to true.
+	SkipSnapshot *bool
+
+	// Time to wait for shards which show no initializing progress before
+	// restarting the next group. Only takes effect with second granularity.
+	ShardInitWaitTime time.Duration
+}
+
+// Validate ensures the parameters are usable by Restart.
+func (params *RestartParams) Validate() error {
+	var merr = multierror.NewPrefixed("deployment resource restart")
+	merr = merr.Append(params.Params.Validate())
+	merr = merr.Append(params.TrackParams.Validate())
+
+	if params.Kind != util.Elasticsearch {
+		if params.GroupAttribute != "" {
+			merr = merr.Append(errors.New("group attribute is only supported for elasticsearch resources"))
+		}
+		if params.SkipSnapshot != nil {
+			merr = merr.Append(errors.New("skip snapshot is only supported for elasticsearch resources"))
+		}
+		if params.ShardInitWaitTime > 0 {
+			merr = merr.Append(errors.New("shard init wait time is only supported for elasticsearch resources"))
+		}
+	}
+
+	if params.ShardInitWaitTime < 0 {
+		merr = merr.Append(errors.New("shard init wait time cannot be negative"))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Restart restarts all the instances of the specified resource kind ref ID on
+// a deployment. If no refID is specified, it tries to autodiscover it. When
+// tracking is enabled, it waits until the restart plan has finished.
+func Restart(params RestartParams) (*models.DeploymentResourceCommandResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var res *models.DeploymentResourceCommandResponse
+	var err error
+	if params.Kind == util.Elasticsearch {
+		res, err = restartElasticsearch(params)
+	} else {
+		res, err = restartStateless(params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return res, trackChange(params.Params, params.TrackParams)
+}
+
+func restartElasticsearch(params RestartParams) (*models.DeploymentResourceCommandResponse, error) {
+	var p = deployments.NewRestartDeploymentEsResourceParams().
+		WithDeploymentID(params.DeploymentID).
+		WithRefID(params.RefID).
+		WithCancelPending(ec.Bool(params.CancelPending)).
+		WithSkipSnapshot(params.SkipSnapshot)
+
+	if params.GroupAttribute != "" {
+		p.SetGroupAttribute(ec.String(params.GroupAttribute))
+	}
+
+	if params.ShardInitWaitTime > 0 {
+		p.SetShardInitWaitTime(ec.Int64(int64(params.ShardInitWaitTime.Seconds())))
+	}
+
+	res, err := params.V1API.Deployments.RestartDeploymentEsResource(p, params.AuthWriter)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+func restartStateless(params RestartParams) (*models.DeploymentResourceCommandResponse, error) {
+	res, err := params.V1API.Deployments.RestartDeploymentStatelessResource(
+		deployments.NewRestartDeploymentStatelessResourceParams().
+			WithDeploymentID(params.DeploymentID).
+			WithStatelessResourceKind(params.Kind).
+			WithRefID(params.RefID).
+			WithCancelPending(ec.Bool(params.CancelPending)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package depresourceapi
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+	plantrack "github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestRestart(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	type args struct {
+		params RestartParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *models.DeploymentResourceCommandResponse
+		wantOut string
+		err     error
+	}{
+		{
+			name: "fails due to parameter validation",
+			args: args{params: RestartParams{
+				Params: Params{
+					API:          api.NewMock(),
+					DeploymentID: mock.ValidClusterID,
+					Kind:         "kibana",
+					RefID:        "main-kibana",
+				},
+				GroupAttribute:    planutil.NameGroupAttribute,
+				SkipSnapshot:      ec.Bool(false),
+				ShardInitWaitTime: time.Minute,
+			}},
+			err: multierror.NewPrefixed("deployment resource restart",
+				errors.New("group attribute is only supported for elasticsearch resources"),
+				errors.New("skip snapshot is only supported for elasticsearch resources"),
+				errors.New("shard init wait time is only supported for elasticsearch resources"),
+			),
+		},
+		{
+			name: "fails due to API error",
+			args: args{params: RestartParams{Params: Params{
+				API:          api.NewMock(mock.SampleInternalError()),
+				DeploymentID: mock.ValidClusterID,
+				Kind:         "elasticsearch",
+				RefID:        "main-elasticsearch",
+			}}},
+			err: mock.MultierrorInternalError,
+		},
+		{
+			name: "restarts an elasticsearch resource one instance at a time",
+			args: args{params: RestartParams{
+				Params: Params{
+					API: api.NewMock(mock.New202ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "POST",
+							Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch/_restart",
+							Query: url.Values{
+								"cancel_pending":       {"true"},
+								"group_attribute":      {"__name__"},
+								"shard_init_wait_time": {"300"},
+								"skip_snapshot":        {"false"},
+							},
+						},
+						mock.NewStringBody(`{}`),
+					)),
+					DeploymentID: mock.ValidClusterID,
+					Kind:         "elasticsearch",
+					RefID:        "main-elasticsearch",
+				},
+				CancelPending:     true,
+				GroupAttribute:    planutil.NameGroupAttribute,
+				SkipSnapshot:      ec.Bool(false),
+				ShardInitWaitTime: 5 * time.Minute,
+			}},
+			want: &models.DeploymentResourceCommandResponse{},
+		},
+		{
+			name: "restarts a stateless resource and tracks the change",
+			args: args{params: RestartParams{
+				Params: Params{
+					API: api.NewMock(
+						mock.New202ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Host:   api.DefaultMockHost,
+								Method: "POST",
+								Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/kibana/main-kibana/_restart",
+								Query:  url.Values{"cancel_pending": {"false"}},
+							},
+							mock.NewStringBody(`{}`),
+						),
+						mock.New200StructResponse(featurePendingPlan),
+						mock.New200StructResponse(featureCurrentPlan),
+						mock.New200StructResponse(featureCurrentPlan),
+					),
+					DeploymentID: mock.ValidClusterID,
+					Kind:         "kibana",
+					RefID:        "main-kibana",
+				},
+				TrackParams: plantrack.TrackParams{
+					Track:          true,
+					Output:         output.NewDevice(buf),
+					MaxPollRetries: 1,
+					TrackFrequency: time.Nanosecond,
+				},
+			}},
+			want:    &models.DeploymentResourceCommandResponse{},
+			wantOut: featureTrackOutput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer buf.Reset()
+			got, err := Restart(tt.args.params)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOut, durationRegexp.ReplaceAllString(buf.String(), ""))
+		})
+	}
+}
@@ -34,6 +34,10 @@ const (
 	// ZoneGroupAttribute represents the rolling strategy porperty
 	// will cause the action to take place on one instance at a time.
 	ZoneGroupAttribute = "logical_zone_name"
+
+	// ZoneRestartGroupAttribute represents the restart grouping property
+	// which will cause the restart to take place one logical zone at a time.
+	ZoneRestartGroupAttribute = "__zone__"
 )
 
 var (
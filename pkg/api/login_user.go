@@ -18,26 +18,49 @@
 package api
 
 import (
+	"context"
 	"io"
+	"time"
 
 	"github.com/elastic/cloud-sdk-go/pkg/auth"
 )
 
-// LoginUser logs in a user when its AuthWriter is of type *auth.UserLogin.
-// Additionally, calls the RefreshToken pethod in *auth.UserLogin launching a
+// LoginUser logs in a user when its AuthWriter is of type *auth.UserLogin or
+// *auth.SAMLLogin. When the token holder of an *auth.UserLogin already has a
+// token which hasn't expired, i.e. one persisted by a previous run, it's used
+// instead of logging in again, which *auth.SAMLLogin does on its own.
+// Additionally, calls the RefreshToken method of the AuthWriter launching a
 // background Go routine which will keep the JWT token always valid.
 func LoginUser(instance *API, writer io.Writer) error {
-	aw, ok := instance.AuthWriter.(*auth.UserLogin)
-	if !ok {
-		return nil
+	var params = auth.RefreshTokenParams{
+		Client:      instance.V1API,
+		ErrorDevice: writer,
 	}
 
-	if err := aw.Login(instance.V1API); err != nil {
-		return err
+	switch aw := instance.AuthWriter.(type) {
+	case *auth.UserLogin:
+		if !validToken(aw.Holder) {
+			if err := aw.Login(instance.V1API); err != nil {
+				return err
+			}
+		}
+		return aw.RefreshToken(params)
+	case *auth.SAMLLogin:
+		if err := aw.Login(context.Background(), instance.V1API); err != nil {
+			return err
+		}
+		return aw.RefreshToken(params)
 	}
 
-	return aw.RefreshToken(auth.RefreshTokenParams{
-		Client:      instance.V1API,
-		ErrorDevice: writer,
-	})
+	return nil
+}
+
+// validToken returns true when the holder has a token which hasn't expired.
+func validToken(holder auth.TokenHandler) bool {
+	if holder == nil {
+		return false
+	}
+
+	exp, err := auth.TokenExpiry(holder.Token())
+	return err == nil && time.Now().Before(exp)
 }
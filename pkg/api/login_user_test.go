@@ -19,9 +19,12 @@ package api
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -32,6 +35,16 @@ import (
 	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
 )
 
+// persistedHolder is a TokenHandler which loads a persisted token.
+type persistedHolder struct {
+	auth.GenericHolder
+	persisted string
+}
+
+func (h *persistedHolder) Load() (string, error) {
+	return h.persisted, h.Update(h.persisted)
+}
+
 func TestLoginUser(t *testing.T) {
 	var apiKeyAPI = NewMock()
 
@@ -57,6 +70,45 @@ func TestLoginUser(t *testing.T) {
 		t.Fatal(err)
 	}
 	userLoginSuccess.AuthWriter = success
+
+	var userLoginCached = NewMock()
+	cached, err := auth.NewUserLogin("user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cached.Holder.Update(newExpiringJWT(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	userLoginCached.AuthWriter = cached
+
+	var userLoginExpired = NewMock(mock.New200Response(mock.NewStructBody(models.TokenResponse{
+		Token: ec.String("sometoken"),
+	})))
+	expired, err := auth.NewUserLogin("user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := expired.Holder.Update(newExpiringJWT(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	userLoginExpired.AuthWriter = expired
+
+	var samlLoginCached = NewMock()
+	saml, err := auth.NewSAMLLogin(func(string) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	saml.Holder = &persistedHolder{persisted: newExpiringJWT(time.Hour)}
+	saml.HTTPClient = mock.NewClient()
+	saml.CallbackAddress = "127.0.0.1:8400"
+	samlLoginCached.AuthWriter = saml
+
+	var samlLoginInvalid = NewMock()
+	invalidSAML, err := auth.NewSAMLLogin(func(string) error { return nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	samlLoginInvalid.AuthWriter = invalidSAML
 	type args struct {
 		instance *API
 	}
@@ -67,7 +119,7 @@ func TestLoginUser(t *testing.T) {
 		err        string
 	}{
 		{
-			name: "skips logging in when the AuthWriter isn't *auth.UserLogin",
+			name: "skips logging in when the AuthWriter isn't *auth.UserLogin or *auth.SAMLLogin",
 			args: args{instance: apiKeyAPI},
 		},
 		{
@@ -81,6 +133,26 @@ func TestLoginUser(t *testing.T) {
 			name: "succeeds logging in",
 			args: args{instance: userLoginSuccess},
 		},
+		{
+			name: "skips logging in when the holder has an unexpired token",
+			args: args{instance: userLoginCached},
+		},
+		{
+			name: "logs in when the holder token has expired",
+			args: args{instance: userLoginExpired},
+		},
+		{
+			name: "skips the saml login flow when the holder has an unexpired token",
+			args: args{instance: samlLoginCached},
+		},
+		{
+			name: "fails the saml login due to validation",
+			args: args{instance: samlLoginInvalid},
+			err: multierror.NewPrefixed("auth",
+				errors.New("http client cannot be nil"),
+				errors.New("callback address cannot be empty"),
+			).Error(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -94,4 +166,15 @@ func TestLoginUser(t *testing.T) {
 			}
 		})
 	}
+
+	if got := expired.Holder.Token(); got != "sometoken" {
+		t.Errorf("LoginUser() token = %v, want %v", got, "sometoken")
+	}
+}
+
+// newExpiringJWT returns an unsigned JWT token which expires in d.
+func newExpiringJWT(d time.Duration) string {
+	var payload = fmt.Sprintf(`{"exp":%d}`, time.Now().Add(d).Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
 }
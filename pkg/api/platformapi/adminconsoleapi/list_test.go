@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package adminconsoleapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const adminconsolesPath = "/api/v1/regions/us-east-1/platform/infrastructure/adminconsoles"
+
+func TestList(t *testing.T) {
+	var overview = &models.AdminconsolesOverview{
+		Adminconsoles: []*models.AdminconsoleInfo{
+			{AdminconsoleID: ec.String("192.168.44.10")},
+		},
+	}
+	tests := []struct {
+		name   string
+		params ListParams
+		want   *models.AdminconsolesOverview
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid adminconsole list params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: ListParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: ListParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   adminconsolesPath,
+					},
+					mock.NewStructBody(overview),
+				)),
+				Region: "us-east-1",
+			},
+			want: overview,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := List(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package adminconsoleapi
+
+import (
+	"context"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// ReindexParams is consumed by Reindex.
+type ReindexParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by Reindex.
+func (params ReindexParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid adminconsole reindex params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Reindex triggers a reindex of the admin console data.
+func Reindex(params ReindexParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformInfrastructure.ReindexAdminconsoles(
+			platform_infrastructure.NewReindexAdminconsolesParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)),
+			params.AuthWriter,
+		),
+	)
+}
@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package adminconsoleapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func TestReindex(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ReindexParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid adminconsole reindex params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: ReindexParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: ReindexParams{
+				API: api.NewMock(mock.New202ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   adminconsolesPath + "/_reindex",
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				Region: "us-east-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Reindex(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const (
+	// DefaultWaitForEmptyFrequency is the default frequency at which the
+	// allocator is polled while waiting for it to be empty.
+	DefaultWaitForEmptyFrequency = 10 * time.Second
+
+	// DefaultWaitForEmptyTimeout is the default maximum duration to wait
+	// for an allocator to be empty.
+	DefaultWaitForEmptyTimeout = 30 * time.Minute
+)
+
+// WaitForEmptyParams is used to wait for an allocator to have no instances.
+type WaitForEmptyParams struct {
+	*api.API
+	ID     string
+	Region string
+
+	// Frequency at which the allocator is polled, defaults to
+	// DefaultWaitForEmptyFrequency.
+	Frequency time.Duration
+
+	// Maximum duration to wait, defaults to DefaultWaitForEmptyTimeout.
+	Timeout time.Duration
+}
+
+// Validate ensures that the parameters are correct
+func (params WaitForEmptyParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator wait params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errors.New("id cannot be empty"))
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// WaitForEmpty polls the allocator until it has no instances or the timeout
+// is reached.
+func WaitForEmpty(params WaitForEmptyParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if params.Frequency <= 0 {
+		params.Frequency = DefaultWaitForEmptyFrequency
+	}
+	if params.Timeout <= 0 {
+		params.Timeout = DefaultWaitForEmptyTimeout
+	}
+
+	var deadline = time.Now().Add(params.Timeout)
+	for {
+		allocator, err := Get(GetParams{API: params.API, ID: params.ID, Region: params.Region})
+		if err != nil {
+			return err
+		}
+
+		var n = len(allocator.Instances)
+		if n == 0 {
+			return nil
+		}
+
+		if time.Now().Add(params.Frequency).After(deadline) {
+			return fmt.Errorf(
+				"allocator %s still has %d instances after %s", params.ID, n, params.Timeout,
+			)
+		}
+
+		<-time.After(params.Frequency)
+	}
+}
+
+// DecommissionParams is used to decommission an allocator.
+type DecommissionParams struct {
+	*api.API
+	ID     string
+	Region string
+
+	// Parameters used to vacate the allocator. Its API, Region and
+	// Allocators fields are ignored and set from the decommission params.
+	// Concurrency defaults to 1 when unset. They're only validated when the
+	// allocator has instances to vacate.
+	Vacate VacateParams
+
+	// Parameters used to wait for the allocator to be empty once vacated.
+	WaitFrequency time.Duration
+	WaitTimeout   time.Duration
+}
+
+func (params DecommissionParams) vacateParams() *VacateParams {
+	var vacate = params.Vacate
+	vacate.API = params.API
+	vacate.Region = params.Region
+	vacate.Allocators = []string{params.ID}
+	if vacate.Concurrency == 0 {
+		vacate.Concurrency = 1
+	}
+	return &vacate
+}
+
+// Validate ensures that the parameters are correct
+func (params DecommissionParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator decommission params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errors.New("id cannot be empty"))
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Decommission removes an allocator from the platform by setting it to
+// maintenance mode, vacating all of its instances, waiting for it to be
+// empty and finally deleting it. When any of the steps fail the flow stops
+// and the allocator is left in maintenance mode, so it doesn't receive new
+// instances; the flow can be safely retried.
+func Decommission(params DecommissionParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	var step = func(name string, err error) error {
+		if err == nil {
+			return nil
+		}
+		return multierror.NewPrefixed(
+			fmt.Sprintf("allocator %s decommission: %s", params.ID, name), err,
+		)
+	}
+
+	if err := StartMaintenance(MaintenanceParams{
+		API: params.API, ID: params.ID, Region: params.Region,
+	}); err != nil {
+		return step("maintenance mode", err)
+	}
+
+	allocator, err := Get(GetParams{API: params.API, ID: params.ID, Region: params.Region})
+	if err != nil {
+		return step("get", err)
+	}
+
+	if len(allocator.Instances) > 0 {
+		if err := Vacate(params.vacateParams()); err != nil {
+			return step("vacate", err)
+		}
+
+		if err := WaitForEmpty(WaitForEmptyParams{
+			API:       params.API,
+			ID:        params.ID,
+			Region:    params.Region,
+			Frequency: params.WaitFrequency,
+			Timeout:   params.WaitTimeout,
+		}); err != nil {
+			return step("wait for empty", err)
+		}
+	}
+
+	return step("delete", Delete(DeleteParams{
+		API: params.API, ID: params.ID, Region: params.Region,
+	}))
+}
@@ -0,0 +1,230 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+)
+
+func TestWaitForEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		params WaitForEmptyParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid allocator wait params",
+				apierror.ErrMissingAPI,
+				errors.New("id cannot be empty"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when the allocator cannot be obtained",
+			params: WaitForEmptyParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "times out when the allocator still has instances",
+			params: WaitForEmptyParams{
+				API: api.NewMock(
+					allocatorResponse(2),
+					allocatorResponse(1),
+				),
+				ID:        "i-09a0e797fb3af6864",
+				Region:    "us-east-1",
+				Frequency: time.Millisecond,
+				Timeout:   time.Millisecond + time.Millisecond/2,
+			},
+			err: "allocator i-09a0e797fb3af6864 still has 1 instances after 1.5ms",
+		},
+		{
+			name: "returns once the allocator is empty",
+			params: WaitForEmptyParams{
+				API: api.NewMock(
+					allocatorResponse(2),
+					allocatorResponse(1),
+					allocatorResponse(0),
+				),
+				ID:        "i-09a0e797fb3af6864",
+				Region:    "us-east-1",
+				Frequency: time.Millisecond,
+				Timeout:   time.Minute,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WaitForEmpty(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDecommission(t *testing.T) {
+	var maintenanceResponse = func() mock.Response {
+		return mock.New202ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "POST",
+				Path:   allocatorPath + "/maintenance-mode/_start",
+			},
+			mock.NewStringBody(`{}`),
+		)
+	}
+	var moveResponse = func() mock.Response {
+		return mock.New202ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "POST",
+				Path:   allocatorPath + "/clusters/_move",
+				Query:  map[string][]string{"validate_only": {"true"}},
+			},
+			mock.NewStructBody(models.MoveClustersDetails{}),
+		)
+	}
+	var vacate = VacateParams{Output: output.NewDevice(io.Discard)}
+
+	tests := []struct {
+		name   string
+		params DecommissionParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid allocator decommission params",
+				apierror.ErrMissingAPI,
+				errors.New("id cannot be empty"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "stops when maintenance mode cannot be set",
+			params: DecommissionParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+				Vacate: vacate,
+			},
+			err: multierror.NewPrefixed(
+				"allocator i-09a0e797fb3af6864 decommission: maintenance mode",
+				mock.MultierrorNotFound,
+			).Error(),
+		},
+		{
+			name: "stops when the allocator isn't empty after vacating it",
+			params: DecommissionParams{
+				API: api.NewMock(
+					maintenanceResponse(),
+					allocatorResponse(1),
+					moveResponse(),
+					allocatorResponse(1),
+				),
+				ID:            "i-09a0e797fb3af6864",
+				Region:        "us-east-1",
+				Vacate:        vacate,
+				WaitFrequency: time.Millisecond,
+				WaitTimeout:   time.Millisecond,
+			},
+			err: multierror.NewPrefixed(
+				"allocator i-09a0e797fb3af6864 decommission: wait for empty",
+				errors.New("allocator i-09a0e797fb3af6864 still has 1 instances after 1ms"),
+			).Error(),
+		},
+		{
+			name: "vacates, waits and deletes the allocator",
+			params: DecommissionParams{
+				API: api.NewMock(
+					maintenanceResponse(),
+					allocatorResponse(1),
+					moveResponse(),
+					allocatorResponse(0),
+					allocatorResponse(0),
+					deleteAllocatorResponse("false"),
+				),
+				ID:            "i-09a0e797fb3af6864",
+				Region:        "us-east-1",
+				Vacate:        vacate,
+				WaitFrequency: time.Millisecond,
+			},
+		},
+		{
+			name: "stops when the vacate params are invalid",
+			params: DecommissionParams{
+				API: api.NewMock(
+					maintenanceResponse(),
+					allocatorResponse(1),
+				),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed(
+				"allocator i-09a0e797fb3af6864 decommission: vacate",
+				multierror.NewPrefixed("invalid allocator vacate params",
+					errOutputDeviceCannotBeNil,
+				),
+			).Error(),
+		},
+		{
+			name: "deletes an already empty allocator without vacate params",
+			params: DecommissionParams{
+				API: api.NewMock(
+					maintenanceResponse(),
+					allocatorResponse(0),
+					allocatorResponse(0),
+					deleteAllocatorResponse("false"),
+				),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Decommission(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// DeleteParams is used to delete an allocator.
+type DeleteParams struct {
+	*api.API
+	ID     string
+	Region string
+
+	// Deletes the allocator even when it still has instances, which are
+	// removed along with the allocator.
+	Force bool
+}
+
+// Validate ensures that the parameters are correct
+func (params DeleteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator delete params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errors.New("id cannot be empty"))
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Delete deletes an allocator. Unless Force is set, the allocator must not
+// have any instances, otherwise it's not deleted and an error is returned.
+func Delete(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if !params.Force {
+		allocator, err := Get(GetParams{API: params.API, ID: params.ID, Region: params.Region})
+		if err != nil {
+			return err
+		}
+
+		if n := len(allocator.Instances); n > 0 {
+			return fmt.Errorf(
+				"allocator %s has %d instances: vacate the allocator or force the deletion",
+				params.ID, n,
+			)
+		}
+	}
+
+	return api.ReturnErrOnly(
+		params.API.V1API.PlatformInfrastructure.DeleteAllocator(
+			platform_infrastructure.NewDeleteAllocatorParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithAllocatorID(params.ID).
+				WithRemoveInstances(ec.Bool(params.Force)),
+			params.AuthWriter,
+		),
+	)
+}
@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func allocatorResponse(instances int) mock.Response {
+	var allocator = models.AllocatorInfo{AllocatorID: ec.String("i-09a0e797fb3af6864")}
+	for i := 0; i < instances; i++ {
+		allocator.Instances = append(allocator.Instances, &models.AllocatedInstanceStatus{
+			ClusterID: ec.String(mock.ValidClusterID),
+		})
+	}
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   allocatorPath,
+		},
+		mock.NewStructBody(allocator),
+	)
+}
+
+func deleteAllocatorResponse(removeInstances string) mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultWriteMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "DELETE",
+			Path:   allocatorPath,
+			Query:  map[string][]string{"remove_instances": {removeInstances}},
+		},
+		mock.NewStringBody(`{}`),
+	)
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DeleteParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid allocator delete params",
+				apierror.ErrMissingAPI,
+				errors.New("id cannot be empty"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when the allocator cannot be obtained",
+			params: DeleteParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "refuses to delete an allocator with instances",
+			params: DeleteParams{
+				API:    api.NewMock(allocatorResponse(2)),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+			err: "allocator i-09a0e797fb3af6864 has 2 instances: vacate the allocator or force the deletion",
+		},
+		{
+			name: "deletes an empty allocator",
+			params: DeleteParams{
+				API: api.NewMock(
+					allocatorResponse(0),
+					deleteAllocatorResponse("false"),
+				),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+		},
+		{
+			name: "deletes an allocator and its instances when forced",
+			params: DeleteParams{
+				API:    api.NewMock(deleteAllocatorResponse("true")),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+				Force:  true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Delete(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+	"github.com/elastic/cloud-sdk-go/pkg/sync/pool"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+// DefaultTargetFill is the default maximum memory fill percentage used when
+// planning a rebalance.
+const DefaultTargetFill = 80
+
+var (
+	errInvalidTargetFill = errors.New("target fill must be between 0 and 100")
+	errPlanCannotBeNil   = errors.New("plan cannot be nil")
+
+	// rebalanceKinds are the resource kinds which can be moved.
+	rebalanceKinds = []string{
+		util.Elasticsearch, util.Kibana, util.Apm, util.Appsearch, util.EnterpriseSearch,
+	}
+)
+
+// PlanRebalanceParams is consumed by PlanRebalance.
+type PlanRebalanceParams struct {
+	*api.API
+
+	Region string
+
+	// Optional Elasticsearch search query to restrict the allocators.
+	Query string
+
+	// Optional filter tags with expected format: key:value slice. i.e.
+	// [key:val, key:value].
+	FilterTags string
+
+	// Maximum memory fill percentage which every allocator should be under
+	// after the rebalance. Defaults to DefaultTargetFill.
+	TargetFill float64
+
+	// Optional list of allocators to be used as move targets. When empty,
+	// any healthy allocator in the same zone can be a target.
+	PreferredAllocators []string
+}
+
+// Validate ensures the parameters are usable by PlanRebalance.
+func (params PlanRebalanceParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator rebalance params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.TargetFill < 0 || params.TargetFill > 100 {
+		merr = merr.Append(errInvalidTargetFill)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// RebalancePlan contains the moves proposed to bring every allocator under
+// the target fill percentage.
+type RebalancePlan struct {
+	TargetFill float64         `json:"target_fill"`
+	Zones      []ZoneRebalance `json:"zones"`
+}
+
+// Moves returns the moves of all the zones.
+func (p *RebalancePlan) Moves() []RebalanceMove {
+	var moves []RebalanceMove
+	for _, zone := range p.Zones {
+		moves = append(moves, zone.Moves...)
+	}
+	return moves
+}
+
+// ZoneRebalance is the rebalance of the allocators within a zone.
+type ZoneRebalance struct {
+	ZoneID     string          `json:"zone_id"`
+	Allocators []AllocatorFill `json:"allocators"`
+	Moves      []RebalanceMove `json:"moves"`
+
+	// Allocators which remain over the target fill after the moves.
+	Unbalanced []string `json:"unbalanced,omitempty"`
+}
+
+// Skewed returns true when any of the zone allocators is over the target.
+func (z ZoneRebalance) Skewed() bool {
+	return len(z.Moves) > 0 || len(z.Unbalanced) > 0
+}
+
+// AllocatorFill is the memory utilization of an allocator before and after
+// the planned moves, the fill values are percentages.
+type AllocatorFill struct {
+	AllocatorID string  `json:"allocator_id"`
+	Total       int64   `json:"total"`
+	Used        int64   `json:"used"`
+	Planned     int64   `json:"planned"`
+	Before      float64 `json:"before"`
+	After       float64 `json:"after"`
+}
+
+// RebalanceMove is a proposed move of the resource instances from one
+// allocator to another.
+type RebalanceMove struct {
+	ZoneID     string `json:"zone_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	ResourceID string `json:"resource_id"`
+	Kind       string `json:"kind"`
+
+	// Memory in MB used by the resource instances on the source allocator.
+	Memory int64 `json:"memory"`
+}
+
+// rebalanceAllocator tracks the planned memory of an allocator.
+type rebalanceAllocator struct {
+	id        string
+	total     int64
+	used      int64
+	planned   int64
+	target    bool
+	resources map[string]bool
+	units     []rebalanceUnit
+}
+
+func (a *rebalanceAllocator) fill(used int64) float64 {
+	return float64(used) * 100 / float64(a.total)
+}
+
+// rebalanceUnit is the set of instances of a resource on an allocator,
+// which are moved together.
+type rebalanceUnit struct {
+	id     string
+	kind   string
+	memory int64
+}
+
+// PlanRebalance detects the allocators which are over the target memory fill
+// percentage and proposes a minimal set of resource moves to allocators in
+// the same zone, so every allocator ends under the target fill. Larger
+// resources are moved first to minimise the number of moves, and a resource
+// is never moved to an allocator which already hosts one of its instances.
+// The resulting plan can be executed with ExecuteRebalance.
+func PlanRebalance(params PlanRebalanceParams) (*RebalancePlan, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.TargetFill == 0 {
+		params.TargetFill = DefaultTargetFill
+	}
+
+	res, err := List(ListParams{
+		API:        params.API,
+		Region:     params.Region,
+		Query:      params.Query,
+		FilterTags: params.FilterTags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var plan = RebalancePlan{
+		TargetFill: params.TargetFill,
+		Zones:      make([]ZoneRebalance, 0, len(res.Zones)),
+	}
+	for _, zone := range res.Zones {
+		var zoneID string
+		if zone.ZoneID != nil {
+			zoneID = *zone.ZoneID
+		}
+		plan.Zones = append(plan.Zones, planZoneRebalance(params, zoneID, zone.Allocators))
+	}
+
+	sort.Slice(plan.Zones, func(i, j int) bool {
+		return plan.Zones[i].ZoneID < plan.Zones[j].ZoneID
+	})
+
+	return &plan, nil
+}
+
+func planZoneRebalance(params PlanRebalanceParams, zoneID string, allocators []*models.AllocatorInfo) ZoneRebalance {
+	var zone = ZoneRebalance{ZoneID: zoneID, Moves: make([]RebalanceMove, 0)}
+	var allocs = newRebalanceAllocators(allocators, params.PreferredAllocators)
+
+	// Start with the fullest allocators.
+	var sources = make([]*rebalanceAllocator, len(allocs))
+	copy(sources, allocs)
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].fill(sources[i].used) > sources[j].fill(sources[j].used)
+	})
+
+	for _, source := range sources {
+		for _, unit := range source.units {
+			if source.fill(source.planned) <= params.TargetFill {
+				break
+			}
+
+			target := rebalanceTarget(allocs, source, unit, params.TargetFill)
+			if target == nil {
+				continue
+			}
+
+			source.planned -= unit.memory
+			target.planned += unit.memory
+			target.resources[unit.id] = true
+			zone.Moves = append(zone.Moves, RebalanceMove{
+				ZoneID:     zoneID,
+				From:       source.id,
+				To:         target.id,
+				ResourceID: unit.id,
+				Kind:       unit.kind,
+				Memory:     unit.memory,
+			})
+		}
+
+		if source.fill(source.planned) > params.TargetFill {
+			zone.Unbalanced = append(zone.Unbalanced, source.id)
+		}
+	}
+
+	zone.Allocators = make([]AllocatorFill, 0, len(allocs))
+	for _, alloc := range allocs {
+		zone.Allocators = append(zone.Allocators, AllocatorFill{
+			AllocatorID: alloc.id,
+			Total:       alloc.total,
+			Used:        alloc.used,
+			Planned:     alloc.planned,
+			Before:      alloc.fill(alloc.used),
+			After:       alloc.fill(alloc.planned),
+		})
+	}
+	sort.Strings(zone.Unbalanced)
+
+	return zone
+}
+
+// rebalanceTarget returns the least utilized allocator which can receive the
+// unit without going over the target fill, or nil when there's none.
+func rebalanceTarget(allocs []*rebalanceAllocator, source *rebalanceAllocator, unit rebalanceUnit, targetFill float64) *rebalanceAllocator {
+	var target *rebalanceAllocator
+	for _, alloc := range allocs {
+		if alloc == source || !alloc.target || alloc.resources[unit.id] {
+			continue
+		}
+
+		if alloc.fill(alloc.planned+unit.memory) > targetFill {
+			continue
+		}
+
+		if target == nil || alloc.fill(alloc.planned) < target.fill(target.planned) {
+			target = alloc
+		}
+	}
+	return target
+}
+
+// newRebalanceAllocators returns the allocators with a known memory capacity
+// sorted by ID. Only healthy and connected allocators which aren't in
+// maintenance mode and are part of the preferred allocators (when set) are
+// considered as targets.
+func newRebalanceAllocators(allocators []*models.AllocatorInfo, preferred []string) []*rebalanceAllocator {
+	var allocs = make([]*rebalanceAllocator, 0, len(allocators))
+	for _, a := range allocators {
+		if a.AllocatorID == nil || a.Capacity == nil || a.Capacity.Memory == nil ||
+			a.Capacity.Memory.Total == nil || *a.Capacity.Memory.Total <= 0 {
+			continue
+		}
+
+		var alloc = rebalanceAllocator{
+			id:        *a.AllocatorID,
+			total:     int64(*a.Capacity.Memory.Total),
+			resources: make(map[string]bool),
+		}
+		if a.Capacity.Memory.Used != nil {
+			alloc.used = int64(*a.Capacity.Memory.Used)
+		}
+		alloc.planned = alloc.used
+
+		if a.Status != nil {
+			alloc.target = a.Status.Connected != nil && *a.Status.Connected &&
+				a.Status.Healthy != nil && *a.Status.Healthy &&
+				(a.Status.MaintenanceMode == nil || !*a.Status.MaintenanceMode)
+		}
+		if len(preferred) > 0 && !slice.HasString(preferred, alloc.id) {
+			alloc.target = false
+		}
+
+		var units = make(map[string]*rebalanceUnit)
+		for _, instance := range a.Instances {
+			if instance.ClusterID == nil {
+				continue
+			}
+			alloc.resources[*instance.ClusterID] = true
+
+			if instance.ClusterType == nil || !slice.HasString(rebalanceKinds, *instance.ClusterType) {
+				continue
+			}
+			if instance.Moving != nil && *instance.Moving {
+				continue
+			}
+
+			unit, ok := units[*instance.ClusterID]
+			if !ok {
+				unit = &rebalanceUnit{id: *instance.ClusterID, kind: *instance.ClusterType}
+				units[*instance.ClusterID] = unit
+			}
+			if instance.NodeMemory != nil {
+				unit.memory += int64(*instance.NodeMemory)
+			}
+		}
+
+		for _, unit := range units {
+			alloc.units = append(alloc.units, *unit)
+		}
+		sort.Slice(alloc.units, func(i, j int) bool {
+			if alloc.units[i].memory == alloc.units[j].memory {
+				return alloc.units[i].id < alloc.units[j].id
+			}
+			return alloc.units[i].memory > alloc.units[j].memory
+		})
+
+		allocs = append(allocs, &alloc)
+	}
+
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i].id < allocs[j].id })
+	return allocs
+}
+
+// ExecuteRebalanceParams is consumed by ExecuteRebalance.
+type ExecuteRebalanceParams struct {
+	*api.API
+
+	Region string
+
+	// Plan obtained from PlanRebalance.
+	Plan *RebalancePlan
+
+	// Maximum number of concurrent moves at any time.
+	Concurrency uint16
+
+	// Output device where the progress will be sent.
+	Output *output.Device
+
+	// OutputFormat to use
+	OutputFormat string
+
+	// Maximum number of errors to allow the plan status poller to tolerate.
+	MaxPollRetries uint8
+
+	// Poll frequency
+	TrackFrequency time.Duration
+
+	// Optional value to be set to the pool on construction.
+	PoolTimeout pool.Timeout
+
+	// SkipTracking skips waiting for the individual moves to complete.
+	SkipTracking bool
+
+	// Optional progress where the status of the moves is tracked.
+	Progress *pool.Progress
+}
+
+// Validate ensures the parameters are usable by ExecuteRebalance.
+func (params ExecuteRebalanceParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator rebalance params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Plan == nil {
+		merr = merr.Append(errPlanCannotBeNil)
+	}
+
+	if params.Concurrency == 0 {
+		merr = merr.Append(errConcurrencyCannotBeZero)
+	}
+
+	if params.Output == nil {
+		merr = merr.Append(errOutputDeviceCannotBeNil)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// ExecuteRebalance performs the moves of a rebalance plan, moving each
+// resource away from its source allocator with the planned target set as the
+// preferred allocator.
+// The maximum concurrent moves is controlled by the Concurrency parameter.
+func ExecuteRebalance(params ExecuteRebalanceParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	var emptyTimeout pool.Timeout
+	if params.PoolTimeout == emptyTimeout {
+		params.PoolTimeout = pool.DefaultTimeout
+	}
+
+	p, err := pool.NewPool(pool.Params{
+		Size:     params.Concurrency,
+		Run:      VacateClusterInPool,
+		Timeout:  params.PoolTimeout,
+		Writer:   params.Output,
+		Progress: params.Progress,
+	})
+	if err != nil {
+		return err
+	}
+
+	var moves = params.Plan.Moves()
+	var work = make([]pool.Validator, 0, len(moves))
+	for _, move := range moves {
+		work = append(work, &VacateClusterParams{
+			API:                 params.API,
+			Region:              params.Region,
+			ID:                  move.From,
+			ClusterID:           move.ResourceID,
+			Kind:                move.Kind,
+			PreferredAllocators: []string{move.To},
+			AllocatorDown:       ec.Bool(false),
+			Output:              params.Output,
+			OutputFormat:        params.OutputFormat,
+			MaxPollRetries:      params.MaxPollRetries,
+			TrackFrequency:      params.TrackFrequency,
+			SkipTracking:        params.SkipTracking,
+		})
+	}
+
+	leftovers, _ := p.Add(work...)
+	if err := p.Start(); err != nil {
+		return err
+	}
+
+	for len(leftovers) > 0 {
+		leftovers, _ = p.Add(leftovers...)
+	}
+
+	var merr = multierror.NewPrefixed("rebalance error")
+	if err := waitVacateCompletion(p, len(work) > 0); err != nil {
+		unpackMultierror(merr, err)
+	}
+
+	return multierror.WithFormat(merr.ErrorOrNil(), params.OutputFormat)
+}
@@ -0,0 +1,332 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const (
+	rebalanceES1    = "1ee11eb40eda22cac0cce259625c6734"
+	rebalanceES2    = "2ee11eb40eda22cac0cce259625c6734"
+	rebalanceKibana = "3ee11eb40eda22cac0cce259625c6734"
+	rebalanceApm    = "4ee11eb40eda22cac0cce259625c6734"
+)
+
+func newRebalanceAllocator(id string, total, used int32, status *models.AllocatorHealthStatus, instances ...*models.AllocatedInstanceStatus) *models.AllocatorInfo {
+	if status == nil {
+		status = &models.AllocatorHealthStatus{
+			Connected:       ec.Bool(true),
+			Healthy:         ec.Bool(true),
+			MaintenanceMode: ec.Bool(false),
+		}
+	}
+	return &models.AllocatorInfo{
+		AllocatorID: ec.String(id),
+		Capacity: &models.AllocatorCapacity{Memory: &models.AllocatorCapacityMemory{
+			Total: ec.Int32(total), Used: ec.Int32(used),
+		}},
+		Status:    status,
+		Instances: instances,
+	}
+}
+
+func newRebalanceInstance(id, kind string, memory int32) *models.AllocatedInstanceStatus {
+	return &models.AllocatedInstanceStatus{
+		ClusterID:   ec.String(id),
+		ClusterType: ec.String(kind),
+		NodeMemory:  ec.Int32(memory),
+	}
+}
+
+func rebalanceListResponse() mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators",
+		},
+		mock.NewStructBody(models.AllocatorOverview{Zones: []*models.AllocatorZoneInfo{
+			{
+				ZoneID: ec.String("zone-2"),
+				Allocators: []*models.AllocatorInfo{
+					newRebalanceAllocator("i-e", 1000, 950, nil,
+						newRebalanceInstance(rebalanceApm, "apm", 950),
+					),
+				},
+			},
+			{
+				ZoneID: ec.String("zone-1"),
+				Allocators: []*models.AllocatorInfo{
+					newRebalanceAllocator("i-d", 10000, 0, &models.AllocatorHealthStatus{
+						Connected:       ec.Bool(true),
+						Healthy:         ec.Bool(true),
+						MaintenanceMode: ec.Bool(true),
+					}),
+					newRebalanceAllocator("i-a", 10000, 9000, nil,
+						newRebalanceInstance(rebalanceES1, "elasticsearch", 2000),
+						newRebalanceInstance(rebalanceES1, "elasticsearch", 1000),
+						newRebalanceInstance(rebalanceKibana, "kibana", 1000),
+						newRebalanceInstance(rebalanceES2, "elasticsearch", 5000),
+					),
+					newRebalanceAllocator("i-c", 10000, 5000, nil),
+					newRebalanceAllocator("i-b", 10000, 2000, nil,
+						newRebalanceInstance(rebalanceES2, "elasticsearch", 2000),
+					),
+				},
+			},
+		}}),
+	)
+}
+
+func TestPlanRebalance(t *testing.T) {
+	var zone2 = ZoneRebalance{
+		ZoneID: "zone-2",
+		Allocators: []AllocatorFill{
+			{AllocatorID: "i-e", Total: 1000, Used: 950, Planned: 950, Before: 95, After: 95},
+		},
+		Moves:      []RebalanceMove{},
+		Unbalanced: []string{"i-e"},
+	}
+
+	tests := []struct {
+		name   string
+		params PlanRebalanceParams
+		want   *RebalancePlan
+		err    string
+	}{
+		{
+			name:   "fails due to parameter validation",
+			params: PlanRebalanceParams{TargetFill: 120},
+			err: multierror.NewPrefixed("invalid allocator rebalance params",
+				apierror.ErrMissingAPI,
+				errInvalidTargetFill,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: PlanRebalanceParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds with the default target fill",
+			params: PlanRebalanceParams{
+				API:    api.NewMock(rebalanceListResponse()),
+				Region: "us-east-1",
+			},
+			want: &RebalancePlan{
+				TargetFill: DefaultTargetFill,
+				Zones: []ZoneRebalance{
+					{
+						ZoneID: "zone-1",
+						Allocators: []AllocatorFill{
+							{AllocatorID: "i-a", Total: 10000, Used: 9000, Planned: 6000, Before: 90, After: 60},
+							{AllocatorID: "i-b", Total: 10000, Used: 2000, Planned: 5000, Before: 20, After: 50},
+							{AllocatorID: "i-c", Total: 10000, Used: 5000, Planned: 5000, Before: 50, After: 50},
+							{AllocatorID: "i-d", Total: 10000},
+						},
+						Moves: []RebalanceMove{{
+							ZoneID: "zone-1", From: "i-a", To: "i-b",
+							ResourceID: rebalanceES1, Kind: "elasticsearch", Memory: 3000,
+						}},
+					},
+					zone2,
+				},
+			},
+		},
+		{
+			name: "succeeds with preferred allocators",
+			params: PlanRebalanceParams{
+				API:                 api.NewMock(rebalanceListResponse()),
+				Region:              "us-east-1",
+				PreferredAllocators: []string{"i-c"},
+			},
+			want: &RebalancePlan{
+				TargetFill: DefaultTargetFill,
+				Zones: []ZoneRebalance{
+					{
+						ZoneID: "zone-1",
+						Allocators: []AllocatorFill{
+							{AllocatorID: "i-a", Total: 10000, Used: 9000, Planned: 6000, Before: 90, After: 60},
+							{AllocatorID: "i-b", Total: 10000, Used: 2000, Planned: 2000, Before: 20, After: 20},
+							{AllocatorID: "i-c", Total: 10000, Used: 5000, Planned: 8000, Before: 50, After: 80},
+							{AllocatorID: "i-d", Total: 10000},
+						},
+						Moves: []RebalanceMove{{
+							ZoneID: "zone-1", From: "i-a", To: "i-c",
+							ResourceID: rebalanceES1, Kind: "elasticsearch", Memory: 3000,
+						}},
+					},
+					zone2,
+				},
+			},
+		},
+		{
+			name: "succeeds with a lower target fill",
+			params: PlanRebalanceParams{
+				API:        api.NewMock(rebalanceListResponse()),
+				Region:     "us-east-1",
+				TargetFill: 50,
+			},
+			want: &RebalancePlan{
+				TargetFill: 50,
+				Zones: []ZoneRebalance{
+					{
+						ZoneID: "zone-1",
+						Allocators: []AllocatorFill{
+							{AllocatorID: "i-a", Total: 10000, Used: 9000, Planned: 6000, Before: 90, After: 60},
+							{AllocatorID: "i-b", Total: 10000, Used: 2000, Planned: 5000, Before: 20, After: 50},
+							{AllocatorID: "i-c", Total: 10000, Used: 5000, Planned: 5000, Before: 50, After: 50},
+							{AllocatorID: "i-d", Total: 10000},
+						},
+						Moves: []RebalanceMove{
+							{
+								ZoneID: "zone-1", From: "i-a", To: "i-b",
+								ResourceID: rebalanceES1, Kind: "elasticsearch", Memory: 3000,
+							},
+						},
+						Unbalanced: []string{"i-a"},
+					},
+					zone2,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanRebalance(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExecuteRebalance(t *testing.T) {
+	var plan = &RebalancePlan{
+		TargetFill: DefaultTargetFill,
+		Zones: []ZoneRebalance{{
+			ZoneID: "zone-1",
+			Moves: []RebalanceMove{{
+				ZoneID: "zone-1", From: "i-a", To: "i-b",
+				ResourceID: rebalanceES1, Kind: "elasticsearch", Memory: 3000,
+			}},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		params ExecuteRebalanceParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid allocator rebalance params",
+				apierror.ErrMissingAPI,
+				errPlanCannotBeNil,
+				errConcurrencyCannotBeZero,
+				errOutputDeviceCannotBeNil,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "succeeds with an empty plan",
+			params: ExecuteRebalanceParams{
+				API:         api.NewMock(),
+				Region:      "us-east-1",
+				Plan:        &RebalancePlan{},
+				Concurrency: 1,
+				Output:      output.NewDevice(new(bytes.Buffer)),
+			},
+		},
+		{
+			name: "moves the resources to the planned targets",
+			params: ExecuteRebalanceParams{
+				API: api.NewMock(
+					mock.New202ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "POST",
+							Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators/i-a/clusters/_move",
+							Query: url.Values{
+								"allocator_down": {"false"},
+								"validate_only":  {"true"},
+							},
+							Body: mock.NewStructBody(models.MoveClustersRequest{
+								ElasticsearchClusters: []*models.MoveElasticsearchClusterConfiguration{{
+									ClusterIds: []string{rebalanceES1},
+								}},
+							}),
+						},
+						newElasticsearchMove(t, rebalanceES1, "i-a"),
+					),
+					mock.New202ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "POST",
+							Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators/i-a/clusters/elasticsearch/_move",
+							Query:  url.Values{"allocator_down": {"false"}},
+							Body: mock.NewStringBody(`{"apm_clusters":null,"appsearch_clusters":null,"elasticsearch_clusters":[{"cluster_ids":["` +
+								rebalanceES1 + `"],"plan_override":{"plan_configuration":{"move_allocators":[{"from":"i-a","to":null}],"move_instances":null,"preferred_allocators":["i-b"]}}}],"enterprise_search_clusters":null,"kibana_clusters":null}` + "\n",
+							),
+						},
+						newElasticsearchMove(t, rebalanceES1, "i-a"),
+					),
+				),
+				Region:       "us-east-1",
+				Plan:         plan,
+				Concurrency:  1,
+				Output:       output.NewDevice(new(bytes.Buffer)),
+				SkipTracking: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ExecuteRebalance(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errSettingsCannotBeNil = errors.New("settings cannot be nil")
+
+// SettingsParams is used to obtain an allocator's settings.
+type SettingsParams struct {
+	*api.API
+	ID     string
+	Region string
+}
+
+// Validate ensures that the parameters are correct
+func (params SettingsParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator settings params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errors.New("id cannot be empty"))
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// GetSettings obtains an allocator's settings along with their version, which
+// can be used to update the settings with optimistic concurrency control.
+func GetSettings(params SettingsParams) (*models.AllocatorSettings, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	res, err := params.API.V1API.PlatformInfrastructure.GetAllocatorSettings(
+		platform_infrastructure.NewGetAllocatorSettingsParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithAllocatorID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// SetSettingsParams is used to replace or patch an allocator's settings.
+type SetSettingsParams struct {
+	*api.API
+	ID       string
+	Region   string
+	Settings *models.AllocatorSettings
+
+	// Optional version as returned by GetSettings. When set, the settings are
+	// only changed if they haven't been modified since they were obtained.
+	Version string
+}
+
+// Validate ensures that the parameters are correct
+func (params SetSettingsParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator settings params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errors.New("id cannot be empty"))
+	}
+
+	if params.Settings == nil {
+		merr = merr.Append(errSettingsCannotBeNil)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func (params SetSettingsParams) version() *string {
+	if params.Version == "" {
+		return nil
+	}
+	return ec.String(params.Version)
+}
+
+// SetSettings replaces an allocator's settings.
+func SetSettings(params SetSettingsParams) (*models.AllocatorSettings, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.API.V1API.PlatformInfrastructure.SetAllocatorSettings(
+		platform_infrastructure.NewSetAllocatorSettingsParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithAllocatorID(params.ID).
+			WithVersion(params.version()).
+			WithBody(params.Settings),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// UpdateSettings patches an allocator's settings, only the non empty fields
+// of the specified settings are changed.
+func UpdateSettings(params SetSettingsParams) (*models.AllocatorSettings, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(params.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := params.API.V1API.PlatformInfrastructure.UpdateAllocatorSettings(
+		platform_infrastructure.NewUpdateAllocatorSettingsParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithAllocatorID(params.ID).
+			WithVersion(params.version()).
+			WithBody(string(body)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
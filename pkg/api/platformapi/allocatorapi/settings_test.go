@@ -0,0 +1,210 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+const allocatorPath = "/api/v1/regions/us-east-1/platform/infrastructure/allocators/i-09a0e797fb3af6864"
+
+func TestGetSettings(t *testing.T) {
+	var settings = &models.AllocatorSettings{Capacity: 8192}
+	tests := []struct {
+		name        string
+		params      SettingsParams
+		want        *models.AllocatorSettings
+		wantVersion string
+		err         string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid allocator settings params",
+				apierror.ErrMissingAPI,
+				errors.New("id cannot be empty"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: SettingsParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: SettingsParams{
+				API: api.NewMock(mock.Response{
+					Response: http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"X-Cloud-Resource-Version": {"5"}},
+						Body:       mock.NewStructBody(settings),
+					},
+					Assert: &mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   allocatorPath + "/settings",
+					},
+				}),
+				ID:     "i-09a0e797fb3af6864",
+				Region: "us-east-1",
+			},
+			want:        settings,
+			wantVersion: "5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, version, err := GetSettings(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestSetSettings(t *testing.T) {
+	var settings = &models.AllocatorSettings{Capacity: 8192}
+	tests := []struct {
+		name   string
+		params SetSettingsParams
+		want   *models.AllocatorSettings
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid allocator settings params",
+				apierror.ErrMissingAPI,
+				errors.New("id cannot be empty"),
+				errSettingsCannotBeNil,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: SetSettingsParams{
+				API:      api.NewMock(mock.SampleNotFoundError()),
+				ID:       "i-09a0e797fb3af6864",
+				Region:   "us-east-1",
+				Settings: settings,
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds with version",
+			params: SetSettingsParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "PUT",
+						Path:   allocatorPath + "/settings",
+						Query:  map[string][]string{"version": {"5"}},
+						Body:   mock.NewStructBody(settings),
+					},
+					mock.NewStructBody(settings),
+				)),
+				ID:       "i-09a0e797fb3af6864",
+				Region:   "us-east-1",
+				Settings: settings,
+				Version:  "5",
+			},
+			want: settings,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetSettings(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	var settings = &models.AllocatorSettings{Capacity: 16384}
+	tests := []struct {
+		name   string
+		params SetSettingsParams
+		want   *models.AllocatorSettings
+		err    string
+	}{
+		{
+			name: "fails due to API error",
+			params: SetSettingsParams{
+				API:      api.NewMock(mock.SampleInternalError()),
+				ID:       "i-09a0e797fb3af6864",
+				Region:   "us-east-1",
+				Settings: settings,
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: SetSettingsParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "PATCH",
+						Path:   allocatorPath + "/settings",
+						Body:   mock.NewStringBody(`{"capacity":16384}`),
+					},
+					mock.NewStructBody(settings),
+				)),
+				ID:       "i-09a0e797fb3af6864",
+				Region:   "us-east-1",
+				Settings: settings,
+			},
+			want: settings,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UpdateSettings(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -60,10 +60,11 @@ func Vacate(params *VacateParams) error {
 	}
 
 	p, err := pool.NewPool(pool.Params{
-		Size:    params.Concurrency,
-		Run:     VacateClusterInPool,
-		Timeout: params.PoolTimeout,
-		Writer:  params.Output,
+		Size:     params.Concurrency,
+		Run:      VacateClusterInPool,
+		Timeout:  params.PoolTimeout,
+		Writer:   params.Output,
+		Progress: params.Progress,
 	})
 	if err != nil {
 		return err
@@ -179,7 +180,7 @@ func waitVacateCompletion(p *pool.Pool, hasWork bool) error {
 				ResourceID:  params.ClusterID,
 				Kind:        params.Kind,
 				Err: apierror.JSONError{
-					Message: "was either cancelled or not processed, follow up accordingly",
+					Message: leftoverMessage(params.Journal),
 				},
 			})
 		}
@@ -195,7 +196,10 @@ func addAllocatorMovesToPool(params addAllocatorMovesToPoolParams) ([]pool.Valid
 	var leftovers []pool.Validator
 	var vacates = make([]pool.Validator, 0)
 	if params.Moves == nil {
-		return leftovers, len(vacates) > 0
+		if params.VacateParams.Journal == nil {
+			return leftovers, len(vacates) > 0
+		}
+		params.Moves = new(models.MoveClustersDetails)
 	}
 
 	var filter = params.VacateParams.ClusterFilter
@@ -260,6 +264,10 @@ func addAllocatorMovesToPool(params addAllocatorMovesToPoolParams) ([]pool.Valid
 		vacates = append(vacates, newVacateClusterParams(params, *move.ClusterID, kind))
 	}
 
+	if params.VacateParams.Journal != nil {
+		vacates = addJournalMoves(params, vacates)
+	}
+
 	if leftover, _ := params.Pool.Add(vacates...); len(leftover) > 0 {
 		leftovers = append(leftovers, leftover...)
 	}
@@ -283,6 +291,7 @@ func newVacateClusterParams(params addAllocatorMovesToPoolParams, id, kind strin
 		OutputFormat:        params.VacateParams.OutputFormat,
 		MoveOnly:            params.VacateParams.MoveOnly,
 		PlanOverrides:       params.VacateParams.PlanOverrides,
+		Journal:             params.VacateParams.Journal,
 	}
 
 	if params.VacateParams.AllocatorDown != nil {
@@ -313,15 +322,46 @@ func VacateCluster(params *VacateClusterParams) error {
 		return err
 	}
 
-	if err := moveClusterByType(params); err != nil {
-		return multierror.WithFormat(err, params.OutputFormat)
+	var status VacateMoveStatus
+	if params.Journal != nil {
+		status = params.Journal.Status(params.ID, params.Kind, params.ClusterID)
+	}
+
+	// Completed moves are only skipped when the resource is no longer on the
+	// allocator, otherwise it has been moved back to it and is moved again.
+	if status == VacateMoveCompleted {
+		onAllocator, err := resourceOnAllocator(params)
+		if err != nil {
+			return err
+		}
+		if !onAllocator {
+			return nil
+		}
+		status = ""
+	}
+
+	// Moves which were started by a previous vacate are only tracked again.
+	if status != VacateMoveStarted && status != VacateMoveUntracked {
+		if err := moveClusterByType(params); err != nil {
+			return multierror.WithFormat(
+				recordVacateMove(params, VacateMoveFailed, err), params.OutputFormat,
+			)
+		}
+
+		var moveStatus = VacateMoveStarted
+		if params.SkipTracking {
+			moveStatus = VacateMoveUntracked
+		}
+		if err := recordVacateMove(params, moveStatus, nil); err != nil {
+			return err
+		}
 	}
 
 	if params.SkipTracking {
 		return nil
 	}
 
-	return planutil.TrackChange(planutil.TrackChangeParams{
+	err = planutil.TrackChange(planutil.TrackChangeParams{
 		TrackChangeParams: plan.TrackChangeParams{
 			API:              params.API,
 			ResourceID:       params.ClusterID,
@@ -335,6 +375,36 @@ func VacateCluster(params *VacateClusterParams) error {
 		Writer: params.Output,
 		Format: params.OutputFormat,
 	})
+	if err != nil {
+		return recordVacateMove(params, VacateMoveFailed, err)
+	}
+
+	return recordVacateMove(params, VacateMoveCompleted, nil)
+}
+
+// resourceOnAllocator returns true when the allocator has any instance of the
+// resource.
+func resourceOnAllocator(params *VacateClusterParams) (bool, error) {
+	alloc, err := Get(
+		GetParams{API: params.API, ID: params.ID, Region: params.Region},
+	)
+	if err != nil {
+		return false, VacateError{
+			AllocatorID: params.ID,
+			ResourceID:  params.ClusterID,
+			Kind:        params.Kind,
+			Ctx:         "failed obtaining the allocator resources",
+			Err:         err,
+		}
+	}
+
+	for _, instance := range alloc.Instances {
+		if instance != nil && instance.ClusterID != nil && *instance.ClusterID == params.ClusterID {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
 
 // fillVacateClusterParams validates the parameters and fills any missing
@@ -635,10 +705,15 @@ func getVacateRequestByClusterID(clusterID, clusterType string) *models.MoveClus
 // ComputeVacateRequest filters the tentative resources that would be moved and
 // filters those by ID if it's specified, also setting any preferred allocators
 // if that is sent. Any resource plan overrides will be set in this function.
+// Resources without a calculated plan are skipped.
 // nolint due to complexity
 func ComputeVacateRequest(pr *models.MoveClustersDetails, resources, to []string, overrides PlanOverrides) *models.MoveClustersRequest {
 	var req models.MoveClustersRequest
 	for _, c := range pr.ElasticsearchClusters {
+		if c == nil || c.ClusterID == nil || c.CalculatedPlan == nil || c.CalculatedPlan.PlanConfiguration == nil {
+			continue
+		}
+
 		if len(resources) > 0 && !slice.HasString(resources, *c.ClusterID) {
 			continue
 		}
@@ -665,6 +740,10 @@ func ComputeVacateRequest(pr *models.MoveClustersDetails, resources, to []string
 	}
 
 	for _, c := range pr.KibanaClusters {
+		if c == nil || c.ClusterID == nil || c.CalculatedPlan == nil || c.CalculatedPlan.PlanConfiguration == nil {
+			continue
+		}
+
 		if len(resources) > 0 && !slice.HasString(resources, *c.ClusterID) {
 			continue
 		}
@@ -679,6 +758,10 @@ func ComputeVacateRequest(pr *models.MoveClustersDetails, resources, to []string
 	}
 
 	for _, c := range pr.ApmClusters {
+		if c == nil || c.ClusterID == nil || c.CalculatedPlan == nil || c.CalculatedPlan.PlanConfiguration == nil {
+			continue
+		}
+
 		if len(resources) > 0 && !slice.HasString(resources, *c.ClusterID) {
 			continue
 		}
@@ -693,6 +776,10 @@ func ComputeVacateRequest(pr *models.MoveClustersDetails, resources, to []string
 	}
 
 	for _, c := range pr.AppsearchClusters {
+		if c == nil || c.ClusterID == nil || c.CalculatedPlan == nil || c.CalculatedPlan.PlanConfiguration == nil {
+			continue
+		}
+
 		if len(resources) > 0 && !slice.HasString(resources, *c.ClusterID) {
 			continue
 		}
@@ -707,6 +794,10 @@ func ComputeVacateRequest(pr *models.MoveClustersDetails, resources, to []string
 	}
 
 	for _, c := range pr.EnterpriseSearchClusters {
+		if c == nil || c.ClusterID == nil || c.CalculatedPlan == nil || c.CalculatedPlan.PlanConfiguration == nil {
+			continue
+		}
+
 		if len(resources) > 0 && !slice.HasString(resources, *c.ClusterID) {
 			continue
 		}
@@ -0,0 +1,281 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/sync/pool"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+// VacateMoveStatus is the status of a resource move recorded in a
+// VacateJournal.
+type VacateMoveStatus string
+
+const (
+	// VacateMoveStarted is recorded once the resource move has been accepted
+	// by the API and its plan is being tracked.
+	VacateMoveStarted VacateMoveStatus = "started"
+	// VacateMoveCompleted is recorded once the resource move plan finishes.
+	VacateMoveCompleted VacateMoveStatus = "completed"
+	// VacateMoveFailed is recorded when the resource move fails.
+	VacateMoveFailed VacateMoveStatus = "failed"
+	// VacateMoveUntracked is recorded once the resource move has been
+	// accepted by the API when its plan isn't tracked, since SkipTracking is
+	// set. It's tracked by a resumed vacate which doesn't skip tracking.
+	VacateMoveUntracked VacateMoveStatus = "untracked"
+)
+
+// VacateJournalEntry records the outcome of a single resource move.
+type VacateJournalEntry struct {
+	AllocatorID string           `json:"allocator_id"`
+	ResourceID  string           `json:"resource_id"`
+	Kind        string           `json:"kind"`
+	Status      VacateMoveStatus `json:"status"`
+	Error       string           `json:"error,omitempty"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+func (e VacateJournalEntry) key() string {
+	return journalKey(e.AllocatorID, e.Kind, e.ResourceID)
+}
+
+func journalKey(allocatorID, kind, resourceID string) string {
+	return allocatorID + "/" + kind + "/" + resourceID
+}
+
+// VacateJournal persists the status of every resource move of a vacate to a
+// local JSON file, so an interrupted vacate can be resumed by passing the
+// same journal to Vacate again: completed moves are skipped unless the
+// resource is back on the allocator, and started or untracked moves are
+// tracked again instead of being moved a second time. It's safe for
+// concurrent use.
+type VacateJournal struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]VacateJournalEntry
+}
+
+type vacateJournalFile struct {
+	Entries []VacateJournalEntry `json:"entries"`
+}
+
+// OpenVacateJournal opens the vacate journal at the specified path, loading
+// its entries when the file exists. The file is created on the first write.
+func OpenVacateJournal(path string) (*VacateJournal, error) {
+	if path == "" {
+		return nil, errors.New("vacate journal: path cannot be empty")
+	}
+
+	var journal = VacateJournal{
+		path:    path,
+		now:     time.Now,
+		entries: make(map[string]VacateJournalEntry),
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &journal, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f vacateJournalFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range f.Entries {
+		journal.entries[entry.key()] = entry
+	}
+
+	return &journal, nil
+}
+
+// Path returns the journal file path.
+func (j *VacateJournal) Path() string { return j.path }
+
+// Entries returns the journal entries sorted by allocator, kind and resource.
+func (j *VacateJournal) Entries() []VacateJournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.sortedEntries()
+}
+
+// Status returns the recorded status of a resource move, or an empty status
+// when the move hasn't been recorded.
+func (j *VacateJournal) Status(allocatorID, kind, resourceID string) VacateMoveStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.entries[journalKey(allocatorID, kind, resourceID)].Status
+}
+
+// Record sets the status of a resource move and persists the journal.
+func (j *VacateJournal) Record(allocatorID, kind, resourceID string, status VacateMoveStatus, moveErr error) error {
+	var entry = VacateJournalEntry{
+		AllocatorID: allocatorID,
+		ResourceID:  resourceID,
+		Kind:        kind,
+		Status:      status,
+		UpdatedAt:   j.now().UTC(),
+	}
+	if moveErr != nil {
+		entry.Error = moveErr.Error()
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries[entry.key()] = entry
+	return j.persist()
+}
+
+// started returns the moves of the allocator which were started, tracked or
+// not, but haven't been recorded as completed or failed.
+func (j *VacateJournal) started(allocatorID string) []VacateJournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var started []VacateJournalEntry
+	for _, entry := range j.sortedEntries() {
+		var pending = entry.Status == VacateMoveStarted || entry.Status == VacateMoveUntracked
+		if entry.AllocatorID == allocatorID && pending {
+			started = append(started, entry)
+		}
+	}
+
+	return started
+}
+
+func (j *VacateJournal) sortedEntries() []VacateJournalEntry {
+	var entries = make([]VacateJournalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, k int) bool {
+		return entries[i].key() < entries[k].key()
+	})
+
+	return entries
+}
+
+// persist atomically writes the journal by writing to a temporary file
+// which then replaces the journal file. Must be called with the lock held.
+func (j *VacateJournal) persist() error {
+	b, err := json.MarshalIndent(vacateJournalFile{Entries: j.sortedEntries()}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), j.path)
+}
+
+// addJournalMoves appends the moves which were started by a previous vacate
+// and haven't been returned by the API to the vacates, so they're tracked
+// until completion.
+func addJournalMoves(params addAllocatorMovesToPoolParams, vacates []pool.Validator) []pool.Validator {
+	var queued = make(map[string]bool, len(vacates))
+	for _, v := range vacates {
+		if p, ok := v.(*VacateClusterParams); ok {
+			queued[journalKey(p.ID, p.Kind, p.ClusterID)] = true
+		}
+	}
+
+	var filter = params.VacateParams.ClusterFilter
+	var kindFilter = params.VacateParams.KindFilter
+	for _, entry := range params.VacateParams.Journal.started(params.ID) {
+		if queued[entry.key()] {
+			continue
+		}
+
+		if len(filter) > 0 && !slice.HasString(filter, entry.ResourceID) {
+			continue
+		}
+
+		if kindFilter != "" && entry.Kind != kindFilter {
+			continue
+		}
+
+		vacates = append(vacates, newVacateClusterParams(params, entry.ResourceID, entry.Kind))
+	}
+
+	return vacates
+}
+
+// recordVacateMove records the move status in the journal when set,
+// returning the move error along with any error persisting the journal.
+func recordVacateMove(params *VacateClusterParams, status VacateMoveStatus, moveErr error) error {
+	if params.Journal == nil {
+		return moveErr
+	}
+
+	err := params.Journal.Record(params.ID, params.Kind, params.ClusterID, status, moveErr)
+	if err == nil {
+		return moveErr
+	}
+
+	var journalErr = VacateError{
+		AllocatorID: params.ID,
+		ResourceID:  params.ClusterID,
+		Kind:        params.Kind,
+		Ctx:         "failed persisting the vacate journal",
+		Err:         err,
+	}
+	if moveErr == nil {
+		return journalErr
+	}
+
+	return multierror.NewPrefixed("vacate error", moveErr, journalErr)
+}
+
+func leftoverMessage(journal *VacateJournal) string {
+	if journal == nil {
+		return "was either cancelled or not processed, follow up accordingly"
+	}
+	return "was either cancelled or not processed, resume the vacate with the journal " +
+		journal.Path()
+}
@@ -0,0 +1,325 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+	"github.com/elastic/cloud-sdk-go/pkg/sync/pool"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var journalNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestVacateJournal(t *testing.T, entries ...VacateJournalEntry) *VacateJournal {
+	journal, err := OpenVacateJournal(filepath.Join(t.TempDir(), "vacate.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	journal.now = func() time.Time { return journalNow }
+	for _, e := range entries {
+		if err := journal.Record(e.AllocatorID, e.Kind, e.ResourceID, e.Status, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return journal
+}
+
+func TestOpenVacateJournal(t *testing.T) {
+	var invalid = filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"entries":`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []VacateJournalEntry
+		err  string
+	}{
+		{
+			name: "fails due to empty path",
+			err:  "vacate journal: path cannot be empty",
+		},
+		{
+			name: "fails due to invalid JSON",
+			path: invalid,
+			err:  "unexpected end of JSON input",
+		},
+		{
+			name: "succeeds with a missing file",
+			path: filepath.Join(t.TempDir(), "missing.json"),
+			want: []VacateJournalEntry{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := OpenVacateJournal(tt.path)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Nil(t, got)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.path, got.Path())
+				assert.Equal(t, tt.want, got.Entries())
+			}
+		})
+	}
+}
+
+func TestVacateJournalRecord(t *testing.T) {
+	var journal = newTestVacateJournal(t)
+	assert.NoError(t, journal.Record("i-1", "kibana", "k1", VacateMoveStarted, nil))
+	assert.NoError(t, journal.Record("i-1", "elasticsearch", "e1", VacateMoveStarted, nil))
+	assert.NoError(t, journal.Record("i-1", "elasticsearch", "e1", VacateMoveFailed, errors.New("a failure")))
+	assert.NoError(t, journal.Record("i-2", "apm", "a1", VacateMoveCompleted, nil))
+
+	want := []VacateJournalEntry{
+		{AllocatorID: "i-1", Kind: "elasticsearch", ResourceID: "e1", Status: VacateMoveFailed, Error: "a failure", UpdatedAt: journalNow},
+		{AllocatorID: "i-1", Kind: "kibana", ResourceID: "k1", Status: VacateMoveStarted, UpdatedAt: journalNow},
+		{AllocatorID: "i-2", Kind: "apm", ResourceID: "a1", Status: VacateMoveCompleted, UpdatedAt: journalNow},
+	}
+	assert.Equal(t, want, journal.Entries())
+	assert.Equal(t, VacateMoveStarted, journal.Status("i-1", "kibana", "k1"))
+	assert.Equal(t, VacateMoveStatus(""), journal.Status("i-1", "kibana", "k2"))
+	assert.Equal(t, []VacateJournalEntry{want[1]}, journal.started("i-1"))
+
+	reopened, err := OpenVacateJournal(journal.Path())
+	if assert.NoError(t, err) {
+		assert.Equal(t, want, reopened.Entries())
+	}
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(journal.Path()), "*.tmp"))
+	assert.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestVacateClusterJournal(t *testing.T) {
+	const clusterID = "3ee11eb40eda22cac0cce259625c6734"
+	var newParams = func(a *api.API, journal *VacateJournal) *VacateClusterParams {
+		return &VacateClusterParams{
+			ID:             "someID",
+			Region:         "us-east-1",
+			ClusterID:      clusterID,
+			Kind:           "elasticsearch",
+			Output:         new(output.Device),
+			TrackFrequency: time.Nanosecond,
+			SkipTracking:   true,
+			MaxPollRetries: 1,
+			API:            a,
+			Journal:        journal,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		journal *VacateJournal
+		params  func(journal *VacateJournal) *VacateClusterParams
+		want    VacateMoveStatus
+		err     string
+	}{
+		{
+			name: "skips a completed move when the resource is no longer on the allocator",
+			journal: newTestVacateJournal(t, VacateJournalEntry{
+				AllocatorID: "someID", Kind: "elasticsearch", ResourceID: clusterID, Status: VacateMoveCompleted,
+			}),
+			params: func(journal *VacateJournal) *VacateClusterParams {
+				p := newParams(api.NewMock(mock.New200Response(
+					newAllocator(t, "someID", "anotherID", "elasticsearch"),
+				)), journal)
+				p.AllocatorDown = ec.Bool(false)
+				return p
+			},
+			want: VacateMoveCompleted,
+		},
+		{
+			name: "moves a completed move again when the resource is back on the allocator",
+			journal: newTestVacateJournal(t, VacateJournalEntry{
+				AllocatorID: "someID", Kind: "elasticsearch", ResourceID: clusterID, Status: VacateMoveCompleted,
+			}),
+			params: func(journal *VacateJournal) *VacateClusterParams {
+				p := newParams(discardResponses(newElasticsearchVacateMove(t, "someID",
+					vacateCaseClusterConfig{ID: clusterID}, "us-east-1",
+				)), journal)
+				p.AllocatorDown = ec.Bool(false)
+				return p
+			},
+			want: VacateMoveUntracked,
+		},
+		{
+			name: "fails checking a completed move when the allocator can't be obtained",
+			journal: newTestVacateJournal(t, VacateJournalEntry{
+				AllocatorID: "someID", Kind: "elasticsearch", ResourceID: clusterID, Status: VacateMoveCompleted,
+			}),
+			params: func(journal *VacateJournal) *VacateClusterParams {
+				p := newParams(api.NewMock(mock.SampleInternalError()), journal)
+				p.AllocatorDown = ec.Bool(false)
+				return p
+			},
+			want: VacateMoveCompleted,
+			err: VacateError{
+				AllocatorID: "someID",
+				ResourceID:  clusterID,
+				Kind:        "elasticsearch",
+				Ctx:         "failed obtaining the allocator resources",
+				Err:         mock.MultierrorInternalError,
+			}.Error(),
+		},
+		{
+			name: "doesn't move a started move again",
+			journal: newTestVacateJournal(t, VacateJournalEntry{
+				AllocatorID: "someID", Kind: "elasticsearch", ResourceID: clusterID, Status: VacateMoveStarted,
+			}),
+			params: func(journal *VacateJournal) *VacateClusterParams {
+				p := newParams(api.NewMock(), journal)
+				p.AllocatorDown = ec.Bool(false)
+				return p
+			},
+			want: VacateMoveStarted,
+		},
+		{
+			name: "doesn't move an untracked move again",
+			journal: newTestVacateJournal(t, VacateJournalEntry{
+				AllocatorID: "someID", Kind: "elasticsearch", ResourceID: clusterID, Status: VacateMoveUntracked,
+			}),
+			params: func(journal *VacateJournal) *VacateClusterParams {
+				p := newParams(api.NewMock(), journal)
+				p.AllocatorDown = ec.Bool(false)
+				return p
+			},
+			want: VacateMoveUntracked,
+		},
+		{
+			name:    "records an untracked move when tracking is skipped",
+			journal: newTestVacateJournal(t),
+			params: func(journal *VacateJournal) *VacateClusterParams {
+				return newParams(discardResponses(newElasticsearchVacateMove(t, "someID",
+					vacateCaseClusterConfig{ID: clusterID}, "us-east-1",
+				)), journal)
+			},
+			want: VacateMoveUntracked,
+		},
+		{
+			name:    "records a failed move",
+			journal: newTestVacateJournal(t),
+			params: func(journal *VacateJournal) *VacateClusterParams {
+				return newParams(discardResponses(newElasticsearchVacateMove(t, "someID",
+					vacateCaseClusterConfig{ID: clusterID, fail: true}, "us-east-1",
+				)), journal)
+			},
+			want: VacateMoveFailed,
+			err: multierror.NewPrefixed("vacate error", VacateError{
+				AllocatorID: "someID",
+				ResourceID:  clusterID,
+				Kind:        "elasticsearch",
+				Ctx:         "failed vacating",
+				Err:         errors.New("a message (a code)"),
+			}).Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VacateCluster(tt.params(tt.journal))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, tt.journal.Status("someID", "elasticsearch", clusterID))
+		})
+	}
+}
+
+func TestAddJournalMoves(t *testing.T) {
+	var journal = newTestVacateJournal(t,
+		VacateJournalEntry{AllocatorID: "i-1", Kind: "elasticsearch", ResourceID: "e1", Status: VacateMoveStarted},
+		VacateJournalEntry{AllocatorID: "i-1", Kind: "elasticsearch", ResourceID: "e2", Status: VacateMoveStarted},
+		VacateJournalEntry{AllocatorID: "i-1", Kind: "kibana", ResourceID: "k1", Status: VacateMoveUntracked},
+		VacateJournalEntry{AllocatorID: "i-1", Kind: "apm", ResourceID: "a1", Status: VacateMoveCompleted},
+		VacateJournalEntry{AllocatorID: "i-2", Kind: "elasticsearch", ResourceID: "e3", Status: VacateMoveStarted},
+	)
+
+	var ids = func(vacates []pool.Validator) []string {
+		var res []string
+		for _, v := range vacates {
+			res = append(res, v.(*VacateClusterParams).ClusterID)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		params  VacateParams
+		vacates []pool.Validator
+		want    []string
+	}{
+		{
+			name:   "adds all the started and untracked moves of the allocator",
+			params: VacateParams{Journal: journal},
+			want:   []string{"e1", "e2", "k1"},
+		},
+		{
+			name:    "doesn't add already queued moves",
+			params:  VacateParams{Journal: journal},
+			vacates: []pool.Validator{&VacateClusterParams{ID: "i-1", Kind: "elasticsearch", ClusterID: "e2"}},
+			want:    []string{"e2", "e1", "k1"},
+		},
+		{
+			name:   "respects the cluster filter",
+			params: VacateParams{Journal: journal, ClusterFilter: []string{"k1"}},
+			want:   []string{"k1"},
+		},
+		{
+			name:   "respects the kind filter",
+			params: VacateParams{Journal: journal, KindFilter: "elasticsearch"},
+			want:   []string{"e1", "e2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			got := addJournalMoves(addAllocatorMovesToPoolParams{
+				ID:           "i-1",
+				VacateParams: &params,
+			}, tt.vacates)
+			assert.Equal(t, tt.want, ids(got))
+		})
+	}
+}
+
+func TestLeftoverMessage(t *testing.T) {
+	assert.Equal(t, "was either cancelled or not processed, follow up accordingly",
+		leftoverMessage(nil),
+	)
+	assert.Equal(t, "was either cancelled or not processed, resume the vacate with the journal /tmp/vacate.json",
+		leftoverMessage(&VacateJournal{path: "/tmp/vacate.json"}),
+	)
+}
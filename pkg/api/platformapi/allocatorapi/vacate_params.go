@@ -107,6 +107,16 @@ type VacateParams struct {
 
 	// Plan body overrides to place in all of the vacate clusters.
 	PlanOverrides
+
+	// Optional journal where the status of each resource move is persisted.
+	// When a vacate is interrupted, calling Vacate again with the same
+	// journal skips the completed moves of resources which are no longer on
+	// the allocator and tracks the started or untracked ones again.
+	Journal *VacateJournal
+
+	// Optional progress where the status of the vacate pool work items is
+	// tracked, it can be drawn to the output with a pool.ProgressBar.
+	Progress *pool.Progress
 }
 
 // Validate validates the parameters
@@ -170,6 +180,7 @@ type VacateClusterParams struct {
 	OutputFormat   string
 	MaxPollRetries uint8
 	SkipTracking   bool
+	Journal        *VacateJournal
 }
 
 // Validate validates the parameters
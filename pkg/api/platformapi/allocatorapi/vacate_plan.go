@@ -0,0 +1,504 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+// constructorPlacement is rendered as the target of the moves which are
+// placed by the constructor.
+const constructorPlacement = "(constructor)"
+
+// PlanVacateParams is consumed by PlanVacate.
+type PlanVacateParams struct {
+	*api.API
+
+	Region string
+
+	// List of allocators for which the vacate is planned.
+	Allocators []string
+
+	// List of allocators to be used as potential targets, which are sent to
+	// the API so the calculated targets are restricted to them.
+	PreferredAllocators []string
+
+	// Optional list of cluster IDs to restrict the plan to.
+	ClusterFilter []string
+
+	// Optional resource kind to restrict the plan to.
+	KindFilter string
+
+	// Optional value to move the clusters in their current state.
+	MoveOnly *bool
+}
+
+// Validate ensures the parameters are usable by PlanVacate.
+func (params PlanVacateParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator vacate plan params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if len(params.Allocators) == 0 {
+		merr = merr.Append(errMustSpecifyAtLeast1Allocator)
+	}
+
+	if len(params.ClusterFilter) > 0 && len(params.KindFilter) > 0 {
+		merr = merr.Append(errCannotFilterByIDAndKind)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// VacatePlan is the preview of a vacate, listing the proposed moves for each
+// allocator and the memory impact on the target allocators.
+type VacatePlan struct {
+	Allocators []AllocatorVacatePlan `json:"allocators"`
+	Targets    []VacateTarget        `json:"targets"`
+
+	// Memory in MB of the moves which are placed by the constructor, since
+	// their target allocators can't be known in advance.
+	UnassignedMemory int64 `json:"unassigned_memory"`
+}
+
+// AllocatorVacatePlan contains the proposed moves for a single allocator.
+type AllocatorVacatePlan struct {
+	AllocatorID string              `json:"allocator_id"`
+	Moves       []VacateMovePlan    `json:"moves"`
+	Unmovable   []VacateMoveFailure `json:"unmovable,omitempty"`
+}
+
+// VacateMovePlan is a proposed resource move.
+type VacateMovePlan struct {
+	ResourceID string `json:"resource_id"`
+	Kind       string `json:"kind"`
+
+	// Memory in MB used by the resource instances on the vacated allocator.
+	Memory int64 `json:"memory"`
+
+	// Target allocators of the move, empty when the constructor decides.
+	Targets []string `json:"targets"`
+}
+
+// VacateMoveFailure is a resource which cannot be moved away from the
+// allocator.
+type VacateMoveFailure struct {
+	ResourceID string `json:"resource_id"`
+	Kind       string `json:"kind"`
+	Reason     string `json:"reason"`
+}
+
+// VacateTarget is the memory impact on a target allocator. When a move has
+// more than one target allocator, its memory is accounted on all of them,
+// so Incoming reflects the worst case.
+type VacateTarget struct {
+	AllocatorID string `json:"allocator_id"`
+
+	// Memory in MB as reported by the allocator capacity.
+	Total int64 `json:"total"`
+	Used  int64 `json:"used"`
+
+	// Memory in MB which the planned moves would consume.
+	Incoming int64 `json:"incoming"`
+}
+
+// Free returns the memory in MB which would be left on the allocator after
+// the planned moves, a negative value means the allocator is overcommitted.
+func (t VacateTarget) Free() int64 { return t.Total - t.Used - t.Incoming }
+
+// vacateMove is a resource move returned by the API, regardless of its kind.
+type vacateMove struct {
+	id      string
+	kind    string
+	targets []string
+	errors  []*models.BasicFailedReplyElement
+}
+
+// PlanVacate calculates the moves which a vacate of the specified allocators
+// would perform, without moving anything. The returned plan contains the
+// proposed moves per allocator, the memory which they would consume on each
+// of the target allocators and the resources which cannot be moved.
+func PlanVacate(params PlanVacateParams) (*VacatePlan, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var plan = VacatePlan{
+		Allocators: make([]AllocatorVacatePlan, 0, len(params.Allocators)),
+		Targets:    make([]VacateTarget, 0),
+	}
+
+	var incoming = make(map[string]int64)
+	for _, id := range params.Allocators {
+		allocPlan, err := planAllocatorVacate(params, id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, move := range allocPlan.Moves {
+			if len(move.Targets) == 0 {
+				plan.UnassignedMemory += move.Memory
+			}
+			for _, target := range move.Targets {
+				incoming[target] += move.Memory
+			}
+		}
+
+		plan.Allocators = append(plan.Allocators, allocPlan)
+	}
+
+	var targets = make([]string, 0, len(incoming))
+	for target := range incoming {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		alloc, err := Get(GetParams{API: params.API, ID: target, Region: params.Region})
+		if err != nil {
+			return nil, multierror.NewPrefixed(
+				fmt.Sprintf("allocator %s vacate plan: target allocator", target), err,
+			)
+		}
+
+		var t = VacateTarget{AllocatorID: target, Incoming: incoming[target]}
+		if alloc.Capacity != nil && alloc.Capacity.Memory != nil {
+			if alloc.Capacity.Memory.Total != nil {
+				t.Total = int64(*alloc.Capacity.Memory.Total)
+			}
+			if alloc.Capacity.Memory.Used != nil {
+				t.Used = int64(*alloc.Capacity.Memory.Used)
+			}
+		}
+		plan.Targets = append(plan.Targets, t)
+	}
+
+	return &plan, nil
+}
+
+func planAllocatorVacate(params PlanVacateParams, id string) (AllocatorVacatePlan, error) {
+	var allocPlan = AllocatorVacatePlan{AllocatorID: id, Moves: make([]VacateMovePlan, 0)}
+	alloc, err := Get(GetParams{API: params.API, ID: id, Region: params.Region})
+	if err != nil {
+		return allocPlan, multierror.NewPrefixed(
+			fmt.Sprintf("allocator %s vacate plan", id), err,
+		)
+	}
+
+	var include = func(move vacateMove) bool {
+		if len(params.ClusterFilter) > 0 && !slice.HasString(params.ClusterFilter, move.id) {
+			return false
+		}
+		return params.KindFilter == "" || params.KindFilter == move.kind
+	}
+
+	res, err := validateVacate(params, id, nil)
+	if err != nil {
+		return allocPlan, multierror.NewPrefixed(
+			fmt.Sprintf("allocator %s vacate plan", id), apierror.Wrap(err),
+		)
+	}
+
+	var moves *models.MoveClustersDetails
+	var failures []*models.MoveClustersDetails
+	if res.Payload != nil {
+		moves = res.Payload.Moves
+		failures = append(failures, res.Payload.Failures)
+	}
+
+	// The same as a vacate does, the preferred allocators are set in the
+	// calculated plans of the moves, which are validated again to obtain
+	// the targets which the API would use.
+	if len(params.PreferredAllocators) > 0 && moves != nil {
+		var req = ComputeVacateRequest(moves, params.ClusterFilter,
+			params.PreferredAllocators, PlanOverrides{},
+		)
+
+		res, err := validateVacate(params, id, req)
+		if err != nil {
+			return allocPlan, multierror.NewPrefixed(
+				fmt.Sprintf("allocator %s vacate plan: preferred allocators", id),
+				apierror.Wrap(err),
+			)
+		}
+
+		moves = nil
+		if res.Payload != nil {
+			moves = res.Payload.Moves
+			failures = append(failures, res.Payload.Failures)
+		}
+	}
+
+	for _, move := range flattenVacateMoves(moves, id) {
+		if !include(move) {
+			continue
+		}
+
+		allocPlan.Moves = append(allocPlan.Moves, VacateMovePlan{
+			ResourceID: move.id,
+			Kind:       move.kind,
+			Memory:     resourceMemory(alloc, move.id),
+			Targets:    move.targets,
+		})
+	}
+
+	for _, f := range failures {
+		for _, move := range flattenVacateMoves(f, id) {
+			if !include(move) {
+				continue
+			}
+
+			allocPlan.Unmovable = append(allocPlan.Unmovable, VacateMoveFailure{
+				ResourceID: move.id,
+				Kind:       move.kind,
+				Reason:     moveFailureReason(move.errors),
+			})
+		}
+	}
+
+	return allocPlan, nil
+}
+
+// validateVacate calls the allocator move API without moving anything, to
+// obtain the calculated moves and failures. When the request is set, only
+// the resources in it are validated.
+func validateVacate(params PlanVacateParams, id string, req *models.MoveClustersRequest) (*platform_infrastructure.MoveClustersAccepted, error) {
+	var moveParams = platform_infrastructure.NewMoveClustersParams().
+		WithAllocatorID(id).
+		WithMoveOnly(params.MoveOnly).
+		WithContext(api.WithRegion(context.Background(), params.Region)).
+		WithValidateOnly(ec.Bool(true))
+	if req != nil {
+		moveParams.SetBody(req)
+	}
+
+	return params.V1API.PlatformInfrastructure.MoveClusters(moveParams, params.AuthWriter)
+}
+
+// moveFailureReason returns the reason of the first move failure error,
+// falling back to "unknown reason" when the error has no message.
+func moveFailureReason(errs []*models.BasicFailedReplyElement) string {
+	if len(errs) == 0 || errs[0] == nil || errs[0].Message == nil {
+		return "unknown reason"
+	}
+
+	var err = errs[0]
+	if err.Code == nil {
+		return *err.Message
+	}
+	return fmt.Sprintf("%s (%s)", *err.Message, *err.Code)
+}
+
+// resourceMemory returns the memory in MB used by the resource instances on
+// the allocator.
+func resourceMemory(alloc *models.AllocatorInfo, resourceID string) int64 {
+	var memory int64
+	for _, instance := range alloc.Instances {
+		if instance.ClusterID == nil || *instance.ClusterID != resourceID {
+			continue
+		}
+		if instance.NodeMemory != nil {
+			memory += int64(*instance.NodeMemory)
+		}
+	}
+	return memory
+}
+
+// vacateMoveDetail contains the move details which are common to all the
+// resource kinds.
+type vacateMoveDetail struct {
+	clusterID      *string
+	moveAllocators []*models.AllocatorMoveRequest
+	errors         []*models.BasicFailedReplyElement
+}
+
+// flattenVacateMoves returns the moves of all resource kinds, using the
+// calculated plan to obtain the target allocators of the move. Moves without
+// a cluster ID are skipped.
+func flattenVacateMoves(details *models.MoveClustersDetails, allocatorID string) []vacateMove {
+	if details == nil {
+		return nil
+	}
+
+	var kinds = []struct {
+		kind    string
+		details []vacateMoveDetail
+	}{
+		{kind: util.Elasticsearch, details: moveDetails(details.ElasticsearchClusters,
+			func(m *models.MoveElasticsearchClusterDetails) vacateMoveDetail {
+				var d = vacateMoveDetail{clusterID: m.ClusterID, errors: m.Errors}
+				if m.CalculatedPlan != nil && m.CalculatedPlan.PlanConfiguration != nil {
+					d.moveAllocators = m.CalculatedPlan.PlanConfiguration.MoveAllocators
+				}
+				return d
+			},
+		)},
+		{kind: util.Kibana, details: moveDetails(details.KibanaClusters,
+			func(m *models.MoveKibanaClusterDetails) vacateMoveDetail {
+				var d = vacateMoveDetail{clusterID: m.ClusterID, errors: m.Errors}
+				if m.CalculatedPlan != nil && m.CalculatedPlan.PlanConfiguration != nil {
+					d.moveAllocators = m.CalculatedPlan.PlanConfiguration.MoveAllocators
+				}
+				return d
+			},
+		)},
+		{kind: util.Apm, details: moveDetails(details.ApmClusters,
+			func(m *models.MoveApmClusterDetails) vacateMoveDetail {
+				var d = vacateMoveDetail{clusterID: m.ClusterID, errors: m.Errors}
+				if m.CalculatedPlan != nil && m.CalculatedPlan.PlanConfiguration != nil {
+					d.moveAllocators = m.CalculatedPlan.PlanConfiguration.MoveAllocators
+				}
+				return d
+			},
+		)},
+		{kind: util.Appsearch, details: moveDetails(details.AppsearchClusters,
+			func(m *models.MoveAppSearchDetails) vacateMoveDetail {
+				var d = vacateMoveDetail{clusterID: m.ClusterID, errors: m.Errors}
+				if m.CalculatedPlan != nil && m.CalculatedPlan.PlanConfiguration != nil {
+					d.moveAllocators = m.CalculatedPlan.PlanConfiguration.MoveAllocators
+				}
+				return d
+			},
+		)},
+		{kind: util.EnterpriseSearch, details: moveDetails(details.EnterpriseSearchClusters,
+			func(m *models.MoveEnterpriseSearchDetails) vacateMoveDetail {
+				var d = vacateMoveDetail{clusterID: m.ClusterID, errors: m.Errors}
+				if m.CalculatedPlan != nil && m.CalculatedPlan.PlanConfiguration != nil {
+					d.moveAllocators = m.CalculatedPlan.PlanConfiguration.MoveAllocators
+				}
+				return d
+			},
+		)},
+	}
+
+	var moves []vacateMove
+	for _, k := range kinds {
+		for _, d := range k.details {
+			if d.clusterID == nil {
+				continue
+			}
+
+			moves = append(moves, vacateMove{
+				id:      *d.clusterID,
+				kind:    k.kind,
+				targets: moveTargets(d.moveAllocators, allocatorID),
+				errors:  d.errors,
+			})
+		}
+	}
+
+	return moves
+}
+
+// moveDetails obtains the common move details of a resource kind, skipping
+// the nil entries.
+func moveDetails[T any](clusters []*T, detail func(*T) vacateMoveDetail) []vacateMoveDetail {
+	var details = make([]vacateMoveDetail, 0, len(clusters))
+	for _, c := range clusters {
+		if c != nil {
+			details = append(details, detail(c))
+		}
+	}
+	return details
+}
+
+func moveTargets(moves []*models.AllocatorMoveRequest, allocatorID string) []string {
+	var targets []string
+	for _, move := range moves {
+		if move != nil && move.From != nil && *move.From == allocatorID {
+			targets = append(targets, move.To...)
+		}
+	}
+	return targets
+}
+
+// Render writes the plan to the writer, either as JSON when the format is
+// "json" or as a set of tables otherwise.
+func (p *VacatePlan) Render(w io.Writer, format string) error {
+	if p == nil {
+		return errors.New("vacate plan: cannot render a nil plan")
+	}
+
+	if strings.EqualFold(format, "json") {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(p)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ALLOCATOR\tRESOURCE ID\tKIND\tMEMORY\tTARGETS")
+	for _, alloc := range p.Allocators {
+		for _, move := range alloc.Moves {
+			var targets = constructorPlacement
+			if len(move.Targets) > 0 {
+				targets = strings.Join(move.Targets, ",")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n",
+				alloc.AllocatorID, move.ResourceID, move.Kind, move.Memory, targets,
+			)
+		}
+	}
+
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "TARGET\tTOTAL\tUSED\tINCOMING\tFREE")
+	for _, target := range p.Targets {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n",
+			target.AllocatorID, target.Total, target.Used, target.Incoming, target.Free(),
+		)
+	}
+	if p.UnassignedMemory > 0 {
+		fmt.Fprintf(tw, "%s\t-\t-\t%d\t-\n", constructorPlacement, p.UnassignedMemory)
+	}
+
+	var unmovable bool
+	for _, alloc := range p.Allocators {
+		for _, failure := range alloc.Unmovable {
+			if !unmovable {
+				unmovable = true
+				fmt.Fprintln(tw)
+				fmt.Fprintln(tw, "ALLOCATOR\tRESOURCE ID\tKIND\tREASON")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+				alloc.AllocatorID, failure.ResourceID, failure.Kind, failure.Reason,
+			)
+		}
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,426 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const (
+	planESID     = "3ee11eb40eda22cac0cce259625c6734"
+	planKibanaID = "4ee11eb40eda22cac0cce259625c6734"
+	planApmID    = "5ee11eb40eda22cac0cce259625c6734"
+)
+
+func planAllocatorResponse(id string, info models.AllocatorInfo) mock.Response {
+	info.AllocatorID = ec.String(id)
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators/" + id,
+		},
+		mock.NewStructBody(info),
+	)
+}
+
+func planSourceAllocatorResponse() mock.Response {
+	return planAllocatorResponse("i-1", models.AllocatorInfo{
+		Instances: []*models.AllocatedInstanceStatus{
+			{ClusterID: ec.String(planESID), NodeMemory: ec.Int32(2048)},
+			{ClusterID: ec.String(planESID), NodeMemory: ec.Int32(1024)},
+			{ClusterID: ec.String(planKibanaID), NodeMemory: ec.Int32(1024)},
+			{ClusterID: ec.String(planApmID), NodeMemory: ec.Int32(512)},
+		},
+	})
+}
+
+func planTargetAllocatorResponse(id string) mock.Response {
+	return planAllocatorResponse(id, models.AllocatorInfo{
+		Capacity: &models.AllocatorCapacity{Memory: &models.AllocatorCapacityMemory{
+			Total: ec.Int32(8192), Used: ec.Int32(6144),
+		}},
+	})
+}
+
+func planMoveResponse() mock.Response {
+	return mock.New202ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultWriteMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "POST",
+			Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators/i-1/clusters/_move",
+			Query:  url.Values{"validate_only": {"true"}},
+		},
+		mock.NewStructBody(models.MoveClustersCommandResponse{
+			Moves: &models.MoveClustersDetails{
+				ElasticsearchClusters: []*models.MoveElasticsearchClusterDetails{{
+					ClusterID: ec.String(planESID),
+					CalculatedPlan: &models.TransientElasticsearchPlanConfiguration{
+						PlanConfiguration: &models.ElasticsearchPlanControlConfiguration{
+							MoveAllocators: []*models.AllocatorMoveRequest{
+								{From: ec.String("i-1"), To: []string{"i-2"}},
+							},
+						},
+					},
+				}},
+				KibanaClusters: []*models.MoveKibanaClusterDetails{{
+					ClusterID: ec.String(planKibanaID),
+					CalculatedPlan: &models.TransientKibanaPlanConfiguration{
+						PlanConfiguration: &models.KibanaPlanControlConfiguration{},
+					},
+				}},
+			},
+			Failures: &models.MoveClustersDetails{
+				ApmClusters: []*models.MoveApmClusterDetails{{
+					ClusterID: ec.String(planApmID),
+					Errors: []*models.BasicFailedReplyElement{{
+						Code:    ec.String("a code"),
+						Message: ec.String("a message"),
+					}},
+				}},
+			},
+		}),
+	)
+}
+
+func planPreferredMoveResponse(preferred []string) mock.Response {
+	return mock.New202ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultWriteMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "POST",
+			Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators/i-1/clusters/_move",
+			Query:  url.Values{"validate_only": {"true"}},
+			Body: mock.NewStructBody(models.MoveClustersRequest{
+				ElasticsearchClusters: []*models.MoveElasticsearchClusterConfiguration{{
+					ClusterIds: []string{planESID},
+					PlanOverride: &models.TransientElasticsearchPlanConfiguration{
+						PlanConfiguration: &models.ElasticsearchPlanControlConfiguration{
+							MoveAllocators: []*models.AllocatorMoveRequest{
+								{From: ec.String("i-1"), To: []string{"i-2"}},
+							},
+							PreferredAllocators: preferred,
+						},
+					},
+				}},
+				KibanaClusters: []*models.MoveKibanaClusterConfiguration{{
+					ClusterIds: []string{planKibanaID},
+					PlanOverride: &models.TransientKibanaPlanConfiguration{
+						PlanConfiguration: &models.KibanaPlanControlConfiguration{
+							PreferredAllocators: preferred,
+						},
+					},
+				}},
+			}),
+		},
+		mock.NewStructBody(models.MoveClustersCommandResponse{
+			Moves: &models.MoveClustersDetails{
+				ElasticsearchClusters: []*models.MoveElasticsearchClusterDetails{{
+					ClusterID: ec.String(planESID),
+					CalculatedPlan: &models.TransientElasticsearchPlanConfiguration{
+						PlanConfiguration: &models.ElasticsearchPlanControlConfiguration{
+							MoveAllocators: []*models.AllocatorMoveRequest{
+								{From: ec.String("i-1"), To: []string{"i-4"}},
+							},
+						},
+					},
+				}},
+				KibanaClusters: []*models.MoveKibanaClusterDetails{{
+					ClusterID: ec.String(planKibanaID),
+					CalculatedPlan: &models.TransientKibanaPlanConfiguration{
+						PlanConfiguration: &models.KibanaPlanControlConfiguration{
+							MoveAllocators: []*models.AllocatorMoveRequest{
+								{From: ec.String("i-1"), To: []string{"i-3"}},
+							},
+						},
+					},
+				}},
+			},
+		}),
+	)
+}
+
+func TestPlanVacate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params PlanVacateParams
+		want   *VacatePlan
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: PlanVacateParams{
+				ClusterFilter: []string{planESID},
+				KindFilter:    "elasticsearch",
+			},
+			err: multierror.NewPrefixed("invalid allocator vacate plan params",
+				apierror.ErrMissingAPI,
+				errMustSpecifyAtLeast1Allocator,
+				errCannotFilterByIDAndKind,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails obtaining the allocator",
+			params: PlanVacateParams{
+				API:        api.NewMock(mock.SampleNotFoundError()),
+				Region:     "us-east-1",
+				Allocators: []string{"i-1"},
+			},
+			err: multierror.NewPrefixed("allocator i-1 vacate plan",
+				mock.MultierrorNotFound,
+			).Error(),
+		},
+		{
+			name: "fails obtaining a target allocator",
+			params: PlanVacateParams{
+				API: api.NewMock(
+					planSourceAllocatorResponse(),
+					planMoveResponse(),
+					mock.SampleInternalError(),
+				),
+				Region:     "us-east-1",
+				Allocators: []string{"i-1"},
+			},
+			err: multierror.NewPrefixed("allocator i-2 vacate plan: target allocator",
+				mock.MultierrorInternalError,
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: PlanVacateParams{
+				API: api.NewMock(
+					planSourceAllocatorResponse(),
+					planMoveResponse(),
+					planTargetAllocatorResponse("i-2"),
+				),
+				Region:     "us-east-1",
+				Allocators: []string{"i-1"},
+			},
+			want: &VacatePlan{
+				Allocators: []AllocatorVacatePlan{{
+					AllocatorID: "i-1",
+					Moves: []VacateMovePlan{
+						{ResourceID: planESID, Kind: "elasticsearch", Memory: 3072, Targets: []string{"i-2"}},
+						{ResourceID: planKibanaID, Kind: "kibana", Memory: 1024},
+					},
+					Unmovable: []VacateMoveFailure{
+						{ResourceID: planApmID, Kind: "apm", Reason: "a message (a code)"},
+					},
+				}},
+				Targets: []VacateTarget{
+					{AllocatorID: "i-2", Total: 8192, Used: 6144, Incoming: 3072},
+				},
+				UnassignedMemory: 1024,
+			},
+		},
+		{
+			name: "succeeds with preferred allocators and a kind filter",
+			params: PlanVacateParams{
+				API: api.NewMock(
+					planSourceAllocatorResponse(),
+					planMoveResponse(),
+					planPreferredMoveResponse([]string{"i-3", "i-4"}),
+					planTargetAllocatorResponse("i-3"),
+				),
+				Region:              "us-east-1",
+				Allocators:          []string{"i-1"},
+				PreferredAllocators: []string{"i-3", "i-4"},
+				KindFilter:          "kibana",
+			},
+			want: &VacatePlan{
+				Allocators: []AllocatorVacatePlan{{
+					AllocatorID: "i-1",
+					Moves: []VacateMovePlan{
+						{ResourceID: planKibanaID, Kind: "kibana", Memory: 1024, Targets: []string{"i-3"}},
+					},
+				}},
+				Targets: []VacateTarget{
+					{AllocatorID: "i-3", Total: 8192, Used: 6144, Incoming: 1024},
+				},
+			},
+		},
+		{
+			name: "succeeds skipping the moves without a cluster id",
+			params: PlanVacateParams{
+				API: api.NewMock(
+					planSourceAllocatorResponse(),
+					mock.New202Response(mock.NewStructBody(models.MoveClustersCommandResponse{
+						Moves: &models.MoveClustersDetails{
+							ElasticsearchClusters: []*models.MoveElasticsearchClusterDetails{nil, {}},
+							KibanaClusters:        []*models.MoveKibanaClusterDetails{{}},
+						},
+						Failures: &models.MoveClustersDetails{
+							ApmClusters: []*models.MoveApmClusterDetails{{}},
+						},
+					})),
+				),
+				Region:     "us-east-1",
+				Allocators: []string{"i-1"},
+			},
+			want: &VacatePlan{
+				Allocators: []AllocatorVacatePlan{{
+					AllocatorID: "i-1",
+					Moves:       []VacateMovePlan{},
+				}},
+				Targets: []VacateTarget{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PlanVacate(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMoveFailureReason(t *testing.T) {
+	tests := []struct {
+		name string
+		errs []*models.BasicFailedReplyElement
+		want string
+	}{
+		{
+			name: "returns unknown reason without errors",
+			want: "unknown reason",
+		},
+		{
+			name: "returns unknown reason with a nil error",
+			errs: []*models.BasicFailedReplyElement{nil},
+			want: "unknown reason",
+		},
+		{
+			name: "returns unknown reason with a nil message",
+			errs: []*models.BasicFailedReplyElement{{Code: ec.String("a code")}},
+			want: "unknown reason",
+		},
+		{
+			name: "returns the message with a nil code",
+			errs: []*models.BasicFailedReplyElement{{Message: ec.String("a message")}},
+			want: "a message",
+		},
+		{
+			name: "returns the message and code of the first error",
+			errs: []*models.BasicFailedReplyElement{
+				{Code: ec.String("a code"), Message: ec.String("a message")},
+				{Code: ec.String("another code"), Message: ec.String("another message")},
+			},
+			want: "a message (a code)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, moveFailureReason(tt.errs))
+		})
+	}
+}
+
+func TestVacatePlanRender(t *testing.T) {
+	var plan = &VacatePlan{
+		Allocators: []AllocatorVacatePlan{{
+			AllocatorID: "i-1",
+			Moves: []VacateMovePlan{
+				{ResourceID: planESID, Kind: "elasticsearch", Memory: 3072, Targets: []string{"i-2"}},
+				{ResourceID: planKibanaID, Kind: "kibana", Memory: 1024},
+			},
+			Unmovable: []VacateMoveFailure{
+				{ResourceID: planApmID, Kind: "apm", Reason: "a message (a code)"},
+			},
+		}},
+		Targets: []VacateTarget{
+			{AllocatorID: "i-2", Total: 8192, Used: 6144, Incoming: 3072},
+		},
+		UnassignedMemory: 1024,
+	}
+
+	tests := []struct {
+		name   string
+		plan   *VacatePlan
+		format string
+		want   string
+		err    string
+	}{
+		{
+			name: "fails on a nil plan",
+			err:  "vacate plan: cannot render a nil plan",
+		},
+		{
+			name: "renders a table",
+			plan: plan,
+			want: `ALLOCATOR  RESOURCE ID                       KIND           MEMORY  TARGETS
+i-1        3ee11eb40eda22cac0cce259625c6734  elasticsearch  3072    i-2
+i-1        4ee11eb40eda22cac0cce259625c6734  kibana         1024    (constructor)
+
+TARGET         TOTAL  USED  INCOMING  FREE
+i-2            8192   6144  3072      -1024
+(constructor)  -      -     1024      -
+
+ALLOCATOR  RESOURCE ID                       KIND  REASON
+i-1        5ee11eb40eda22cac0cce259625c6734  apm   a message (a code)
+`,
+		},
+		{
+			name:   "renders JSON",
+			plan:   &VacatePlan{Allocators: []AllocatorVacatePlan{{AllocatorID: "i-1", Moves: []VacateMovePlan{}}}, Targets: []VacateTarget{}},
+			format: "json",
+			want: `{
+  "allocators": [
+    {
+      "allocator_id": "i-1",
+      "moves": []
+    }
+  ],
+  "targets": [],
+  "unassigned_memory": 0
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf = new(bytes.Buffer)
+			err := tt.plan.Render(buf, tt.format)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
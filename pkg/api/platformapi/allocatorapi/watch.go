@@ -0,0 +1,338 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// DefaultWatchFrequency is the default frequency at which Watch polls the
+// allocators.
+const DefaultWatchFrequency = 30 * time.Second
+
+// WatchEventType is the type of a WatchEvent.
+type WatchEventType string
+
+const (
+	// AllocatorDisconnected is sent when an allocator is disconnected.
+	AllocatorDisconnected WatchEventType = "disconnected"
+	// AllocatorDisconnectedTimeout is sent once when an allocator has been
+	// disconnected for longer than the DisconnectedTimeout.
+	AllocatorDisconnectedTimeout WatchEventType = "disconnected_timeout"
+	// AllocatorReconnected is sent when a disconnected allocator connects.
+	AllocatorReconnected WatchEventType = "reconnected"
+	// AllocatorEnteredMaintenance is sent when an allocator enters
+	// maintenance mode.
+	AllocatorEnteredMaintenance WatchEventType = "entered_maintenance"
+	// AllocatorLeftMaintenance is sent when an allocator leaves maintenance
+	// mode.
+	AllocatorLeftMaintenance WatchEventType = "left_maintenance"
+	// AllocatorCapacityAboveThreshold is sent when the allocator memory fill
+	// crosses the CapacityThreshold upwards.
+	AllocatorCapacityAboveThreshold WatchEventType = "capacity_above_threshold"
+	// AllocatorCapacityBelowThreshold is sent when the allocator memory fill
+	// goes back under the CapacityThreshold.
+	AllocatorCapacityBelowThreshold WatchEventType = "capacity_below_threshold"
+	// AllocatorUnhealthyInstances is sent when an allocator which has
+	// instances becomes disconnected or unhealthy.
+	AllocatorUnhealthyInstances WatchEventType = "unhealthy_instances"
+	// WatchError is sent when the allocators cannot be obtained.
+	WatchError WatchEventType = "error"
+)
+
+var errContextCannotBeNil = errors.New("context cannot be nil")
+
+// WatchParams is consumed by Watch.
+type WatchParams struct {
+	*api.API
+
+	// Context which stops the watcher when cancelled.
+	Context context.Context
+
+	Region string
+
+	// Optional search request to restrict the watched allocators.
+	Request models.SearchRequest
+
+	// Frequency at which the allocators are polled. Defaults to
+	// DefaultWatchFrequency.
+	Frequency time.Duration
+
+	// Optional memory fill percentage which sends a capacity event when
+	// crossed. Disabled when 0.
+	CapacityThreshold float64
+
+	// Optional duration after which a disconnected allocator sends an
+	// AllocatorDisconnectedTimeout event. Disabled when 0.
+	DisconnectedTimeout time.Duration
+}
+
+// Validate ensures the parameters are usable by Watch.
+func (params WatchParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid allocator watch params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Context == nil {
+		merr = merr.Append(errContextCannotBeNil)
+	}
+
+	if params.CapacityThreshold < 0 || params.CapacityThreshold > 100 {
+		merr = merr.Append(errors.New("capacity threshold must be between 0 and 100"))
+	}
+
+	if err := params.Request.Validate(strfmt.Default); err != nil {
+		merr = merr.Append(err)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// WatchEvent is a change in the state of an allocator.
+type WatchEvent struct {
+	Type        WatchEventType
+	AllocatorID string
+	ZoneID      string
+	Time        time.Time
+
+	// Memory fill percentage of the allocator.
+	Fill float64
+
+	// Number of instances on the allocator.
+	Instances int
+
+	// Duration since the allocator was first seen disconnected, only set
+	// when the allocator is disconnected.
+	DisconnectedFor time.Duration
+
+	// Allocator as returned by the API, nil for WatchError events.
+	Allocator *models.AllocatorInfo
+
+	// Err is set on WatchError events.
+	Err error
+}
+
+// Watch polls the allocators every Frequency and sends the changes in their
+// state as events to the returned channel, until the context is cancelled,
+// at which point the channel is closed. The first poll sends events for the
+// allocators which are already disconnected, in maintenance mode, over the
+// capacity threshold or with instances while unhealthy. API errors are sent
+// as WatchError events and don't stop the watcher.
+func Watch(params WatchParams) (<-chan WatchEvent, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if params.Frequency <= 0 {
+		params.Frequency = DefaultWatchFrequency
+	}
+
+	var w = newAllocatorWatcher(params)
+	var out = make(chan WatchEvent)
+	go w.run(out, time.NewTicker(params.Frequency))
+
+	return out, nil
+}
+
+// allocatorState is the last known state of an allocator.
+type allocatorState struct {
+	connected          bool
+	unhealthyInstances bool
+	maintenance        bool
+	aboveThreshold     bool
+	disconnectedSince  time.Time
+	timedOut           bool
+}
+
+type allocatorWatcher struct {
+	params WatchParams
+	now    func() time.Time
+	states map[string]*allocatorState
+}
+
+func newAllocatorWatcher(params WatchParams) *allocatorWatcher {
+	return &allocatorWatcher{
+		params: params,
+		now:    time.Now,
+		states: make(map[string]*allocatorState),
+	}
+}
+
+func (w *allocatorWatcher) run(c chan<- WatchEvent, ticker *time.Ticker) {
+	defer close(c)
+	defer ticker.Stop()
+
+	for {
+		for _, event := range w.poll() {
+			select {
+			case c <- event:
+			case <-w.params.Context.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-w.params.Context.Done():
+			return
+		}
+	}
+}
+
+// poll searches the allocators with the watch context, so an in-flight
+// request is cancelled along with the watcher.
+func (w *allocatorWatcher) poll() []WatchEvent {
+	res, err := w.params.API.V1API.PlatformInfrastructure.SearchAllocators(
+		platform_infrastructure.NewSearchAllocatorsParams().
+			WithContext(api.WithRegion(w.params.Context, w.params.Region)).
+			WithBody(&w.params.Request),
+		w.params.AuthWriter,
+	)
+	if err != nil {
+		if w.params.Context.Err() != nil {
+			return nil
+		}
+		return []WatchEvent{{Type: WatchError, Time: w.now(), Err: apierror.Wrap(err)}}
+	}
+
+	return w.process(res.Payload)
+}
+
+// process compares the allocators with their last known state and returns
+// the events for any changes. Allocators which are no longer returned are
+// forgotten.
+func (w *allocatorWatcher) process(overview *models.AllocatorOverview) []WatchEvent {
+	var events []WatchEvent
+	var now = w.now()
+	var seen = make(map[string]bool)
+	for _, zone := range overview.Zones {
+		for _, alloc := range zone.Allocators {
+			if alloc.AllocatorID == nil {
+				continue
+			}
+			seen[*alloc.AllocatorID] = true
+			events = append(events, w.processAllocator(zone, alloc, now)...)
+		}
+	}
+
+	for id := range w.states {
+		if !seen[id] {
+			delete(w.states, id)
+		}
+	}
+
+	return events
+}
+
+func (w *allocatorWatcher) processAllocator(zone *models.AllocatorZoneInfo, alloc *models.AllocatorInfo, now time.Time) []WatchEvent {
+	var current = allocatorState{connected: true}
+	if s := alloc.Status; s != nil {
+		current.connected = s.Connected != nil && *s.Connected
+		current.maintenance = s.MaintenanceMode != nil && *s.MaintenanceMode
+		current.unhealthyInstances = len(alloc.Instances) > 0 &&
+			(!current.connected || s.Healthy == nil || !*s.Healthy)
+	}
+
+	var fill float64
+	if c := alloc.Capacity; c != nil && c.Memory != nil && c.Memory.Total != nil &&
+		c.Memory.Used != nil && *c.Memory.Total > 0 {
+		fill = float64(*c.Memory.Used) * 100 / float64(*c.Memory.Total)
+	}
+	current.aboveThreshold = w.params.CapacityThreshold > 0 && fill > w.params.CapacityThreshold
+
+	previous, known := w.states[*alloc.AllocatorID]
+	if !known {
+		// Treat unknown allocators as healthy so their current state is sent.
+		previous = &allocatorState{connected: true}
+	}
+
+	var newEvent = func(t WatchEventType) WatchEvent {
+		var e = WatchEvent{
+			Type:        t,
+			AllocatorID: *alloc.AllocatorID,
+			Time:        now,
+			Fill:        fill,
+			Instances:   len(alloc.Instances),
+			Allocator:   alloc,
+		}
+		if zone.ZoneID != nil {
+			e.ZoneID = *zone.ZoneID
+		}
+		if !current.connected {
+			e.DisconnectedFor = now.Sub(current.disconnectedSince)
+		}
+		return e
+	}
+
+	var events []WatchEvent
+	switch {
+	case !current.connected && previous.connected:
+		current.disconnectedSince = now
+		events = append(events, newEvent(AllocatorDisconnected))
+	case !current.connected:
+		current.disconnectedSince = previous.disconnectedSince
+		current.timedOut = previous.timedOut
+	case current.connected && !previous.connected:
+		events = append(events, newEvent(AllocatorReconnected))
+	}
+
+	if !current.connected && !current.timedOut && w.params.DisconnectedTimeout > 0 &&
+		now.Sub(current.disconnectedSince) >= w.params.DisconnectedTimeout {
+		current.timedOut = true
+		events = append(events, newEvent(AllocatorDisconnectedTimeout))
+	}
+
+	if current.maintenance != previous.maintenance {
+		if current.maintenance {
+			events = append(events, newEvent(AllocatorEnteredMaintenance))
+		} else {
+			events = append(events, newEvent(AllocatorLeftMaintenance))
+		}
+	}
+
+	if current.aboveThreshold != previous.aboveThreshold {
+		if current.aboveThreshold {
+			events = append(events, newEvent(AllocatorCapacityAboveThreshold))
+		} else {
+			events = append(events, newEvent(AllocatorCapacityBelowThreshold))
+		}
+	}
+
+	if current.unhealthyInstances && !previous.unhealthyInstances {
+		events = append(events, newEvent(AllocatorUnhealthyInstances))
+	}
+
+	w.states[*alloc.AllocatorID] = &current
+	return events
+}
@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package allocatorapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+type watchAllocator struct {
+	id          string
+	connected   bool
+	maintenance bool
+	used        int32
+	instances   int
+}
+
+func newWatchOverview(allocators ...watchAllocator) *models.AllocatorOverview {
+	var zone = &models.AllocatorZoneInfo{ZoneID: ec.String("zone-1")}
+	for _, a := range allocators {
+		var info = &models.AllocatorInfo{
+			AllocatorID: ec.String(a.id),
+			Capacity: &models.AllocatorCapacity{Memory: &models.AllocatorCapacityMemory{
+				Total: ec.Int32(1000), Used: ec.Int32(a.used),
+			}},
+			Status: &models.AllocatorHealthStatus{
+				Connected:       ec.Bool(a.connected),
+				Healthy:         ec.Bool(a.connected),
+				MaintenanceMode: ec.Bool(a.maintenance),
+			},
+		}
+		for i := 0; i < a.instances; i++ {
+			info.Instances = append(info.Instances, &models.AllocatedInstanceStatus{
+				ClusterID: ec.String(mock.ValidClusterID),
+			})
+		}
+		zone.Allocators = append(zone.Allocators, info)
+	}
+	return &models.AllocatorOverview{Zones: []*models.AllocatorZoneInfo{zone}}
+}
+
+type watchEventSummary struct {
+	Type            WatchEventType
+	AllocatorID     string
+	DisconnectedFor time.Duration
+}
+
+func summarizeWatchEvents(events []WatchEvent) []watchEventSummary {
+	var res []watchEventSummary
+	for _, e := range events {
+		res = append(res, watchEventSummary{e.Type, e.AllocatorID, e.DisconnectedFor})
+	}
+	return res
+}
+
+func TestAllocatorWatcherProcess(t *testing.T) {
+	var start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var w = newAllocatorWatcher(WatchParams{
+		CapacityThreshold:   80,
+		DisconnectedTimeout: 5 * time.Minute,
+	})
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		overview *models.AllocatorOverview
+		want     []watchEventSummary
+	}{
+		{
+			name: "sends the current state of unknown allocators",
+			overview: newWatchOverview(
+				watchAllocator{id: "i-1", connected: true, used: 500},
+				watchAllocator{id: "i-2", used: 900, instances: 1},
+			),
+			want: []watchEventSummary{
+				{Type: AllocatorDisconnected, AllocatorID: "i-2"},
+				{Type: AllocatorCapacityAboveThreshold, AllocatorID: "i-2"},
+				{Type: AllocatorUnhealthyInstances, AllocatorID: "i-2"},
+			},
+		},
+		{
+			name:    "sends maintenance changes",
+			elapsed: 3 * time.Minute,
+			overview: newWatchOverview(
+				watchAllocator{id: "i-1", connected: true, maintenance: true, used: 500},
+				watchAllocator{id: "i-2", used: 900, instances: 1},
+			),
+			want: []watchEventSummary{
+				{Type: AllocatorEnteredMaintenance, AllocatorID: "i-1"},
+			},
+		},
+		{
+			name:    "sends the disconnected timeout",
+			elapsed: 6 * time.Minute,
+			overview: newWatchOverview(
+				watchAllocator{id: "i-1", connected: true, maintenance: true, used: 500},
+				watchAllocator{id: "i-2", used: 900, instances: 1},
+			),
+			want: []watchEventSummary{
+				{Type: AllocatorDisconnectedTimeout, AllocatorID: "i-2", DisconnectedFor: 6 * time.Minute},
+			},
+		},
+		{
+			name:    "sends the disconnected timeout once",
+			elapsed: 7 * time.Minute,
+			overview: newWatchOverview(
+				watchAllocator{id: "i-1", connected: true, maintenance: true, used: 500},
+				watchAllocator{id: "i-2", used: 900, instances: 1},
+			),
+		},
+		{
+			name:    "sends the recoveries",
+			elapsed: 8 * time.Minute,
+			overview: newWatchOverview(
+				watchAllocator{id: "i-1", connected: true, used: 500},
+				watchAllocator{id: "i-2", connected: true, used: 500, instances: 1},
+			),
+			want: []watchEventSummary{
+				{Type: AllocatorLeftMaintenance, AllocatorID: "i-1"},
+				{Type: AllocatorReconnected, AllocatorID: "i-2"},
+				{Type: AllocatorCapacityBelowThreshold, AllocatorID: "i-2"},
+			},
+		},
+		{
+			name:     "forgets the allocators which aren't returned",
+			elapsed:  9 * time.Minute,
+			overview: newWatchOverview(watchAllocator{id: "i-1", connected: true, used: 500}),
+		},
+		{
+			name:    "sends the current state of the forgotten allocators",
+			elapsed: 10 * time.Minute,
+			overview: newWatchOverview(
+				watchAllocator{id: "i-1", connected: true, used: 500},
+				watchAllocator{id: "i-2", connected: true, maintenance: true},
+			),
+			want: []watchEventSummary{
+				{Type: AllocatorEnteredMaintenance, AllocatorID: "i-2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w.now = func() time.Time { return start.Add(tt.elapsed) }
+			assert.Equal(t, tt.want, summarizeWatchEvents(w.process(tt.overview)))
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("fails due to parameter validation", func(t *testing.T) {
+		got, err := Watch(WatchParams{CapacityThreshold: 120})
+		assert.Nil(t, got)
+		assert.EqualError(t, err, multierror.NewPrefixed("invalid allocator watch params",
+			apierror.ErrMissingAPI,
+			errContextCannotBeNil,
+			errors.New("capacity threshold must be between 0 and 100"),
+			errors.New("region not specified and is required for this operation"),
+		).Error())
+	})
+
+	t.Run("sends events until the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := Watch(WatchParams{
+			API: api.NewMock(
+				mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators/_search",
+						Body:   mock.NewStringBody(`{"sort":null}` + "\n"),
+					},
+					mock.NewStructBody(newWatchOverview(watchAllocator{id: "i-1"})),
+				),
+				mock.SampleInternalError(),
+			),
+			Context:   ctx,
+			Region:    "us-east-1",
+			Frequency: time.Millisecond,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		var e = <-events
+		assert.Equal(t, AllocatorDisconnected, e.Type)
+		assert.Equal(t, "i-1", e.AllocatorID)
+		assert.Equal(t, "zone-1", e.ZoneID)
+
+		e = <-events
+		assert.Equal(t, WatchError, e.Type)
+		assert.EqualError(t, e.Err, mock.MultierrorInternalError.Error())
+
+		cancel()
+		for range events {
+		}
+	})
+
+	t.Run("doesn't send an error event when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var w = newAllocatorWatcher(WatchParams{
+			API:     api.NewMock(mock.SampleInternalError()),
+			Context: ctx,
+			Region:  "us-east-1",
+		})
+		assert.Empty(t, w.poll())
+	})
+}
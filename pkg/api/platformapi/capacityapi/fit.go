@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package capacityapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errFitMemoryMustBePositive = errors.New("capacity fit: memory must be greater than 0")
+
+// Fit returns how many more instances of the specified memory in MB (i.e.
+// 4096 for 4GB instances) of the instance configuration fit in the zone. An
+// empty zone considers all the zones. Only the available allocators matching
+// the instance configuration are considered, and each instance must fit in
+// the free memory and the free storage of a single allocator.
+func (r *CapacityReport) Fit(configID, zoneID string, memory int64) (int64, error) {
+	if memory <= 0 {
+		return 0, errFitMemoryMustBePositive
+	}
+
+	var config *InstanceConfigurationCapacity
+	for i := range r.InstanceConfigurations {
+		if r.InstanceConfigurations[i].ID == configID {
+			config = &r.InstanceConfigurations[i]
+			break
+		}
+	}
+	if config == nil {
+		return 0, fmt.Errorf("capacity fit: instance configuration %s not found", configID)
+	}
+
+	var matches = make(map[string]bool, len(config.Allocators))
+	for _, id := range config.Allocators {
+		matches[id] = true
+	}
+
+	var storage = int64(float64(memory) * config.StorageMultiplier)
+	var count int64
+	for _, alloc := range r.Allocators {
+		if !matches[alloc.AllocatorID] || !alloc.Available {
+			continue
+		}
+		if zoneID != "" && alloc.ZoneID != zoneID {
+			continue
+		}
+
+		var n = alloc.MemoryFree() / memory
+		if storage > 0 && alloc.StorageTotal > 0 {
+			if ns := alloc.StorageFree() / storage; ns < n {
+				n = ns
+			}
+		}
+		if n > 0 {
+			count += n
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package capacityapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapacityReportFit(t *testing.T) {
+	var report = &CapacityReport{
+		Allocators: []AllocatorCapacity{
+			{
+				AllocatorID: "i-a", ZoneID: "zone-1", Available: true,
+				Capacity: Capacity{MemoryTotal: 8192, MemoryUsed: 4096, StorageTotal: 327680, StorageUsed: 122880},
+			},
+			{
+				AllocatorID: "i-b", ZoneID: "zone-1", Available: true,
+				Capacity: Capacity{MemoryTotal: 8192, StorageTotal: 8192},
+			},
+			{
+				AllocatorID: "i-c", ZoneID: "zone-2",
+				Capacity: Capacity{MemoryTotal: 4096, MemoryUsed: 1024},
+			},
+			{
+				AllocatorID: "i-d", ZoneID: "zone-2", Available: true,
+				Capacity: Capacity{MemoryTotal: 4096},
+			},
+		},
+		InstanceConfigurations: []InstanceConfigurationCapacity{
+			{ID: "highio", StorageMultiplier: 30, Allocators: []string{"i-a", "i-c", "i-d"}},
+			{ID: "kibana", StorageMultiplier: 2, Allocators: []string{"i-a", "i-b", "i-c", "i-d"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config string
+		zone   string
+		memory int64
+		want   int64
+		err    error
+	}{
+		{
+			name:   "fails due to invalid memory",
+			config: "highio",
+			err:    errFitMemoryMustBePositive,
+		},
+		{
+			name:   "fails due to unknown instance configuration",
+			config: "unknown",
+			memory: 1024,
+			err:    errors.New("capacity fit: instance configuration unknown not found"),
+		},
+		{
+			name:   "is limited by the free memory",
+			config: "highio",
+			zone:   "zone-1",
+			memory: 1024,
+			want:   4,
+		},
+		{
+			name:   "doesn't count partial instances",
+			config: "highio",
+			zone:   "zone-1",
+			memory: 3000,
+			want:   1,
+		},
+		{
+			name:   "is limited by the free storage",
+			config: "kibana",
+			zone:   "zone-1",
+			memory: 512,
+			want:   16,
+		},
+		{
+			name:   "ignores unavailable allocators across all zones",
+			config: "highio",
+			memory: 1024,
+			want:   8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := report.Fit(tt.config, tt.zone, tt.memory)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,398 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package capacityapi
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/allocatorapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/instanceconfigapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// ReportParams is consumed by Report.
+type ReportParams struct {
+	*api.API
+
+	Region string
+
+	// Optional Elasticsearch search query to restrict the allocators.
+	Query string
+
+	// Optional filter tags with expected format: key:value slice. i.e.
+	// [key:val, key:value].
+	FilterTags string
+
+	// Optional metadata keys by which the allocators are grouped in tag
+	// sets. When empty, no tag sets are reported.
+	TagKeys []string
+
+	// Optional toggle to include the disconnected allocators without
+	// instances.
+	ShowAll bool
+}
+
+// Validate ensures the parameters are usable by Report.
+func (params ReportParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid capacity report params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// CapacityReport is the platform capacity of a region.
+type CapacityReport struct {
+	Zones                  []ZoneCapacity                  `json:"zones"`
+	TagSets                []TagSetCapacity                `json:"tag_sets,omitempty"`
+	InstanceConfigurations []InstanceConfigurationCapacity `json:"instance_configurations"`
+	Allocators             []AllocatorCapacity             `json:"allocators"`
+}
+
+// Capacity is the memory and storage capacity in MB. The used storage is
+// estimated from the instances memory and their instance configuration
+// storage multiplier.
+type Capacity struct {
+	MemoryTotal  int64 `json:"memory_total"`
+	MemoryUsed   int64 `json:"memory_used"`
+	StorageTotal int64 `json:"storage_total"`
+	StorageUsed  int64 `json:"storage_used"`
+}
+
+// MemoryFree returns the free memory in MB.
+func (c Capacity) MemoryFree() int64 { return c.MemoryTotal - c.MemoryUsed }
+
+// StorageFree returns the free storage in MB.
+func (c Capacity) StorageFree() int64 { return c.StorageTotal - c.StorageUsed }
+
+func (c *Capacity) add(o Capacity) {
+	c.MemoryTotal += o.MemoryTotal
+	c.MemoryUsed += o.MemoryUsed
+	c.StorageTotal += o.StorageTotal
+	c.StorageUsed += o.StorageUsed
+}
+
+// AllocatorCapacity is the capacity of a single allocator.
+type AllocatorCapacity struct {
+	AllocatorID string            `json:"allocator_id"`
+	ZoneID      string            `json:"zone_id"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Instances   int               `json:"instances"`
+
+	// Available is true when the allocator is connected, healthy and not in
+	// maintenance mode, so it can receive new instances.
+	Available bool `json:"available"`
+
+	Capacity
+}
+
+// ZoneCapacity is the aggregated capacity of the allocators in a zone.
+type ZoneCapacity struct {
+	ZoneID     string `json:"zone_id"`
+	Allocators int    `json:"allocators"`
+	Available  int    `json:"available"`
+	Instances  int    `json:"instances"`
+
+	// Maximum memory in MB available in a single allocator of the zone, as
+	// reported by the platform zone summary.
+	MaxAvailableCapacity int64 `json:"max_available_capacity,omitempty"`
+
+	Capacity
+}
+
+// TagSetCapacity is the aggregated capacity of the allocators which share
+// the same values for the report tag keys.
+type TagSetCapacity struct {
+	Tags       map[string]string `json:"tags"`
+	Allocators int               `json:"allocators"`
+	Available  int               `json:"available"`
+
+	Capacity
+}
+
+// InstanceConfigurationCapacity is the capacity of the allocators which
+// match the instance configuration allocator filter, per zone.
+type InstanceConfigurationCapacity struct {
+	ID                string         `json:"id"`
+	Name              string         `json:"name"`
+	InstanceType      string         `json:"instance_type"`
+	StorageMultiplier float64        `json:"storage_multiplier"`
+	Allocators        []string       `json:"allocators"`
+	Zones             []ZoneCapacity `json:"zones"`
+}
+
+// Report obtains the capacity of the region allocators, aggregated per zone,
+// per tag set and per instance configuration. The allocators matching each
+// instance configuration are obtained by searching the allocators with the
+// instance configuration allocator filter.
+func Report(params ReportParams) (*CapacityReport, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	overview, err := allocatorapi.List(allocatorapi.ListParams{
+		API:        params.API,
+		Region:     params.Region,
+		Query:      params.Query,
+		FilterTags: params.FilterTags,
+		ShowAll:    params.ShowAll,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := platformapi.GetInfo(platformapi.GetInfoParams{
+		API: params.API, Region: params.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := instanceconfigapi.List(instanceconfigapi.ListParams{
+		API: params.API, Region: params.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var multipliers = make(map[string]float64, len(configs))
+	for _, config := range configs {
+		multipliers[config.ID] = config.StorageMultiplier
+	}
+
+	var report = CapacityReport{Allocators: make([]AllocatorCapacity, 0)}
+	for _, zone := range overview.Zones {
+		for _, alloc := range zone.Allocators {
+			report.Allocators = append(report.Allocators,
+				newAllocatorCapacity(zone, alloc, multipliers),
+			)
+		}
+	}
+	sort.Slice(report.Allocators, func(i, j int) bool {
+		return report.Allocators[i].AllocatorID < report.Allocators[j].AllocatorID
+	})
+
+	report.Zones = zoneCapacities(report.Allocators, zoneSummaries(info, params.Region))
+	report.TagSets = tagSetCapacities(report.Allocators, params.TagKeys)
+
+	report.InstanceConfigurations = make([]InstanceConfigurationCapacity, 0, len(configs))
+	for _, config := range configs {
+		capacity, err := instanceConfigurationCapacity(params, config, report.Allocators)
+		if err != nil {
+			return nil, multierror.NewPrefixed(
+				"capacity report: instance configuration "+config.ID, err,
+			)
+		}
+		report.InstanceConfigurations = append(report.InstanceConfigurations, capacity)
+	}
+
+	return &report, nil
+}
+
+func newAllocatorCapacity(zone *models.AllocatorZoneInfo, alloc *models.AllocatorInfo, multipliers map[string]float64) AllocatorCapacity {
+	var capacity = AllocatorCapacity{
+		Instances: len(alloc.Instances),
+		Tags:      make(map[string]string, len(alloc.Metadata)),
+	}
+	if alloc.AllocatorID != nil {
+		capacity.AllocatorID = *alloc.AllocatorID
+	}
+	if zone.ZoneID != nil {
+		capacity.ZoneID = *zone.ZoneID
+	}
+
+	for _, m := range alloc.Metadata {
+		if m.Key != nil && m.Value != nil {
+			capacity.Tags[*m.Key] = *m.Value
+		}
+	}
+
+	if s := alloc.Status; s != nil {
+		capacity.Available = s.Connected != nil && *s.Connected &&
+			s.Healthy != nil && *s.Healthy &&
+			(s.MaintenanceMode == nil || !*s.MaintenanceMode)
+	}
+
+	if c := alloc.Capacity; c != nil {
+		if c.Memory != nil && c.Memory.Total != nil {
+			capacity.MemoryTotal = int64(*c.Memory.Total)
+		}
+		if c.Memory != nil && c.Memory.Used != nil {
+			capacity.MemoryUsed = int64(*c.Memory.Used)
+		}
+		if c.Storage != nil && c.Storage.Total != nil {
+			capacity.StorageTotal = *c.Storage.Total
+		}
+	}
+
+	var storage float64
+	for _, instance := range alloc.Instances {
+		if instance.NodeMemory != nil {
+			storage += float64(*instance.NodeMemory) * multipliers[instance.InstanceConfigurationID]
+		}
+	}
+	capacity.StorageUsed = int64(storage)
+
+	return capacity
+}
+
+// zoneSummaries returns the platform zone summaries of the region by zone.
+func zoneSummaries(info *models.PlatformInfo, region string) map[string]*models.AllocatorsZoneSummary {
+	var summaries = make(map[string]*models.AllocatorsZoneSummary)
+	for _, r := range info.Regions {
+		if r.RegionID == nil || *r.RegionID != region || r.Allocators == nil {
+			continue
+		}
+		for _, summary := range r.Allocators.ZoneSummaries {
+			if summary != nil && summary.ZoneID != nil {
+				summaries[*summary.ZoneID] = summary
+			}
+		}
+	}
+	return summaries
+}
+
+func zoneCapacities(allocators []AllocatorCapacity, summaries map[string]*models.AllocatorsZoneSummary) []ZoneCapacity {
+	var zones = make(map[string]*ZoneCapacity)
+	var ids []string
+	for _, alloc := range allocators {
+		zone, ok := zones[alloc.ZoneID]
+		if !ok {
+			zone = &ZoneCapacity{ZoneID: alloc.ZoneID}
+			if s := summaries[alloc.ZoneID]; s != nil && s.MaxAvailableCapacity != nil {
+				zone.MaxAvailableCapacity = int64(*s.MaxAvailableCapacity)
+			}
+			zones[alloc.ZoneID] = zone
+			ids = append(ids, alloc.ZoneID)
+		}
+
+		zone.Allocators++
+		zone.Instances += alloc.Instances
+		if alloc.Available {
+			zone.Available++
+		}
+		zone.add(alloc.Capacity)
+	}
+
+	sort.Strings(ids)
+	var res = make([]ZoneCapacity, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, *zones[id])
+	}
+	return res
+}
+
+func tagSetCapacities(allocators []AllocatorCapacity, keys []string) []TagSetCapacity {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var sets = make(map[string]*TagSetCapacity)
+	var ids []string
+	for _, alloc := range allocators {
+		var tags = make(map[string]string, len(keys))
+		var pairs = make([]string, 0, len(keys))
+		for _, key := range keys {
+			tags[key] = alloc.Tags[key]
+			pairs = append(pairs, key+":"+alloc.Tags[key])
+		}
+
+		var id = strings.Join(pairs, ",")
+		set, ok := sets[id]
+		if !ok {
+			set = &TagSetCapacity{Tags: tags}
+			sets[id] = set
+			ids = append(ids, id)
+		}
+
+		set.Allocators++
+		if alloc.Available {
+			set.Available++
+		}
+		set.add(alloc.Capacity)
+	}
+
+	sort.Strings(ids)
+	var res = make([]TagSetCapacity, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, *sets[id])
+	}
+	return res
+}
+
+func instanceConfigurationCapacity(params ReportParams, config *models.InstanceConfiguration, allocators []AllocatorCapacity) (InstanceConfigurationCapacity, error) {
+	var capacity = InstanceConfigurationCapacity{
+		ID:                config.ID,
+		InstanceType:      config.InstanceType,
+		StorageMultiplier: config.StorageMultiplier,
+		Allocators:        make([]string, 0),
+	}
+	if config.Name != nil {
+		capacity.Name = *config.Name
+	}
+
+	var matches []AllocatorCapacity
+	if config.AllocatorFilter == nil {
+		matches = allocators
+	} else {
+		res, err := allocatorapi.Search(allocatorapi.SearchParams{
+			API:    params.API,
+			Region: params.Region,
+			Request: models.SearchRequest{
+				Query: config.AllocatorFilter,
+				Size:  int32(len(allocators)),
+			},
+		})
+		if err != nil {
+			return capacity, err
+		}
+
+		var ids = make(map[string]bool)
+		for _, zone := range res.Zones {
+			for _, alloc := range zone.Allocators {
+				if alloc.AllocatorID != nil {
+					ids[*alloc.AllocatorID] = true
+				}
+			}
+		}
+
+		for _, alloc := range allocators {
+			if ids[alloc.AllocatorID] {
+				matches = append(matches, alloc)
+			}
+		}
+	}
+
+	for _, alloc := range matches {
+		capacity.Allocators = append(capacity.Allocators, alloc.AllocatorID)
+	}
+	capacity.Zones = zoneCapacities(matches, nil)
+
+	return capacity, nil
+}
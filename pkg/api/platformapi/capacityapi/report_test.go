@@ -0,0 +1,294 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package capacityapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const regionPath = "/api/v1/regions/us-east-1/platform"
+
+var highIOFilter = &models.QueryContainer{
+	Term: map[string]models.TermQuery{
+		"metadata.instanceFamily": {Value: ec.String("highio")},
+	},
+}
+
+func newAllocator(id string, memTotal, memUsed int32, storage int64, family string, maintenance bool, instances ...*models.AllocatedInstanceStatus) *models.AllocatorInfo {
+	return &models.AllocatorInfo{
+		AllocatorID: ec.String(id),
+		Capacity: &models.AllocatorCapacity{
+			Memory:  &models.AllocatorCapacityMemory{Total: ec.Int32(memTotal), Used: ec.Int32(memUsed)},
+			Storage: &models.AllocatorCapacityStorage{Total: ec.Int64(storage)},
+		},
+		Metadata: []*models.MetadataItem{
+			{Key: ec.String("instanceFamily"), Value: ec.String(family)},
+		},
+		Status: &models.AllocatorHealthStatus{
+			Connected:       ec.Bool(true),
+			Healthy:         ec.Bool(true),
+			MaintenanceMode: ec.Bool(maintenance),
+		},
+		Instances: instances,
+	}
+}
+
+func newInstance(config string, memory int32) *models.AllocatedInstanceStatus {
+	return &models.AllocatedInstanceStatus{
+		ClusterID:               ec.String(mock.ValidClusterID),
+		InstanceConfigurationID: config,
+		NodeMemory:              ec.Int32(memory),
+	}
+}
+
+func listAllocatorsResponse() mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   regionPath + "/infrastructure/allocators",
+		},
+		mock.NewStructBody(models.AllocatorOverview{Zones: []*models.AllocatorZoneInfo{
+			{
+				ZoneID: ec.String("zone-2"),
+				Allocators: []*models.AllocatorInfo{
+					newAllocator("i-c", 4096, 1024, 0, "highio", true,
+						newInstance("kibana", 1024),
+					),
+				},
+			},
+			{
+				ZoneID: ec.String("zone-1"),
+				Allocators: []*models.AllocatorInfo{
+					newAllocator("i-b", 8192, 0, 81920, "highstorage", false),
+					newAllocator("i-a", 8192, 4096, 327680, "highio", false,
+						newInstance("highio", 2048),
+						newInstance("highio", 2048),
+					),
+				},
+			},
+		}}),
+	)
+}
+
+func platformResponse() mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   regionPath,
+		},
+		mock.NewStructBody(models.PlatformInfo{Regions: []*models.RegionInfo{
+			{
+				RegionID: ec.String("us-east-1"),
+				Allocators: &models.AllocatorsSummary{
+					ZoneSummaries: []*models.AllocatorsZoneSummary{
+						{ZoneID: ec.String("zone-1"), MaxAvailableCapacity: ec.Int32(8192)},
+					},
+				},
+			},
+		}}),
+	)
+}
+
+func instanceConfigurationsResponse() mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   regionPath + "/configuration/instances",
+		},
+		mock.NewStructBody([]*models.InstanceConfiguration{
+			{
+				ID:                "highio",
+				Name:              ec.String("High IO"),
+				InstanceType:      "elasticsearch",
+				StorageMultiplier: 30,
+				AllocatorFilter:   highIOFilter,
+			},
+			{
+				ID:                "kibana",
+				Name:              ec.String("Kibana"),
+				InstanceType:      "kibana",
+				StorageMultiplier: 2,
+			},
+		}),
+	)
+}
+
+func searchAllocatorsResponse() mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultWriteMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "POST",
+			Path:   regionPath + "/infrastructure/allocators/_search",
+			Body:   mock.NewStructBody(models.SearchRequest{Query: highIOFilter, Size: 3}),
+		},
+		mock.NewStructBody(models.AllocatorOverview{Zones: []*models.AllocatorZoneInfo{
+			{ZoneID: ec.String("zone-1"), Allocators: []*models.AllocatorInfo{{AllocatorID: ec.String("i-a")}}},
+			{ZoneID: ec.String("zone-2"), Allocators: []*models.AllocatorInfo{{AllocatorID: ec.String("i-c")}}},
+		}}),
+	)
+}
+
+func TestReport(t *testing.T) {
+	var allocators = []AllocatorCapacity{
+		{
+			AllocatorID: "i-a", ZoneID: "zone-1", Instances: 2, Available: true,
+			Tags:     map[string]string{"instanceFamily": "highio"},
+			Capacity: Capacity{MemoryTotal: 8192, MemoryUsed: 4096, StorageTotal: 327680, StorageUsed: 122880},
+		},
+		{
+			AllocatorID: "i-b", ZoneID: "zone-1", Available: true,
+			Tags:     map[string]string{"instanceFamily": "highstorage"},
+			Capacity: Capacity{MemoryTotal: 8192, StorageTotal: 81920},
+		},
+		{
+			AllocatorID: "i-c", ZoneID: "zone-2", Instances: 1,
+			Tags:     map[string]string{"instanceFamily": "highio"},
+			Capacity: Capacity{MemoryTotal: 4096, MemoryUsed: 1024, StorageUsed: 2048},
+		},
+	}
+	var zone2 = ZoneCapacity{
+		ZoneID: "zone-2", Allocators: 1, Instances: 1,
+		Capacity: Capacity{MemoryTotal: 4096, MemoryUsed: 1024, StorageUsed: 2048},
+	}
+
+	tests := []struct {
+		name   string
+		params ReportParams
+		want   *CapacityReport
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid capacity report params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails listing the allocators",
+			params: ReportParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "fails searching the instance configuration allocators",
+			params: ReportParams{
+				API: api.NewMock(
+					listAllocatorsResponse(),
+					platformResponse(),
+					instanceConfigurationsResponse(),
+					mock.SampleNotFoundError(),
+				),
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed("capacity report: instance configuration highio",
+				mock.MultierrorNotFound,
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: ReportParams{
+				API: api.NewMock(
+					listAllocatorsResponse(),
+					platformResponse(),
+					instanceConfigurationsResponse(),
+					searchAllocatorsResponse(),
+				),
+				Region:  "us-east-1",
+				TagKeys: []string{"instanceFamily"},
+			},
+			want: &CapacityReport{
+				Allocators: allocators,
+				Zones: []ZoneCapacity{
+					{
+						ZoneID: "zone-1", Allocators: 2, Available: 2, Instances: 2,
+						MaxAvailableCapacity: 8192,
+						Capacity:             Capacity{MemoryTotal: 16384, MemoryUsed: 4096, StorageTotal: 409600, StorageUsed: 122880},
+					},
+					zone2,
+				},
+				TagSets: []TagSetCapacity{
+					{
+						Tags:       map[string]string{"instanceFamily": "highio"},
+						Allocators: 2, Available: 1,
+						Capacity: Capacity{MemoryTotal: 12288, MemoryUsed: 5120, StorageTotal: 327680, StorageUsed: 124928},
+					},
+					{
+						Tags:       map[string]string{"instanceFamily": "highstorage"},
+						Allocators: 1, Available: 1,
+						Capacity: Capacity{MemoryTotal: 8192, StorageTotal: 81920},
+					},
+				},
+				InstanceConfigurations: []InstanceConfigurationCapacity{
+					{
+						ID: "highio", Name: "High IO", InstanceType: "elasticsearch", StorageMultiplier: 30,
+						Allocators: []string{"i-a", "i-c"},
+						Zones: []ZoneCapacity{
+							{
+								ZoneID: "zone-1", Allocators: 1, Available: 1, Instances: 2,
+								Capacity: Capacity{MemoryTotal: 8192, MemoryUsed: 4096, StorageTotal: 327680, StorageUsed: 122880},
+							},
+							zone2,
+						},
+					},
+					{
+						ID: "kibana", Name: "Kibana", InstanceType: "kibana", StorageMultiplier: 2,
+						Allocators: []string{"i-a", "i-b", "i-c"},
+						Zones: []ZoneCapacity{
+							{
+								ZoneID: "zone-1", Allocators: 2, Available: 2, Instances: 2,
+								Capacity: Capacity{MemoryTotal: 16384, MemoryUsed: 4096, StorageTotal: 409600, StorageUsed: 122880},
+							},
+							zone2,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Report(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
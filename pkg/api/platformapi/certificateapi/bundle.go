@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+var (
+	errBundleEmpty         = errors.New("bundle cannot be empty")
+	errBundleNoKey         = errors.New("bundle does not contain a private key")
+	errBundleMultipleKeys  = errors.New("bundle contains more than one private key")
+	errBundleNoCertificate = errors.New("bundle does not contain any certificates")
+	errKeyMismatch         = errors.New("private key does not match the leaf certificate public key")
+)
+
+// Bundle is a parsed PEM encoded certificate bundle, composed of a private
+// key and its certificate chain. The first certificate in the chain is the
+// leaf certificate, followed by the intermediates in signing order.
+type Bundle struct {
+	PrivateKey   crypto.PrivateKey
+	Certificates []*x509.Certificate
+}
+
+// ParseBundle decodes a PEM encoded bundle containing an unencrypted private
+// key and a certificate chain. Any PEM blocks which aren't a certificate or
+// a private key are ignored.
+func ParseBundle(b []byte) (*Bundle, error) {
+	if len(b) == 0 {
+		return nil, errBundleEmpty
+	}
+
+	var bundle Bundle
+	var merr = multierror.NewPrefixed("certificate bundle")
+	for rest := b; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				merr = merr.Append(fmt.Errorf(
+					"certificate %d: %w", len(bundle.Certificates), err,
+				))
+				continue
+			}
+			bundle.Certificates = append(bundle.Certificates, cert)
+		case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+			if bundle.PrivateKey != nil {
+				merr = merr.Append(errBundleMultipleKeys)
+				continue
+			}
+			key, err := parsePrivateKey(block)
+			if err != nil {
+				merr = merr.Append(err)
+				continue
+			}
+			bundle.PrivateKey = key
+		}
+	}
+
+	if err := merr.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	return &bundle, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+}
+
+// Leaf returns the leaf certificate of the bundle, or nil when the bundle has
+// no certificates.
+func (b *Bundle) Leaf() *x509.Certificate {
+	if len(b.Certificates) == 0 {
+		return nil
+	}
+	return b.Certificates[0]
+}
+
+// ValidateBundleParams is consumed by Bundle.Validate.
+type ValidateBundleParams struct {
+	// Optional hostnames which must be covered by the leaf certificate
+	// subject alternative names.
+	Hostnames []string
+
+	// Optional time used to verify the validity period of all the chain
+	// certificates. Defaults to the current time.
+	Now time.Time
+
+	// When set, the bundle is not required to contain a private key.
+	SkipPrivateKey bool
+}
+
+// Validate ensures that the bundle is usable as a platform certificate:
+//   - The private key matches the leaf certificate public key.
+//   - Each certificate in the chain is signed by the next one.
+//   - The leaf certificate covers all of the specified hostnames.
+//   - None of the certificates are expired or not yet valid.
+func (b *Bundle) Validate(params ValidateBundleParams) error {
+	var merr = multierror.NewPrefixed("certificate bundle")
+	if len(b.Certificates) == 0 {
+		merr = merr.Append(errBundleNoCertificate)
+	}
+
+	if b.PrivateKey == nil && !params.SkipPrivateKey {
+		merr = merr.Append(errBundleNoKey)
+	}
+
+	if err := merr.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	if b.PrivateKey != nil && !keyMatches(b.PrivateKey, b.Leaf().PublicKey) {
+		merr = merr.Append(errKeyMismatch)
+	}
+
+	for i := 0; i < len(b.Certificates)-1; i++ {
+		if err := b.Certificates[i].CheckSignatureFrom(b.Certificates[i+1]); err != nil {
+			merr = merr.Append(fmt.Errorf(
+				"chain order: certificate %d (%s) is not signed by certificate %d (%s)",
+				i, b.Certificates[i].Subject, i+1, b.Certificates[i+1].Subject,
+			))
+		}
+	}
+
+	for _, hostname := range params.Hostnames {
+		if err := b.Leaf().VerifyHostname(hostname); err != nil {
+			merr = merr.Append(fmt.Errorf(
+				"leaf certificate does not cover hostname %s", hostname,
+			))
+		}
+	}
+
+	var now = params.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	for i, cert := range b.Certificates {
+		if now.After(cert.NotAfter) {
+			merr = merr.Append(fmt.Errorf(
+				"certificate %d (%s) expired on %s", i, cert.Subject,
+				cert.NotAfter.UTC().Format(time.RFC3339),
+			))
+		}
+		if now.Before(cert.NotBefore) {
+			merr = merr.Append(fmt.Errorf(
+				"certificate %d (%s) is not valid until %s", i, cert.Subject,
+				cert.NotBefore.UTC().Format(time.RFC3339),
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func keyMatches(key crypto.PrivateKey, pub crypto.PublicKey) bool {
+	type publicKeyEqualer interface {
+		Equal(crypto.PublicKey) bool
+	}
+
+	var public crypto.PublicKey
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		public = k.Public()
+	case *ecdsa.PrivateKey:
+		public = k.Public()
+	case ed25519.PrivateKey:
+		public = k.Public()
+	default:
+		return false
+	}
+
+	if p, ok := public.(publicKeyEqualer); ok {
+		return p.Equal(pub)
+	}
+	return false
+}
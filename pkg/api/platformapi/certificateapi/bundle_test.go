@@ -0,0 +1,247 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+var testNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type testCert struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+	pem  []byte
+}
+
+func (c testCert) keyPEM(t *testing.T) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// newTestCert creates a certificate signed by the parent, or a self-signed CA
+// certificate when the parent is nil.
+func newTestCert(t *testing.T, cn string, parent *testCert, notAfter time.Time, dnsNames ...string) testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		DNSNames:              dnsNames,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+
+	var signerCert, signerKey = template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testCert{
+		key:  key,
+		cert: cert,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func joinPEM(blocks ...[]byte) []byte {
+	var out []byte
+	for _, b := range blocks {
+		out = append(out, b...)
+	}
+	return out
+}
+
+func TestParseBundle(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil, testNow.Add(720*time.Hour))
+	leaf := newTestCert(t, "ece.example.com", &ca, testNow.Add(240*time.Hour), "*.ece.example.com")
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  int
+		key   bool
+		err   string
+	}{
+		{
+			name: "fails on empty input",
+			err:  errBundleEmpty.Error(),
+		},
+		{
+			name:  "fails with multiple keys",
+			input: joinPEM(leaf.keyPEM(t), ca.keyPEM(t), leaf.pem),
+			err: multierror.NewPrefixed("certificate bundle",
+				errBundleMultipleKeys,
+			).Error(),
+		},
+		{
+			name:  "parses key and chain",
+			input: joinPEM(leaf.keyPEM(t), leaf.pem, ca.pem),
+			want:  2,
+			key:   true,
+		},
+		{
+			name:  "parses chain without key",
+			input: joinPEM(leaf.pem, ca.pem),
+			want:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBundle(tt.input)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Certificates, tt.want)
+			assert.Equal(t, tt.key, got.PrivateKey != nil)
+			assert.Equal(t, leaf.cert, got.Leaf())
+		})
+	}
+}
+
+func TestBundleValidate(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil, testNow.Add(720*time.Hour))
+	leaf := newTestCert(t, "ece.example.com", &ca, testNow.Add(240*time.Hour), "*.ece.example.com", "ece.example.com")
+	expired := newTestCert(t, "old.example.com", &ca, testNow.Add(-time.Hour), "old.example.com")
+	other := newTestCert(t, "Other CA", nil, testNow.Add(720*time.Hour))
+
+	tests := []struct {
+		name   string
+		bundle Bundle
+		params ValidateBundleParams
+		err    error
+	}{
+		{
+			name:   "fails on empty bundle",
+			bundle: Bundle{},
+			err: multierror.NewPrefixed("certificate bundle",
+				errBundleNoCertificate,
+				errBundleNoKey,
+			),
+		},
+		{
+			name: "fails on mismatched key",
+			bundle: Bundle{
+				PrivateKey:   other.key,
+				Certificates: []*x509.Certificate{leaf.cert, ca.cert},
+			},
+			params: ValidateBundleParams{Now: testNow},
+			err:    multierror.NewPrefixed("certificate bundle", errKeyMismatch),
+		},
+		{
+			name: "fails on wrong chain order",
+			bundle: Bundle{
+				PrivateKey:   leaf.key,
+				Certificates: []*x509.Certificate{leaf.cert, other.cert},
+			},
+			params: ValidateBundleParams{Now: testNow},
+			err: multierror.NewPrefixed("certificate bundle",
+				errors.New("chain order: certificate 0 (CN=ece.example.com) is not signed by certificate 1 (CN=Other CA)"),
+			),
+		},
+		{
+			name: "fails on uncovered hostnames and expiry",
+			bundle: Bundle{
+				PrivateKey:   expired.key,
+				Certificates: []*x509.Certificate{expired.cert, ca.cert},
+			},
+			params: ValidateBundleParams{Now: testNow, Hostnames: []string{"ece.example.com"}},
+			err: multierror.NewPrefixed("certificate bundle",
+				errors.New("leaf certificate does not cover hostname ece.example.com"),
+				errors.New("certificate 0 (CN=old.example.com) expired on 2025-12-31T23:00:00Z"),
+			),
+		},
+		{
+			name: "fails on not yet valid certificate",
+			bundle: Bundle{
+				PrivateKey:   leaf.key,
+				Certificates: []*x509.Certificate{leaf.cert, ca.cert},
+			},
+			params: ValidateBundleParams{Now: testNow.Add(-8760 * time.Hour)},
+			err: multierror.NewPrefixed("certificate bundle",
+				errors.New("certificate 0 (CN=ece.example.com) is not valid until 2025-01-11T00:00:00Z"),
+				errors.New("certificate 1 (CN=Test CA) is not valid until 2025-01-31T00:00:00Z"),
+			),
+		},
+		{
+			name: "succeeds",
+			bundle: Bundle{
+				PrivateKey:   leaf.key,
+				Certificates: []*x509.Certificate{leaf.cert, ca.cert},
+			},
+			params: ValidateBundleParams{
+				Now:       testNow,
+				Hostnames: []string{"ece.example.com", "admin.ece.example.com"},
+			},
+		},
+		{
+			name: "succeeds without a key when skipped",
+			bundle: Bundle{
+				Certificates: []*x509.Certificate{leaf.cert, ca.cert},
+			},
+			params: ValidateBundleParams{Now: testNow, SkipPrivateKey: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bundle.Validate(tt.params)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
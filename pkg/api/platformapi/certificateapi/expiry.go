@@ -0,0 +1,193 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const (
+	// TLSCertificate is the Expiry type of the platform TLS certificates.
+	TLSCertificate = "tls"
+
+	// ExtraCertificate is the Expiry type of the extra certificates.
+	ExtraCertificate = "extra"
+)
+
+var errChainEmpty = errors.New("certificate chain is empty")
+
+// Expiry describes when a platform certificate chain expires.
+type Expiry struct {
+	// Name is the TLS service name or the extra certificate ID.
+	Name string `json:"name"`
+
+	// Type is either TLSCertificate or ExtraCertificate.
+	Type string `json:"type"`
+
+	// ExpirationDate is the date on which the first certificate in the chain
+	// expires, effectively invalidating the chain.
+	ExpirationDate time.Time `json:"expiration_date"`
+
+	// FirstCertificateToExpire describes the first certificate to expire.
+	FirstCertificateToExpire string `json:"first_certificate_to_expire"`
+}
+
+// ExpiresWithin returns true when the chain expires before now + window.
+func (e Expiry) ExpiresWithin(window time.Duration, now time.Time) bool {
+	return e.ExpirationDate.Before(now.Add(window))
+}
+
+// ReportExpiryParams is consumed by ReportExpiry.
+type ReportExpiryParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by ReportExpiry.
+func (params ReportExpiryParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid certificate expiry report params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// ReportExpiry returns the expiry dates of all the platform TLS certificate
+// chains and extra certificates, sorted by the earliest expiration date.
+// Chains which can't be obtained or parsed are reported in the returned
+// error, while the rest are still returned.
+func ReportExpiry(params ReportExpiryParams) ([]Expiry, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var report []Expiry
+	var merr = multierror.NewPrefixed("certificate expiry report")
+	for _, service := range Services {
+		chain, err := GetTLS(GetTLSParams{
+			API: params.API, Region: params.Region, Service: service,
+		})
+		if err != nil {
+			merr = merr.Append(multierror.NewPrefixed("tls "+service, err))
+			continue
+		}
+
+		expiry, err := tlsChainExpiry(chain.Chain, chain.ChainStatus)
+		if err != nil {
+			merr = merr.Append(multierror.NewPrefixed("tls "+service, err))
+			continue
+		}
+		expiry.Name, expiry.Type = service, TLSCertificate
+		report = append(report, expiry)
+	}
+
+	extra, err := ListExtra(ListExtraParams{API: params.API, Region: params.Region})
+	if err != nil {
+		merr = merr.Append(multierror.NewPrefixed("extra certificates", err))
+	}
+
+	if extra != nil {
+		for id, chain := range extra.Certs {
+			expiry, err := chainExpiry(chain.Chain)
+			if err != nil {
+				merr = merr.Append(multierror.NewPrefixed("extra "+id, err))
+				continue
+			}
+			expiry.Name, expiry.Type = id, ExtraCertificate
+			report = append(report, expiry)
+		}
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].ExpirationDate.Equal(report[j].ExpirationDate) {
+			return report[i].Name < report[j].Name
+		}
+		return report[i].ExpirationDate.Before(report[j].ExpirationDate)
+	})
+
+	return report, merr.ErrorOrNil()
+}
+
+// tlsChainExpiry prefers the chain status computed by the API, falling back
+// to parsing the chain when it's not present.
+func tlsChainExpiry(chain []string, status *models.ChainStatus) (Expiry, error) {
+	if status == nil || status.ExpirationDate == nil {
+		return chainExpiry(chain)
+	}
+
+	var expiry = Expiry{ExpirationDate: time.Time(*status.ExpirationDate).UTC()}
+	if status.FirstCertificateToExpire != nil {
+		expiry.FirstCertificateToExpire = *status.FirstCertificateToExpire
+	}
+
+	return expiry, nil
+}
+
+// chainExpiry parses the PEM encoded certificates in the chain and returns
+// the expiry of the first certificate to expire.
+func chainExpiry(chain []string) (Expiry, error) {
+	var certs []*x509.Certificate
+	for _, encoded := range chain {
+		for rest := []byte(strings.TrimSpace(encoded)); ; {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return Expiry{}, err
+			}
+			certs = append(certs, cert)
+		}
+	}
+
+	if len(certs) == 0 {
+		return Expiry{}, errChainEmpty
+	}
+
+	var first = certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(first.NotAfter) {
+			first = cert
+		}
+	}
+
+	return Expiry{
+		ExpirationDate:           first.NotAfter.UTC(),
+		FirstCertificateToExpire: first.Subject.String(),
+	}, nil
+}
@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestReportExpiry(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil, testNow.Add(720*time.Hour))
+	proxy := newTestCert(t, "proxy.example.com", &ca, testNow.Add(48*time.Hour), "proxy.example.com")
+	admin := newTestCert(t, "admin.example.com", &ca, testNow.Add(480*time.Hour), "admin.example.com")
+	extra := newTestCert(t, "extra.example.com", &ca, testNow.Add(24*time.Hour), "extra.example.com")
+
+	uiExpiry := strfmt.DateTime(testNow.Add(96 * time.Hour))
+	tlsResponse := func(service string, chain *models.TLSPublicCertChain) mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "GET",
+				Path:   tlsPath + service,
+			},
+			mock.NewStructBody(chain),
+		)
+	}
+
+	tests := []struct {
+		name   string
+		params ReportExpiryParams
+		want   []Expiry
+		err    string
+	}{
+		{
+			name: "reports all chains sorted by expiration",
+			params: ReportExpiryParams{
+				Region: "us-east-1",
+				API: api.NewMock(
+					tlsResponse("ui", &models.TLSPublicCertChain{
+						Chain: []string{"invalid"},
+						ChainStatus: &models.ChainStatus{
+							ExpirationDate:           &uiExpiry,
+							FirstCertificateToExpire: ec.String("CN=ui.example.com"),
+						},
+					}),
+					tlsResponse("proxy", &models.TLSPublicCertChain{
+						Chain: []string{string(proxy.pem), string(ca.pem)},
+					}),
+					tlsResponse("adminconsole", &models.TLSPublicCertChain{
+						Chain: []string{string(admin.pem) + string(ca.pem)},
+					}),
+					tlsResponse("internalca", &models.TLSPublicCertChain{
+						Chain: []string{string(ca.pem)},
+					}),
+					mock.New200StructResponse(models.PublicCertChainCollection{
+						Certs: map[string]models.PublicCertChain{
+							"star_example_com": {Chain: []string{string(extra.pem), string(ca.pem)}},
+							"empty":            {Chain: []string{}},
+						},
+					}),
+				),
+			},
+			want: []Expiry{
+				{Name: "star_example_com", Type: ExtraCertificate, ExpirationDate: testNow.Add(24 * time.Hour), FirstCertificateToExpire: "CN=extra.example.com"},
+				{Name: "proxy", Type: TLSCertificate, ExpirationDate: testNow.Add(48 * time.Hour), FirstCertificateToExpire: "CN=proxy.example.com"},
+				{Name: "ui", Type: TLSCertificate, ExpirationDate: testNow.Add(96 * time.Hour), FirstCertificateToExpire: "CN=ui.example.com"},
+				{Name: "adminconsole", Type: TLSCertificate, ExpirationDate: testNow.Add(480 * time.Hour), FirstCertificateToExpire: "CN=admin.example.com"},
+				{Name: "internalca", Type: TLSCertificate, ExpirationDate: testNow.Add(720 * time.Hour), FirstCertificateToExpire: "CN=Test CA"},
+			},
+			err: multierror.NewPrefixed("certificate expiry report",
+				multierror.NewPrefixed("extra empty", errChainEmpty),
+			).Error(),
+		},
+		{
+			name: "returns partial results on API errors",
+			params: ReportExpiryParams{
+				Region: "us-east-1",
+				API: api.NewMock(
+					mock.SampleInternalError(),
+					tlsResponse("proxy", &models.TLSPublicCertChain{
+						Chain: []string{string(proxy.pem), string(ca.pem)},
+					}),
+					mock.SampleInternalError(),
+					mock.SampleInternalError(),
+					mock.SampleInternalError(),
+				),
+			},
+			want: []Expiry{
+				{Name: "proxy", Type: TLSCertificate, ExpirationDate: testNow.Add(48 * time.Hour), FirstCertificateToExpire: "CN=proxy.example.com"},
+			},
+			err: multierror.NewPrefixed("certificate expiry report",
+				multierror.NewPrefixed("tls ui", mock.MultierrorInternalError),
+				multierror.NewPrefixed("tls adminconsole", mock.MultierrorInternalError),
+				multierror.NewPrefixed("tls internalca", mock.MultierrorInternalError),
+				multierror.NewPrefixed("extra certificates", mock.MultierrorInternalError),
+			).Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReportExpiry(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpiryExpiresWithin(t *testing.T) {
+	e := Expiry{ExpirationDate: testNow.Add(72 * time.Hour)}
+	assert.True(t, e.ExpiresWithin(96*time.Hour, testNow))
+	assert.False(t, e.ExpiresWithin(48*time.Hour, testNow))
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errIDCannotBeEmpty = errors.New("id not specified and is required for this operation")
+
+// ListExtraParams is consumed by ListExtra.
+type ListExtraParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by ListExtra.
+func (params ListExtraParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid extra certificate list params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// ListExtra returns all of the extra certificate chains.
+func ListExtra(params ListExtraParams) (*models.PublicCertChainCollection, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.Platform.GetExtraCertificates(
+		platform.NewGetExtraCertificatesParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// ExtraParams is consumed by GetExtra and DeleteExtra.
+type ExtraParams struct {
+	*api.API
+
+	// Required extra certificate ID.
+	ID     string
+	Region string
+}
+
+// Validate ensures the parameters are usable.
+func (params ExtraParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid extra certificate params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// GetExtra returns the extra certificate chain matching the ID.
+func GetExtra(params ExtraParams) (*models.PublicCertChain, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.Platform.GetExtraCertificate(
+		platform.NewGetExtraCertificateParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithCertID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// DeleteExtra deletes the extra certificate matching the ID.
+func DeleteExtra(params ExtraParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.Platform.DeleteExtraCertificate(
+			platform.NewDeleteExtraCertificateParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithCertID(params.ID),
+			params.AuthWriter,
+		),
+	)
+}
+
+// SetExtraParams is consumed by SetExtra.
+type SetExtraParams struct {
+	*api.API
+
+	// Required extra certificate ID, i.e. "star_my_domain_com".
+	ID     string
+	Region string
+
+	// Required PEM encoded bundle, containing the unencrypted private key
+	// followed by the certificate chain, starting with the leaf certificate.
+	Bundle []byte
+
+	// Optional hostnames which the leaf certificate must cover.
+	Hostnames []string
+
+	// Optional time used to verify the certificates validity. Defaults to
+	// the current time.
+	Now time.Time
+}
+
+// Validate ensures the parameters are usable by SetExtra, parsing and
+// validating the certificate bundle.
+func (params SetExtraParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid extra certificate set params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	if len(params.Bundle) == 0 {
+		merr = merr.Append(errBundleCannotBeEmpty)
+		return merr.ErrorOrNil()
+	}
+
+	bundle, err := ParseBundle(params.Bundle)
+	if err != nil {
+		return merr.Append(err).ErrorOrNil()
+	}
+
+	merr = merr.Append(bundle.Validate(ValidateBundleParams{
+		Hostnames: params.Hostnames,
+		Now:       params.Now,
+	}))
+
+	return merr.ErrorOrNil()
+}
+
+// SetExtra validates the certificate bundle and creates or updates the extra
+// certificate matching the ID.
+func SetExtra(params SetExtraParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.Platform.SetExtraCertificate(
+			platform.NewSetExtraCertificateParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithCertID(params.ID).
+				WithBody(string(params.Bundle)),
+			params.AuthWriter,
+		),
+	)
+}
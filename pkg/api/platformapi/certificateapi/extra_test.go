@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+const extraPath = "/api/v1/regions/us-east-1/platform/configuration/security/extra_certs"
+
+func TestListExtra(t *testing.T) {
+	var certs = &models.PublicCertChainCollection{
+		Certs: map[string]models.PublicCertChain{
+			"star_example_com": {Chain: []string{"-----BEGIN CERTIFICATE-----"}},
+		},
+	}
+	tests := []struct {
+		name   string
+		params ListExtraParams
+		want   *models.PublicCertChainCollection
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid extra certificate list params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: ListExtraParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   extraPath,
+					},
+					mock.NewStructBody(certs),
+				)),
+				Region: "us-east-1",
+			},
+			want: certs,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListExtra(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetExtra(t *testing.T) {
+	var chain = &models.PublicCertChain{Chain: []string{"-----BEGIN CERTIFICATE-----"}}
+	tests := []struct {
+		name   string
+		params ExtraParams
+		want   *models.PublicCertChain
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid extra certificate params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: ExtraParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+				ID:     "star_example_com",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: ExtraParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   extraPath + "/star_example_com",
+					},
+					mock.NewStructBody(chain),
+				)),
+				Region: "us-east-1",
+				ID:     "star_example_com",
+			},
+			want: chain,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetExtra(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetExtra(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil, time.Now().Add(720*time.Hour))
+	leaf := newTestCert(t, "example.com", &ca, time.Now().Add(240*time.Hour), "*.example.com")
+	bundle := joinPEM(leaf.keyPEM(t), leaf.pem, ca.pem)
+
+	tests := []struct {
+		name   string
+		params SetExtraParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: SetExtraParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+				Bundle: joinPEM(leaf.pem, ca.pem),
+			},
+			err: multierror.NewPrefixed("invalid extra certificate set params",
+				errIDCannotBeEmpty,
+				multierror.NewPrefixed("certificate bundle", errBundleNoKey),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: SetExtraParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: map[string][]string{
+							"Accept":        {"application/json"},
+							"Authorization": {"ApiKey dummy"},
+							"Content-Type":  {"application/text"},
+							"User-Agent":    {"cloud-sdk-go/" + api.Version},
+						},
+						Host:   api.DefaultMockHost,
+						Method: "PUT",
+						Path:   extraPath + "/star_example_com",
+						Body:   mock.NewStringBody(string(bundle)),
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				Region: "us-east-1",
+				ID:     "star_example_com",
+				Bundle: bundle,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetExtra(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeleteExtra(t *testing.T) {
+	err := DeleteExtra(ExtraParams{
+		API: api.NewMock(mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "DELETE",
+				Path:   extraPath + "/star_example_com",
+			},
+			mock.NewStringBody(`{}`),
+		)),
+		Region: "us-east-1",
+		ID:     "star_example_com",
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,180 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_security"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+const (
+	// UIService is the Cloud UI TLS certificate chain.
+	UIService = "ui"
+
+	// ProxyService is the proxy TLS certificate chain.
+	ProxyService = "proxy"
+
+	// AdminConsoleService is the admin console TLS certificate chain.
+	AdminConsoleService = "adminconsole"
+
+	// InternalCAService is the internal CA certificate chain, it can only be
+	// read.
+	InternalCAService = "internalca"
+)
+
+// Services contains all of the platform TLS services.
+var Services = []string{
+	UIService, ProxyService, AdminConsoleService, InternalCAService,
+}
+
+// settableServices contains the services whose certificate can be updated.
+var settableServices = []string{
+	UIService, ProxyService, AdminConsoleService,
+}
+
+var errBundleCannotBeEmpty = errors.New("bundle not specified and is required for this operation")
+
+func validateService(service string, valid []string) error {
+	if !slice.HasString(valid, service) {
+		return fmt.Errorf("service %q is invalid, must be one of %v", service, valid)
+	}
+	return nil
+}
+
+// GetTLSParams is consumed by GetTLS.
+type GetTLSParams struct {
+	*api.API
+
+	// Required TLS service name, one of Services.
+	Service string
+	Region  string
+}
+
+// Validate ensures the parameters are usable by GetTLS.
+func (params GetTLSParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid tls certificate get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(validateService(params.Service, Services))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// GetTLS returns the current certificate chain for the specified service.
+func GetTLS(params GetTLSParams) (*models.TLSPublicCertChain, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.GetTLSCertificate(
+		platform_configuration_security.NewGetTLSCertificateParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithServiceName(params.Service),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// SetTLSParams is consumed by SetTLS.
+type SetTLSParams struct {
+	*api.API
+
+	// Required TLS service name, one of UIService, ProxyService or
+	// AdminConsoleService.
+	Service string
+	Region  string
+
+	// Required PEM encoded bundle, containing the unencrypted private key
+	// followed by the certificate chain, starting with the leaf certificate.
+	Bundle []byte
+
+	// Optional hostnames which the leaf certificate must cover, i.e. the
+	// platform Cloud UI or proxy endpoints.
+	Hostnames []string
+
+	// Optional time used to verify the certificates validity. Defaults to
+	// the current time.
+	Now time.Time
+}
+
+// Validate ensures the parameters are usable by SetTLS, parsing and
+// validating the certificate bundle.
+func (params SetTLSParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid tls certificate set params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(validateService(params.Service, settableServices))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	if len(params.Bundle) == 0 {
+		merr = merr.Append(errBundleCannotBeEmpty)
+		return merr.ErrorOrNil()
+	}
+
+	bundle, err := ParseBundle(params.Bundle)
+	if err != nil {
+		return merr.Append(err).ErrorOrNil()
+	}
+
+	merr = merr.Append(bundle.Validate(ValidateBundleParams{
+		Hostnames: params.Hostnames,
+		Now:       params.Now,
+	}))
+
+	return merr.ErrorOrNil()
+}
+
+// SetTLS validates the certificate bundle and uploads it as the new
+// certificate chain for the specified service.
+func SetTLS(params SetTLSParams) (*models.UpdatedTLSChain, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.SetTLSCertificate(
+		platform_configuration_security.NewSetTLSCertificateParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithServiceName(params.Service).
+			WithChain(string(params.Bundle)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package certificateapi
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const tlsPath = "/api/v1/regions/us-east-1/platform/configuration/security/tls/"
+
+func TestGetTLS(t *testing.T) {
+	var chain = &models.TLSPublicCertChain{
+		Chain:        []string{"-----BEGIN CERTIFICATE-----"},
+		UserSupplied: ec.Bool(true),
+	}
+	tests := []struct {
+		name   string
+		params GetTLSParams
+		want   *models.TLSPublicCertChain
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid tls certificate get params",
+				apierror.ErrMissingAPI,
+				errors.New(`service "" is invalid, must be one of [ui proxy adminconsole internalca]`),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: GetTLSParams{
+				API:     api.NewMock(mock.SampleNotFoundError()),
+				Region:  "us-east-1",
+				Service: ProxyService,
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetTLSParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   tlsPath + "ui",
+					},
+					mock.NewStructBody(chain),
+				)),
+				Region:  "us-east-1",
+				Service: UIService,
+			},
+			want: chain,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetTLS(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetTLS(t *testing.T) {
+	ca := newTestCert(t, "Test CA", nil, time.Now().Add(720*time.Hour))
+	leaf := newTestCert(t, "ece.example.com", &ca, time.Now().Add(240*time.Hour), "*.ece.example.com")
+	other := newTestCert(t, "Other", nil, time.Now().Add(240*time.Hour))
+	bundle := joinPEM(leaf.keyPEM(t), leaf.pem, ca.pem)
+
+	tests := []struct {
+		name   string
+		params SetTLSParams
+		want   *models.UpdatedTLSChain
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: SetTLSParams{
+				API:     api.NewMock(),
+				Service: InternalCAService,
+			},
+			err: multierror.NewPrefixed("invalid tls certificate set params",
+				errors.New(`service "internalca" is invalid, must be one of [ui proxy adminconsole]`),
+				errors.New("region not specified and is required for this operation"),
+				errBundleCannotBeEmpty,
+			).Error(),
+		},
+		{
+			name: "fails due to bundle validation",
+			params: SetTLSParams{
+				API:       api.NewMock(),
+				Region:    "us-east-1",
+				Service:   ProxyService,
+				Bundle:    joinPEM(other.keyPEM(t), leaf.pem, ca.pem),
+				Hostnames: []string{"*.ece.example.com", "ece.other.com"},
+			},
+			err: multierror.NewPrefixed("invalid tls certificate set params",
+				multierror.NewPrefixed("certificate bundle",
+					errKeyMismatch,
+					errors.New("leaf certificate does not cover hostname ece.other.com"),
+				),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: SetTLSParams{
+				API: api.NewMock(mock.New202ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   tlsPath + "proxy",
+						Body:   mock.NewStringBody(string(bundle)),
+					},
+					mock.NewStructBody(models.UpdatedTLSChain{Service: ec.String("proxy")}),
+				)),
+				Region:    "us-east-1",
+				Service:   ProxyService,
+				Bundle:    bundle,
+				Hostnames: []string{"*.ece.example.com"},
+			},
+			want: &models.UpdatedTLSChain{Service: ec.String("proxy")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetTLS(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+// DefaultModifyRetries is the number of times that Modify retries when the
+// option has been concurrently modified.
+const DefaultModifyRetries = 3
+
+var errFuncCannotBeNil = errors.New("modify function not specified and is required for this operation")
+
+// ModifyFunc receives the current value of a config store option and returns
+// the value to be set.
+type ModifyFunc func(current string) (string, error)
+
+// ModifyParams is consumed by Modify.
+type ModifyParams struct {
+	*api.API
+
+	// Required config store option name.
+	Name   string
+	Region string
+
+	// Required function which computes the new value from the current one.
+	Func ModifyFunc
+
+	// Number of times to retry when the option has been concurrently
+	// modified. Defaults to DefaultModifyRetries.
+	Retries int
+}
+
+// Validate ensures the parameters are usable by Modify.
+func (params ModifyParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option modify params")
+	merr = merr.Append(GetParams{
+		API: params.API, Name: params.Name, Region: params.Region,
+	}.Validate())
+
+	if params.Func == nil {
+		merr = merr.Append(errFuncCannotBeNil)
+	}
+
+	if params.Retries < 0 {
+		merr = merr.Append(errors.New("retries cannot be negative"))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Modify performs an optimistic read-modify-write of a config store option.
+// The option is obtained along with its version, the new value is computed
+// by Func, and the update is sent with the obtained version. When the option
+// has been modified in the meantime, the whole cycle is retried.
+func Modify(params ModifyParams) (*models.ConfigStoreOption, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var retries = params.Retries
+	if retries == 0 {
+		retries = DefaultModifyRetries
+	}
+
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		var res *models.ConfigStoreOption
+		if res, err = modify(params); !IsConflict(err) {
+			return res, err
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"config store option %s: giving up after %d conflicting updates: %w",
+		params.Name, retries+1, err,
+	)
+}
+
+func modify(params ModifyParams) (*models.ConfigStoreOption, error) {
+	current, version, err := Get(GetParams{
+		API: params.API, Name: params.Name, Region: params.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var value = optionValue(current)
+	newValue, err := params.Func(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if newValue == value {
+		return current, nil
+	}
+
+	res, _, err := Update(UpdateParams{
+		API:     params.API,
+		Name:    params.Name,
+		Value:   newValue,
+		Region:  params.Region,
+		Version: version,
+	})
+	return res, err
+}
@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func TestModify(t *testing.T) {
+	var upper = func(current string) (string, error) {
+		return strings.ToUpper(current), nil
+	}
+	var conflictErr = multierror.NewPrefixed("api error",
+		errors.New("config_store.version_conflict: version conflict"),
+	)
+	tests := []struct {
+		name   string
+		params ModifyParams
+		want   *models.ConfigStoreOption
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: ModifyParams{
+				Retries: -1,
+			},
+			err: multierror.NewPrefixed("invalid config store option modify params",
+				multierror.NewPrefixed("invalid config store option get params",
+					apierror.ErrMissingAPI,
+					errNameCannotBeEmpty,
+					errors.New("region not specified and is required for this operation"),
+				),
+				errFuncCannotBeNil,
+				errors.New("retries cannot be negative"),
+			).Error(),
+		},
+		{
+			name: "fails when the function returns an error",
+			params: ModifyParams{
+				API:    api.NewMock(getResponse("some-option", "value", "1")),
+				Name:   "some-option",
+				Region: "us-east-1",
+				Func: func(string) (string, error) {
+					return "", errors.New("some error")
+				},
+			},
+			err: "some error",
+		},
+		{
+			name: "doesn't update the option when the value is unchanged",
+			params: ModifyParams{
+				API:    api.NewMock(getResponse("some-option", "VALUE", "1")),
+				Name:   "some-option",
+				Region: "us-east-1",
+				Func:   upper,
+			},
+			want: newOption("some-option", "VALUE"),
+		},
+		{
+			name: "succeeds on the first attempt",
+			params: ModifyParams{
+				API: api.NewMock(
+					getResponse("some-option", "value", "1"),
+					updateResponse("some-option", "VALUE", "1", "2"),
+				),
+				Name:   "some-option",
+				Region: "us-east-1",
+				Func:   upper,
+			},
+			want: newOption("some-option", "VALUE"),
+		},
+		{
+			name: "succeeds after a conflict",
+			params: ModifyParams{
+				API: api.NewMock(
+					getResponse("some-option", "value", "1"),
+					conflictResponse(),
+					getResponse("some-option", "other", "2"),
+					updateResponse("some-option", "OTHER", "2", "3"),
+				),
+				Name:   "some-option",
+				Region: "us-east-1",
+				Func:   upper,
+			},
+			want: newOption("some-option", "OTHER"),
+		},
+		{
+			name: "gives up after the retries are exhausted",
+			params: ModifyParams{
+				API: api.NewMock(
+					getResponse("some-option", "value", "1"),
+					conflictResponse(),
+					getResponse("some-option", "value", "2"),
+					conflictResponse(),
+				),
+				Name:    "some-option",
+				Region:  "us-east-1",
+				Func:    upper,
+				Retries: 1,
+			},
+			err: "config store option some-option: giving up after 2 conflicting updates: " +
+				conflictErr.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Modify(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errNameCannotBeEmpty = errors.New("name not specified and is required for this operation")
+
+// ListParams is consumed by List.
+type ListParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by List.
+func (params ListParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option list params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// List returns all of the config store options.
+func List(params ListParams) (*models.ConfigStoreOptionList, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.ListConfigStoreOption(
+		platform_infrastructure.NewListConfigStoreOptionParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// GetParams is consumed by Get and the typed getters.
+type GetParams struct {
+	*api.API
+
+	// Required config store option name.
+	Name   string
+	Region string
+}
+
+// Validate ensures the parameters are usable.
+func (params GetParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Name == "" {
+		merr = merr.Append(errNameCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Get returns the config store option matching the name, along with its
+// version, which can be used to update the option with optimistic
+// concurrency control.
+func Get(params GetParams) (*models.ConfigStoreOption, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.GetConfigStoreOption(
+		platform_infrastructure.NewGetConfigStoreOptionParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithConfigOptionID(params.Name),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// CreateParams is consumed by Create.
+type CreateParams struct {
+	*api.API
+
+	// Required config store option name.
+	Name   string
+	Value  string
+	Region string
+}
+
+// Validate ensures the parameters are usable by Create.
+func (params CreateParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option create params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Name == "" {
+		merr = merr.Append(errNameCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Create creates a new config store option, returning the created option
+// and its version.
+func Create(params CreateParams) (*models.ConfigStoreOption, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.CreateConfigStoreOption(
+		platform_infrastructure.NewCreateConfigStoreOptionParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithConfigOptionID(params.Name).
+			WithBody(&models.ConfigStoreOptionData{Value: ec.String(params.Value)}),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// UpdateParams is consumed by Update.
+type UpdateParams struct {
+	*api.API
+
+	// Required config store option name.
+	Name   string
+	Value  string
+	Region string
+
+	// Optional version as returned by Get. When set, the update fails with a
+	// conflict if the option has been modified since it was obtained.
+	Version string
+}
+
+// Validate ensures the parameters are usable by Update.
+func (params UpdateParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option update params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Name == "" {
+		merr = merr.Append(errNameCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Update replaces the value of an existing config store option, returning
+// the updated option and its new version.
+func Update(params UpdateParams) (*models.ConfigStoreOption, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	var version *string
+	if params.Version != "" {
+		version = ec.String(params.Version)
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.PutConfigStoreOption(
+		platform_infrastructure.NewPutConfigStoreOptionParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithConfigOptionID(params.Name).
+			WithVersion(version).
+			WithBody(&models.ConfigStoreOptionData{Value: ec.String(params.Value)}),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// DeleteParams is consumed by Delete.
+type DeleteParams struct {
+	*api.API
+
+	// Required config store option name.
+	Name   string
+	Region string
+}
+
+// Validate ensures the parameters are usable by Delete.
+func (params DeleteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option delete params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Name == "" {
+		merr = merr.Append(errNameCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Delete deletes the config store option matching the name.
+func Delete(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformInfrastructure.DeleteConfigStoreOption(
+			platform_infrastructure.NewDeleteConfigStoreOptionParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithConfigOptionID(params.Name),
+			params.AuthWriter,
+		),
+	)
+}
+
+// IsNotFound returns true when the error is caused by a missing config store
+// option.
+func IsNotFound(err error) bool {
+	var getErr *platform_infrastructure.GetConfigStoreOptionNotFound
+	var putErr *platform_infrastructure.PutConfigStoreOptionNotFound
+	var deleteErr *platform_infrastructure.DeleteConfigStoreOptionNotFound
+	return errors.As(err, &getErr) || errors.As(err, &putErr) ||
+		errors.As(err, &deleteErr)
+}
+
+// IsConflict returns true when the error is caused by a version mismatch
+// when updating a config store option.
+func IsConflict(err error) bool {
+	var conflict *platform_infrastructure.PutConfigStoreOptionConflict
+	return errors.As(err, &conflict)
+}
@@ -0,0 +1,309 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func TestList(t *testing.T) {
+	var options = &models.ConfigStoreOptionList{Values: []*models.ConfigStoreOption{
+		newOption("some-option", "some-value"),
+	}}
+	tests := []struct {
+		name   string
+		params ListParams
+		want   *models.ConfigStoreOptionList
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid config store option list params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: ListParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: ListParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   storePath,
+					},
+					mock.NewStructBody(options),
+				)),
+				Region: "us-east-1",
+			},
+			want: options,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := List(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      GetParams
+		want        *models.ConfigStoreOption
+		wantVersion string
+		notFound    bool
+		err         string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid config store option get params",
+				apierror.ErrMissingAPI,
+				errNameCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when the option doesn't exist",
+			params: GetParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Name:   "some-option",
+				Region: "us-east-1",
+			},
+			notFound: true,
+			err:      mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetParams{
+				API:    api.NewMock(getResponse("some-option", "some-value", "3")),
+				Name:   "some-option",
+				Region: "us-east-1",
+			},
+			want:        newOption("some-option", "some-value"),
+			wantVersion: "3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, version, err := Get(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.notFound, IsNotFound(err))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params CreateParams
+		want   *models.ConfigStoreOption
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid config store option create params",
+				apierror.ErrMissingAPI,
+				errNameCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: CreateParams{
+				API:    api.NewMock(mock.SampleBadRequestError()),
+				Name:   "some-option",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorBadRequest.Error(),
+		},
+		{
+			name: "succeeds",
+			params: CreateParams{
+				API:    api.NewMock(createResponse("some-option", "some-value")),
+				Name:   "some-option",
+				Value:  "some-value",
+				Region: "us-east-1",
+			},
+			want: newOption("some-option", "some-value"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := Create(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      UpdateParams
+		want        *models.ConfigStoreOption
+		wantVersion string
+		conflict    bool
+		err         string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid config store option update params",
+				apierror.ErrMissingAPI,
+				errNameCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails on version conflict",
+			params: UpdateParams{
+				API:     api.NewMock(conflictResponse()),
+				Name:    "some-option",
+				Value:   "new-value",
+				Version: "2",
+				Region:  "us-east-1",
+			},
+			conflict: true,
+			err: multierror.NewPrefixed("api error",
+				errors.New("config_store.version_conflict: version conflict"),
+			).Error(),
+		},
+		{
+			name: "succeeds with version",
+			params: UpdateParams{
+				API:     api.NewMock(updateResponse("some-option", "new-value", "2", "3")),
+				Name:    "some-option",
+				Value:   "new-value",
+				Version: "2",
+				Region:  "us-east-1",
+			},
+			want:        newOption("some-option", "new-value"),
+			wantVersion: "3",
+		},
+		{
+			name: "succeeds without version",
+			params: UpdateParams{
+				API:    api.NewMock(updateResponse("some-option", "new-value", "", "3")),
+				Name:   "some-option",
+				Value:  "new-value",
+				Region: "us-east-1",
+			},
+			want:        newOption("some-option", "new-value"),
+			wantVersion: "3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, version, err := Update(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.conflict, IsConflict(err))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DeleteParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid config store option delete params",
+				apierror.ErrMissingAPI,
+				errNameCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: DeleteParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Name:   "some-option",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: DeleteParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "DELETE",
+						Path:   storePath + "/some-option",
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				Name:   "some-option",
+				Region: "us-east-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Delete(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errDirectoryCannotBeEmpty = errors.New("folder not specified and is required for the operation")
+
+// PullToDirectoryParams is used to store all config store options in a local
+// directory.
+type PullToDirectoryParams struct {
+	*api.API
+	Directory string
+	Region    string
+}
+
+// Validate ensures that the parameters are correct.
+func (params PullToDirectoryParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option pull params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Directory == "" {
+		merr = merr.Append(errDirectoryCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// PullToDirectory downloads the config store options and saves them in a
+// local folder.
+func PullToDirectory(params PullToDirectoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	res, err := List(ListParams{API: params.API, Region: params.Region})
+	if err != nil {
+		return err
+	}
+
+	return writeOptionsToDirectory(params.Directory, res.Values)
+}
+
+// writeOptionsToDirectory writes all the config store options to a folder
+// following this structure:
+//
+//	folder/
+//	folder/name.json
+func writeOptionsToDirectory(folder string, options []*models.ConfigStoreOption) error {
+	if err := os.MkdirAll(folder, os.ModePerm); err != nil {
+		return err
+	}
+
+	var merr = multierror.NewPrefixed("failed persisting config store options")
+	for _, option := range options {
+		if option == nil || option.Name == nil {
+			continue
+		}
+
+		if err := writeOption(filepath.Join(folder, *option.Name+".json"), option); err != nil {
+			merr = merr.Append(err)
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func writeOption(path string, option *models.ConfigStoreOption) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var enc = json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(option)
+}
@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func TestPullToDirectory(t *testing.T) {
+	var listResponse = mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   storePath,
+		},
+		mock.NewStructBody(models.ConfigStoreOptionList{Values: []*models.ConfigStoreOption{
+			newOption("option-a", "value-a"),
+			newOption("option-b", `{"some":"json"}`),
+		}}),
+	)
+	tests := []struct {
+		name   string
+		params PullToDirectoryParams
+		want   map[string]string
+		err    string
+	}{
+		{
+			name: "fails due to param validation",
+			err: multierror.NewPrefixed("invalid config store option pull params",
+				apierror.ErrMissingAPI,
+				errDirectoryCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails listing the options due to API error",
+			params: PullToDirectoryParams{
+				API:       api.NewMock(mock.SampleInternalError()),
+				Directory: filepath.Join(t.TempDir(), "store"),
+				Region:    "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "pulls the options successfully",
+			params: PullToDirectoryParams{
+				API:       api.NewMock(listResponse),
+				Directory: filepath.Join(t.TempDir(), "store"),
+				Region:    "us-east-1",
+			},
+			want: map[string]string{
+				"option-a.json": `{
+  "changed": true,
+  "name": "option-a",
+  "value": "value-a"
+}
+`,
+				"option-b.json": `{
+  "changed": true,
+  "name": "option-b",
+  "value": "{\"some\":\"json\"}"
+}
+`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PullToDirectory(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			matches, err := filepath.Glob(filepath.Join(tt.params.Directory, "*.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got = make(map[string]string, len(matches))
+			for _, m := range matches {
+				b, err := os.ReadFile(m)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[filepath.Base(m)] = string(b)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
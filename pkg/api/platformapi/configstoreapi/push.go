@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// PushFromDirectoryParams is used to create or update the config store
+// options from the files in a local directory, as written by PullToDirectory.
+type PushFromDirectoryParams struct {
+	*api.API
+	Directory string
+	Region    string
+
+	// When set, the changes are computed but not applied.
+	DryRun bool
+}
+
+// Validate ensures that the parameters are correct.
+func (params PushFromDirectoryParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid config store option push params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Directory == "" {
+		merr = merr.Append(errDirectoryCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// PushResult contains the names of the config store options which were
+// created, updated or left unchanged by PushFromDirectory.
+type PushResult struct {
+	Created   []string `json:"created,omitempty"`
+	Updated   []string `json:"updated,omitempty"`
+	Unchanged []string `json:"unchanged,omitempty"`
+}
+
+// PushFromDirectory reads all of the config store options from the JSON
+// files in a local folder, creating the options which don't exist and
+// updating the ones whose value differs. Updates are sent with the version
+// obtained when reading the option, so concurrent modifications result in a
+// conflict rather than being overwritten. Options which exist in the store
+// but not in the folder are left untouched.
+func PushFromDirectory(params PushFromDirectoryParams) (*PushResult, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	options, err := readOptionsFromDirectory(params.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PushResult
+	var merr = multierror.NewPrefixed("failed pushing config store options")
+	for _, option := range options {
+		var name, value = *option.Name, optionValue(option)
+		current, version, err := Get(GetParams{
+			API: params.API, Name: name, Region: params.Region,
+		})
+		if err != nil && !IsNotFound(err) {
+			merr = merr.Append(multierror.NewPrefixed(name, err))
+			continue
+		}
+
+		if current == nil {
+			if !params.DryRun {
+				if _, _, err := Create(CreateParams{
+					API: params.API, Name: name, Value: value, Region: params.Region,
+				}); err != nil {
+					merr = merr.Append(multierror.NewPrefixed(name, err))
+					continue
+				}
+			}
+			result.Created = append(result.Created, name)
+			continue
+		}
+
+		if optionValue(current) == value {
+			result.Unchanged = append(result.Unchanged, name)
+			continue
+		}
+
+		if !params.DryRun {
+			if _, _, err := Update(UpdateParams{
+				API: params.API, Name: name, Value: value,
+				Region: params.Region, Version: version,
+			}); err != nil {
+				merr = merr.Append(multierror.NewPrefixed(name, err))
+				continue
+			}
+		}
+		result.Updated = append(result.Updated, name)
+	}
+
+	return &result, merr.ErrorOrNil()
+}
+
+// readOptionsFromDirectory reads the config store options from all of the
+// JSON files in a folder, sorted by file name. When an option has no name,
+// the file name without its extension is used.
+func readOptionsFromDirectory(folder string) ([]*models.ConfigStoreOption, error) {
+	matches, err := filepath.Glob(filepath.Join(folder, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+
+	var options = make([]*models.ConfigStoreOption, 0, len(matches))
+	var merr = multierror.NewPrefixed("failed reading config store options")
+	for _, match := range matches {
+		option, err := readOption(match)
+		if err != nil {
+			merr = merr.Append(err)
+			continue
+		}
+		options = append(options, option)
+	}
+
+	return options, merr.ErrorOrNil()
+}
+
+func readOption(path string) (*models.ConfigStoreOption, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var option models.ConfigStoreOption
+	if err := json.NewDecoder(f).Decode(&option); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	if option.Name == nil || *option.Name == "" {
+		option.Name = ec.String(strings.TrimSuffix(filepath.Base(path), ".json"))
+	}
+
+	if option.Value == nil {
+		return nil, fmt.Errorf("%s: value not specified", filepath.Base(path))
+	}
+
+	return &option, nil
+}
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	var dir = t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestPushFromDirectory(t *testing.T) {
+	var files = map[string]string{
+		"option-a.json": `{"name":"option-a","value":"value-a"}`,
+		"option-b.json": `{"name":"option-b","value":"new-b","changed":true}`,
+		"option-c.json": `{"value":"value-c"}`,
+	}
+	tests := []struct {
+		name   string
+		params PushFromDirectoryParams
+		want   *PushResult
+		err    string
+	}{
+		{
+			name: "fails due to param validation",
+			err: multierror.NewPrefixed("invalid config store option push params",
+				apierror.ErrMissingAPI,
+				errDirectoryCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails reading invalid files",
+			params: PushFromDirectoryParams{
+				API: api.NewMock(),
+				Directory: writeTestFiles(t, map[string]string{
+					"broken.json":  `{`,
+					"novalue.json": `{"name":"novalue"}`,
+				}),
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed("failed reading config store options",
+				errors.New("broken.json: unexpected EOF"),
+				errors.New("novalue.json: value not specified"),
+			).Error(),
+		},
+		{
+			name: "creates, updates and skips the options",
+			params: PushFromDirectoryParams{
+				API: api.NewMock(
+					getResponse("option-a", "value-a", "1"),
+					getResponse("option-b", "old-b", "4"),
+					updateResponse("option-b", "new-b", "4", "5"),
+					mock.SampleNotFoundError(),
+					createResponse("option-c", "value-c"),
+				),
+				Directory: writeTestFiles(t, files),
+				Region:    "us-east-1",
+			},
+			want: &PushResult{
+				Created:   []string{"option-c"},
+				Updated:   []string{"option-b"},
+				Unchanged: []string{"option-a"},
+			},
+		},
+		{
+			name: "computes the changes on dry run",
+			params: PushFromDirectoryParams{
+				API: api.NewMock(
+					getResponse("option-a", "value-a", "1"),
+					getResponse("option-b", "old-b", "4"),
+					mock.SampleNotFoundError(),
+				),
+				Directory: writeTestFiles(t, files),
+				Region:    "us-east-1",
+				DryRun:    true,
+			},
+			want: &PushResult{
+				Created:   []string{"option-c"},
+				Updated:   []string{"option-b"},
+				Unchanged: []string{"option-a"},
+			},
+		},
+		{
+			name: "returns the partial result on failure",
+			params: PushFromDirectoryParams{
+				API: api.NewMock(
+					getResponse("option-a", "value-a", "1"),
+					getResponse("option-b", "old-b", "4"),
+					conflictResponse(),
+					mock.SampleInternalError(),
+				),
+				Directory: writeTestFiles(t, files),
+				Region:    "us-east-1",
+			},
+			want: &PushResult{
+				Unchanged: []string{"option-a"},
+			},
+			err: multierror.NewPrefixed("failed pushing config store options",
+				multierror.NewPrefixed("option-b", multierror.NewPrefixed("api error",
+					errors.New("config_store.version_conflict: version conflict"),
+				)),
+				multierror.NewPrefixed("option-c", mock.MultierrorInternalError),
+			).Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PushFromDirectory(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"net/http"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const storePath = "/api/v1/regions/us-east-1/platform/configuration/store"
+
+func newOption(name, value string) *models.ConfigStoreOption {
+	return &models.ConfigStoreOption{
+		Name:    ec.String(name),
+		Value:   ec.String(value),
+		Changed: ec.Bool(true),
+	}
+}
+
+func versionedResponse(code int, version string, assertion *mock.RequestAssertion, body interface{}) mock.Response {
+	return mock.Response{
+		Response: http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Header:     http.Header{"X-Cloud-Resource-Version": {version}},
+			Body:       mock.NewStructBody(body),
+		},
+		Assert: assertion,
+	}
+}
+
+func getResponse(name, value, version string) mock.Response {
+	return versionedResponse(200, version, &mock.RequestAssertion{
+		Header: api.DefaultReadMockHeaders,
+		Host:   api.DefaultMockHost,
+		Method: "GET",
+		Path:   storePath + "/" + name,
+	}, newOption(name, value))
+}
+
+func createResponse(name, value string) mock.Response {
+	return versionedResponse(201, "1", &mock.RequestAssertion{
+		Header: api.DefaultWriteMockHeaders,
+		Host:   api.DefaultMockHost,
+		Method: "POST",
+		Path:   storePath + "/" + name,
+		Body:   mock.NewStructBody(models.ConfigStoreOptionData{Value: ec.String(value)}),
+	}, newOption(name, value))
+}
+
+func updateResponse(name, value, version, newVersion string) mock.Response {
+	var query map[string][]string
+	if version != "" {
+		query = map[string][]string{"version": {version}}
+	}
+	return versionedResponse(200, newVersion, &mock.RequestAssertion{
+		Header: api.DefaultWriteMockHeaders,
+		Host:   api.DefaultMockHost,
+		Method: "PUT",
+		Path:   storePath + "/" + name,
+		Query:  query,
+		Body:   mock.NewStructBody(models.ConfigStoreOptionData{Value: ec.String(value)}),
+	}, newOption(name, value))
+}
+
+func conflictResponse() mock.Response {
+	return mock.NewErrorResponse(409, mock.APIError{
+		Code: "config_store.version_conflict", Message: "version conflict",
+	})
+}
@@ -0,0 +1,114 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// GetString returns the value of the config store option matching the name.
+func GetString(params GetParams) (string, error) {
+	option, _, err := Get(params)
+	if err != nil {
+		return "", err
+	}
+
+	return optionValue(option), nil
+}
+
+// GetBool returns the value of the config store option matching the name,
+// parsed as a boolean.
+func GetBool(params GetParams) (bool, error) {
+	value, err := GetString(params)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("config store option %s: value %q is not a boolean", params.Name, value)
+	}
+
+	return b, nil
+}
+
+// GetInt64 returns the value of the config store option matching the name,
+// parsed as an integer.
+func GetInt64(params GetParams) (int64, error) {
+	value, err := GetString(params)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("config store option %s: value %q is not an integer", params.Name, value)
+	}
+
+	return i, nil
+}
+
+// GetJSON decodes the value of the config store option matching the name
+// into v.
+func GetJSON(params GetParams, v interface{}) error {
+	value, err := GetString(params)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return fmt.Errorf("config store option %s: value is not valid JSON: %w", params.Name, err)
+	}
+
+	return nil
+}
+
+// FormatValue formats a typed value as a config store option value. Strings
+// are returned as is, booleans and integers are formatted in their canonical
+// form and any other value is encoded as JSON.
+func FormatValue(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int:
+		return strconv.Itoa(value), nil
+	case int32:
+		return strconv.FormatInt(int64(value), 10), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
+func optionValue(option *models.ConfigStoreOption) string {
+	if option == nil || option.Value == nil {
+		return ""
+	}
+	return *option.Value
+}
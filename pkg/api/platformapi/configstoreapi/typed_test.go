@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package configstoreapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+)
+
+func newGetParams(value string) GetParams {
+	return GetParams{
+		API:    api.NewMock(getResponse("some-option", value, "1")),
+		Name:   "some-option",
+		Region: "us-east-1",
+	}
+}
+
+func TestGetBool(t *testing.T) {
+	got, err := GetBool(newGetParams("true"))
+	assert.NoError(t, err)
+	assert.True(t, got)
+
+	_, err = GetBool(newGetParams("yes please"))
+	assert.EqualError(t, err, `config store option some-option: value "yes please" is not a boolean`)
+
+	_, err = GetBool(GetParams{
+		API: api.NewMock(mock.SampleNotFoundError()), Name: "some-option", Region: "us-east-1",
+	})
+	assert.EqualError(t, err, mock.MultierrorNotFound.Error())
+}
+
+func TestGetInt64(t *testing.T) {
+	got, err := GetInt64(newGetParams("42"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), got)
+
+	_, err = GetInt64(newGetParams("4.2"))
+	assert.EqualError(t, err, `config store option some-option: value "4.2" is not an integer`)
+}
+
+func TestGetJSON(t *testing.T) {
+	var got map[string]int
+	assert.NoError(t, GetJSON(newGetParams(`{"a":1}`), &got))
+	assert.Equal(t, map[string]int{"a": 1}, got)
+
+	assert.EqualError(t, GetJSON(newGetParams(`{`), &got),
+		"config store option some-option: value is not valid JSON: unexpected end of JSON input",
+	)
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{value: "some", want: "some"},
+		{value: true, want: "true"},
+		{value: 42, want: "42"},
+		{value: int32(-1), want: "-1"},
+		{value: int64(1 << 40), want: "1099511627776"},
+		{value: map[string]int{"a": 1}, want: `{"a":1}`},
+	}
+	for _, tt := range tests {
+		got, err := FormatValue(tt.value)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := FormatValue(make(chan int))
+	assert.EqualError(t, err, "json: unsupported type: chan int")
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package coordinatorapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errIDCannotBeEmpty = errors.New("id not specified and is required for this operation")
+
+// ListParams is consumed by List and ListCandidates.
+type ListParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable.
+func (params ListParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid coordinator list params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// List returns the platform coordinators.
+func List(params ListParams) (*models.CoordinatorsSummary, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.GetCoordinators(
+		platform_infrastructure.NewGetCoordinatorsParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// ListCandidates returns the coordinator candidates.
+func ListCandidates(params ListParams) (*models.CoordinatorCandidatesSummary, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.GetCoordinatorCandidates(
+		platform_infrastructure.NewGetCoordinatorCandidatesParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// GetParams is consumed by Get and GetCandidate.
+type GetParams struct {
+	*api.API
+
+	// Required coordinator or coordinator candidate ID.
+	ID     string
+	Region string
+}
+
+// Validate ensures the parameters are usable.
+func (params GetParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid coordinator get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Get returns the coordinator matching the ID.
+func Get(params GetParams) (*models.CoordinatorSummary, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.GetCoordinator(
+		platform_infrastructure.NewGetCoordinatorParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithCoordinatorID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// GetCandidate returns the coordinator candidate matching the ID.
+func GetCandidate(params GetParams) (*models.CoordinatorCandidateInfo, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.GetCoordinatorCandidate(
+		platform_infrastructure.NewGetCoordinatorCandidateParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithCoordinatorCandidateID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// DeleteCandidateParams is consumed by DeleteCandidate.
+type DeleteCandidateParams struct {
+	*api.API
+
+	// Required coordinator candidate ID.
+	ID     string
+	Region string
+
+	// Optional version for optimistic concurrency control.
+	Version string
+}
+
+// Validate ensures the parameters are usable by DeleteCandidate.
+func (params DeleteCandidateParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid coordinator candidate delete params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// DeleteCandidate deletes the coordinator candidate matching the ID.
+func DeleteCandidate(params DeleteCandidateParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	var version *string
+	if params.Version != "" {
+		version = ec.String(params.Version)
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformInfrastructure.DeleteCoordinatorCandidate(
+			platform_infrastructure.NewDeleteCoordinatorCandidateParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithCoordinatorCandidateID(params.ID).
+				WithVersion(version),
+			params.AuthWriter,
+		),
+	)
+}
@@ -0,0 +1,313 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package coordinatorapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const (
+	coordinatorsPath = "/api/v1/regions/us-east-1/platform/infrastructure/coordinators"
+	candidatesPath   = coordinatorsPath + "/candidates"
+)
+
+func TestList(t *testing.T) {
+	var coordinators = &models.CoordinatorsSummary{
+		Coordinators: []*models.CoordinatorSummary{
+			{Name: ec.String("192.168.44.10"), PublicHostname: ec.String("host-1")},
+		},
+	}
+	tests := []struct {
+		name   string
+		params ListParams
+		want   *models.CoordinatorsSummary
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid coordinator list params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: ListParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: ListParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   coordinatorsPath,
+					},
+					mock.NewStructBody(coordinators),
+				)),
+				Region: "us-east-1",
+			},
+			want: coordinators,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := List(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestListCandidates(t *testing.T) {
+	var candidates = &models.CoordinatorCandidatesSummary{
+		Candidates: []*models.CoordinatorCandidateInfo{
+			{Name: ec.String("192.168.44.11"), State: ec.String("pending")},
+		},
+	}
+	tests := []struct {
+		name   string
+		params ListParams
+		want   *models.CoordinatorCandidatesSummary
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid coordinator list params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: ListParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: ListParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   candidatesPath,
+					},
+					mock.NewStructBody(candidates),
+				)),
+				Region: "us-east-1",
+			},
+			want: candidates,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListCandidates(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	var coordinator = &models.CoordinatorSummary{
+		Name: ec.String("192.168.44.10"), PublicHostname: ec.String("host-1"),
+	}
+	tests := []struct {
+		name   string
+		params GetParams
+		want   *models.CoordinatorSummary
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid coordinator get params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: GetParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     "192.168.44.10",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   coordinatorsPath + "/192.168.44.10",
+					},
+					mock.NewStructBody(coordinator),
+				)),
+				ID:     "192.168.44.10",
+				Region: "us-east-1",
+			},
+			want: coordinator,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Get(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetCandidate(t *testing.T) {
+	var candidate = &models.CoordinatorCandidateInfo{
+		Name: ec.String("192.168.44.11"), State: ec.String("accepted"),
+	}
+	tests := []struct {
+		name   string
+		params GetParams
+		want   *models.CoordinatorCandidateInfo
+		err    string
+	}{
+		{
+			name: "fails due to API error",
+			params: GetParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     "192.168.44.11",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   candidatesPath + "/192.168.44.11",
+					},
+					mock.NewStructBody(candidate),
+				)),
+				ID:     "192.168.44.11",
+				Region: "us-east-1",
+			},
+			want: candidate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetCandidate(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeleteCandidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DeleteCandidateParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid coordinator candidate delete params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: DeleteCandidateParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     "192.168.44.11",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds with version",
+			params: DeleteCandidateParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "DELETE",
+						Path:   candidatesPath + "/192.168.44.11",
+						Query:  map[string][]string{"version": {"3"}},
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				ID:      "192.168.44.11",
+				Region:  "us-east-1",
+				Version: "3",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DeleteCandidate(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
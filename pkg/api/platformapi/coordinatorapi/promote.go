@@ -0,0 +1,224 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package coordinatorapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/runnerapi"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// PromoteParams is consumed by Promote.
+type PromoteParams struct {
+	*api.API
+
+	// Required coordinator candidate ID, which matches its runner ID.
+	ID     string
+	Region string
+
+	// Skips verifying that the candidate's runner is healthy and connected.
+	SkipHealthCheck bool
+}
+
+// Validate ensures the parameters are usable by Promote.
+func (params PromoteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid coordinator promote params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Promote promotes the coordinator candidate to a coordinator. Unless
+// SkipHealthCheck is set, the candidate must exist and its runner must be
+// healthy and connected before it's promoted.
+func Promote(params PromoteParams) (*models.CoordinatorCandidateInfo, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !params.SkipHealthCheck {
+		if _, err := GetCandidate(GetParams{
+			API: params.API, ID: params.ID, Region: params.Region,
+		}); err != nil {
+			return nil, err
+		}
+
+		healthy, err := runnerHealthy(params.API, params.Region, params.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !healthy {
+			return nil, fmt.Errorf(
+				"coordinator promote: candidate %s runner is not healthy or not connected", params.ID,
+			)
+		}
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.PromoteCoordinatorCandidate(
+		platform_infrastructure.NewPromoteCoordinatorCandidateParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithCoordinatorCandidateID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// DemoteParams is consumed by Demote.
+type DemoteParams struct {
+	*api.API
+
+	// Required coordinator ID, which matches its runner ID.
+	ID     string
+	Region string
+
+	// Skips the quorum checks, demoting the coordinator even when it could
+	// result in a loss of quorum.
+	Force bool
+}
+
+// Validate ensures the parameters are usable by Demote.
+func (params DemoteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid coordinator demote params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Demote demotes the coordinator to a coordinator candidate. Unless Force is
+// set, the coordinator must be part of the current coordinators and a
+// majority of the remaining coordinators must be healthy, so the remaining
+// ensemble retains its quorum after the coordinator is demoted.
+func Demote(params DemoteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if !params.Force {
+		if err := checkQuorum(params); err != nil {
+			return err
+		}
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformInfrastructure.DemoteCoordinator(
+			platform_infrastructure.NewDemoteCoordinatorParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithCoordinatorID(params.ID),
+			params.AuthWriter,
+		),
+	)
+}
+
+// Quorum returns the number of members required for an ensemble of the
+// specified size to have a quorum.
+func Quorum(size int) int {
+	return size/2 + 1
+}
+
+// checkQuorum ensures that after demoting the coordinator, the majority of
+// the remaining coordinators are healthy.
+func checkQuorum(params DemoteParams) error {
+	coordinators, err := List(ListParams{API: params.API, Region: params.Region})
+	if err != nil {
+		return err
+	}
+
+	var found bool
+	var remaining []string
+	for _, c := range coordinators.Coordinators {
+		if c == nil || c.Name == nil {
+			continue
+		}
+		if *c.Name == params.ID {
+			found = true
+			continue
+		}
+		remaining = append(remaining, *c.Name)
+	}
+
+	var merr = multierror.NewPrefixed("coordinator demote")
+	if !found {
+		return merr.Append(fmt.Errorf("%s is not a coordinator", params.ID)).ErrorOrNil()
+	}
+
+	if len(remaining) == 0 {
+		return merr.Append(errors.New("cannot demote the last coordinator")).ErrorOrNil()
+	}
+
+	var healthy int
+	for _, id := range remaining {
+		ok, err := runnerHealthy(params.API, params.Region, id)
+		if err != nil {
+			merr = merr.Append(err)
+			continue
+		}
+		if ok {
+			healthy++
+		}
+	}
+
+	if required := Quorum(len(remaining)); healthy < required {
+		merr = merr.Append(fmt.Errorf(
+			"demoting %s would leave %d healthy coordinators out of %d, quorum requires %d",
+			params.ID, healthy, len(remaining), required,
+		))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func runnerHealthy(a *api.API, region, id string) (bool, error) {
+	runner, err := runnerapi.Show(runnerapi.ShowParams{
+		API: a, Region: region, ID: id,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return runner.Healthy != nil && *runner.Healthy &&
+		runner.Connected != nil && *runner.Connected, nil
+}
@@ -0,0 +1,292 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package coordinatorapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const runnersPath = "/api/v1/regions/us-east-1/platform/infrastructure/runners"
+
+func runnerResponse(id string, healthy, connected bool) mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   runnersPath + "/" + id,
+		},
+		mock.NewStructBody(models.RunnerInfo{
+			RunnerID:  ec.String(id),
+			Healthy:   ec.Bool(healthy),
+			Connected: ec.Bool(connected),
+		}),
+	)
+}
+
+func coordinatorsResponse(names ...string) mock.Response {
+	var summary models.CoordinatorsSummary
+	for _, name := range names {
+		summary.Coordinators = append(summary.Coordinators,
+			&models.CoordinatorSummary{Name: ec.String(name)},
+		)
+	}
+
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   coordinatorsPath,
+		},
+		mock.NewStructBody(summary),
+	)
+}
+
+func TestPromote(t *testing.T) {
+	const id = "192.168.44.11"
+	var candidate = &models.CoordinatorCandidateInfo{
+		Name: ec.String(id), State: ec.String("accepted"),
+	}
+	var candidateResponse = func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "GET",
+				Path:   candidatesPath + "/" + id,
+			},
+			mock.NewStructBody(candidate),
+		)
+	}
+	var promoteResponse = func() mock.Response {
+		return mock.New202ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "POST",
+				Path:   candidatesPath + "/" + id + "/_promote",
+			},
+			mock.NewStructBody(candidate),
+		)
+	}
+	tests := []struct {
+		name   string
+		params PromoteParams
+		want   *models.CoordinatorCandidateInfo
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid coordinator promote params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when the candidate doesn't exist",
+			params: PromoteParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				ID:     id,
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "fails when the candidate runner is not healthy",
+			params: PromoteParams{
+				API: api.NewMock(
+					candidateResponse(),
+					runnerResponse(id, false, true),
+				),
+				ID:     id,
+				Region: "us-east-1",
+			},
+			err: "coordinator promote: candidate 192.168.44.11 runner is not healthy or not connected",
+		},
+		{
+			name: "fails when the candidate runner is not connected",
+			params: PromoteParams{
+				API: api.NewMock(
+					candidateResponse(),
+					runnerResponse(id, true, false),
+				),
+				ID:     id,
+				Region: "us-east-1",
+			},
+			err: "coordinator promote: candidate 192.168.44.11 runner is not healthy or not connected",
+		},
+		{
+			name: "succeeds when the candidate is healthy",
+			params: PromoteParams{
+				API: api.NewMock(
+					candidateResponse(),
+					runnerResponse(id, true, true),
+					promoteResponse(),
+				),
+				ID:     id,
+				Region: "us-east-1",
+			},
+			want: candidate,
+		},
+		{
+			name: "succeeds skipping the health check",
+			params: PromoteParams{
+				API:             api.NewMock(promoteResponse()),
+				ID:              id,
+				Region:          "us-east-1",
+				SkipHealthCheck: true,
+			},
+			want: candidate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Promote(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDemote(t *testing.T) {
+	var demoteResponse = func(id string) mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "POST",
+				Path:   coordinatorsPath + "/" + id + "/_demote",
+			},
+			mock.NewStringBody(`{}`),
+		)
+	}
+	tests := []struct {
+		name   string
+		params DemoteParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid coordinator demote params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when the coordinators cannot be listed",
+			params: DemoteParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				ID:     "192.168.44.10",
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "fails when the ID is not a coordinator",
+			params: DemoteParams{
+				API:    api.NewMock(coordinatorsResponse("192.168.44.10", "192.168.44.11")),
+				ID:     "192.168.44.12",
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed("coordinator demote",
+				errors.New("192.168.44.12 is not a coordinator"),
+			).Error(),
+		},
+		{
+			name: "fails when demoting the last coordinator",
+			params: DemoteParams{
+				API:    api.NewMock(coordinatorsResponse("192.168.44.10")),
+				ID:     "192.168.44.10",
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed("coordinator demote",
+				errors.New("cannot demote the last coordinator"),
+			).Error(),
+		},
+		{
+			name: "fails when the remaining coordinators would lose quorum",
+			params: DemoteParams{
+				API: api.NewMock(
+					coordinatorsResponse("192.168.44.10", "192.168.44.11", "192.168.44.12"),
+					runnerResponse("192.168.44.11", true, true),
+					runnerResponse("192.168.44.12", false, true),
+				),
+				ID:     "192.168.44.10",
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed("coordinator demote",
+				errors.New("demoting 192.168.44.10 would leave 1 healthy coordinators out of 2, quorum requires 2"),
+			).Error(),
+		},
+		{
+			name: "succeeds when the remaining coordinators retain quorum",
+			params: DemoteParams{
+				API: api.NewMock(
+					coordinatorsResponse("192.168.44.10", "192.168.44.11", "192.168.44.12"),
+					runnerResponse("192.168.44.11", true, true),
+					runnerResponse("192.168.44.12", true, true),
+					demoteResponse("192.168.44.10"),
+				),
+				ID:     "192.168.44.10",
+				Region: "us-east-1",
+			},
+		},
+		{
+			name: "succeeds forcing the demotion",
+			params: DemoteParams{
+				API:    api.NewMock(demoteResponse("192.168.44.10")),
+				ID:     "192.168.44.10",
+				Region: "us-east-1",
+				Force:  true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Demote(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQuorum(t *testing.T) {
+	for size, want := range map[int]int{1: 1, 2: 2, 3: 2, 4: 3, 5: 3} {
+		assert.Equal(t, want, Quorum(size), "size %d", size)
+	}
+}
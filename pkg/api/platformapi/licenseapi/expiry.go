@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package licenseapi
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+// DefaultExpiryWarningWindow is the default window before the license expiry
+// date in which CheckExpiry warns about the upcoming expiry.
+const DefaultExpiryWarningWindow = 30 * 24 * time.Hour
+
+var errExpiryNotSet = errors.New("license expiry date is not set")
+
+// ExpiryStatus describes the platform license expiry.
+type ExpiryStatus struct {
+	// UID of the license.
+	UID string `json:"uid"`
+
+	// ExpiresAt is the license expiry date.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Remaining is the time left until the license expires, negative when
+	// it has already expired.
+	Remaining time.Duration `json:"remaining"`
+
+	// Expired is true when the license has expired.
+	Expired bool `json:"expired"`
+
+	// Warning is true when the license expires within the warning window or
+	// has already expired.
+	Warning bool `json:"warning"`
+}
+
+// CheckExpiry returns the expiry status of the license at the specified time,
+// which defaults to the current time when zero. A window of 0 defaults to
+// DefaultExpiryWarningWindow.
+func CheckExpiry(license *models.LicenseInfo, window time.Duration, now time.Time) (*ExpiryStatus, error) {
+	if license == nil {
+		return nil, errLicenseCannotBeNil
+	}
+
+	if license.ExpiryDateInMillis == nil {
+		return nil, errExpiryNotSet
+	}
+
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if window == 0 {
+		window = DefaultExpiryWarningWindow
+	}
+
+	var status = ExpiryStatus{
+		ExpiresAt: millisToTime(*license.ExpiryDateInMillis),
+	}
+	if license.UID != nil {
+		status.UID = *license.UID
+	}
+
+	status.Remaining = status.ExpiresAt.Sub(now)
+	status.Expired = status.Remaining <= 0
+	status.Warning = status.Remaining <= window
+
+	return &status, nil
+}
+
+// GetExpiryParams is consumed by GetExpiry.
+type GetExpiryParams struct {
+	*api.API
+
+	Region string
+
+	// Optional warning window, defaults to DefaultExpiryWarningWindow.
+	Window time.Duration
+
+	// Optional time to check the expiry against, defaults to the current
+	// time.
+	Now time.Time
+}
+
+// GetExpiry obtains the platform license and returns its expiry status.
+func GetExpiry(params GetExpiryParams) (*ExpiryStatus, error) {
+	license, err := Get(GetParams{API: params.API, Region: params.Region})
+	if err != nil {
+		return nil, err
+	}
+
+	return CheckExpiry(license.License, params.Window, params.Now)
+}
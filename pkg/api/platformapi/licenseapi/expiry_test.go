@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package licenseapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+func TestCheckExpiry(t *testing.T) {
+	var expiresAt = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		license *models.LicenseInfo
+		window  time.Duration
+		now     time.Time
+		want    *ExpiryStatus
+		err     error
+	}{
+		{
+			name: "fails on nil license",
+			err:  errLicenseCannotBeNil,
+		},
+		{
+			name:    "fails on missing expiry",
+			license: &models.LicenseInfo{},
+			err:     errExpiryNotSet,
+		},
+		{
+			name:    "not within the default window",
+			license: newLicenseInfo(),
+			now:     testNow,
+			want: &ExpiryStatus{
+				UID:       "some-uid",
+				ExpiresAt: expiresAt,
+				Remaining: 365 * 24 * time.Hour,
+			},
+		},
+		{
+			name:    "within the default window",
+			license: newLicenseInfo(),
+			now:     expiresAt.Add(-10 * 24 * time.Hour),
+			want: &ExpiryStatus{
+				UID:       "some-uid",
+				ExpiresAt: expiresAt,
+				Remaining: 10 * 24 * time.Hour,
+				Warning:   true,
+			},
+		},
+		{
+			name:    "not within a custom window",
+			license: newLicenseInfo(),
+			window:  24 * time.Hour,
+			now:     expiresAt.Add(-10 * 24 * time.Hour),
+			want: &ExpiryStatus{
+				UID:       "some-uid",
+				ExpiresAt: expiresAt,
+				Remaining: 10 * 24 * time.Hour,
+			},
+		},
+		{
+			name:    "expired",
+			license: newLicenseInfo(),
+			now:     expiresAt.Add(time.Hour),
+			want: &ExpiryStatus{
+				UID:       "some-uid",
+				ExpiresAt: expiresAt,
+				Remaining: -time.Hour,
+				Expired:   true,
+				Warning:   true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CheckExpiry(tt.license, tt.window, tt.now)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetExpiry(t *testing.T) {
+	got, err := GetExpiry(GetExpiryParams{
+		API:    api.NewMock(mock.New200StructResponse(models.LicenseObject{License: newLicenseInfo()})),
+		Region: "us-east-1",
+		Window: 400 * 24 * time.Hour,
+		Now:    testNow,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &ExpiryStatus{
+		UID:       "some-uid",
+		ExpiresAt: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		Remaining: 365 * 24 * time.Hour,
+		Warning:   true,
+	}, got)
+
+	got, err = GetExpiry(GetExpiryParams{
+		API:    api.NewMock(mock.SampleInternalError()),
+		Region: "us-east-1",
+	})
+	assert.EqualError(t, err, mock.MultierrorInternalError.Error())
+	assert.Nil(t, got)
+}
@@ -0,0 +1,171 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package licenseapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/input"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errSourceCannotBeEmpty = errors.New("either a file name or a reader must be specified")
+
+// GetParams is consumed by Get.
+type GetParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by Get.
+func (params GetParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid license get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Get returns the platform license.
+func Get(params GetParams) (*models.LicenseObject, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformInfrastructure.GetLicense(
+		platform_infrastructure.NewGetLicenseParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// SetParams is consumed by Set.
+type SetParams struct {
+	*api.API
+
+	Region string
+
+	// Path to the license JSON file. Takes precedence over Reader.
+	Filename string
+
+	// Reader from which the license JSON is read when Filename is empty.
+	Reader io.Reader
+
+	// Optional time used to validate the license dates. Defaults to the
+	// current time.
+	Now time.Time
+}
+
+// Validate ensures the parameters are usable by Set.
+func (params SetParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid license set params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Filename == "" && params.Reader == nil {
+		merr = merr.Append(errSourceCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Set reads the license from the file or reader, validates it locally and
+// uploads it as the platform license.
+func Set(params SetParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	f, err := input.NewFileOrReader(params.Reader, params.Filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	license, err := ParseLicense(f)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateLicense(license.License, params.Now); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformInfrastructure.SetLicense(
+			platform_infrastructure.NewSetLicenseParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithBody(license),
+			params.AuthWriter,
+		),
+	)
+}
+
+// DeleteParams is consumed by Delete.
+type DeleteParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by Delete.
+func (params DeleteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid license delete params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Delete deletes the platform license.
+func Delete(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformInfrastructure.DeleteLicense(
+			platform_infrastructure.NewDeleteLicenseParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)),
+			params.AuthWriter,
+		),
+	)
+}
@@ -0,0 +1,239 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package licenseapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const licensePath = "/api/v1/regions/us-east-1/platform/license"
+
+var (
+	// 2026-01-01T00:00:00Z
+	testNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	licenseJSON = `{"license":{"uid":"some-uid","type":"enterprise","issue_date_in_millis":1735689600000,"start_date_in_millis":1735689600000,"expiry_date_in_millis":1798761600000,"issued_to":"Some Company","issuer":"API","signature":"AAAA","max_instances":10,"cluster_licenses":[]}}`
+)
+
+func newLicenseInfo() *models.LicenseInfo {
+	return &models.LicenseInfo{
+		UID:                ec.String("some-uid"),
+		Type:               ec.String("enterprise"),
+		IssueDateInMillis:  ec.Int64(1735689600000),
+		StartDateInMillis:  ec.Int64(1735689600000),
+		ExpiryDateInMillis: ec.Int64(1798761600000),
+		IssuedTo:           ec.String("Some Company"),
+		Issuer:             ec.String("API"),
+		Signature:          ec.String("AAAA"),
+		MaxInstances:       10,
+		ClusterLicenses:    []*models.ClusterLicenseInfo{},
+	}
+}
+
+func TestGet(t *testing.T) {
+	var license = &models.LicenseObject{License: newLicenseInfo()}
+	tests := []struct {
+		name   string
+		params GetParams
+		want   *models.LicenseObject
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid license get params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: GetParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   licensePath,
+					},
+					mock.NewStructBody(license),
+				)),
+				Region: "us-east-1",
+			},
+			want: license,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Get(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	var licenseFile = filepath.Join(t.TempDir(), "license.json")
+	if err := os.WriteFile(licenseFile, []byte(licenseJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var setAssertion = func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "PUT",
+				Path:   licensePath,
+				Body:   mock.NewStructBody(models.LicenseObject{License: newLicenseInfo()}),
+			},
+			mock.NewStringBody(`{}`),
+		)
+	}
+
+	tests := []struct {
+		name   string
+		params SetParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid license set params",
+				apierror.ErrMissingAPI,
+				errSourceCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to an expired license",
+			params: SetParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+				Reader: strings.NewReader(licenseJSON),
+				Now:    testNow.Add(365 * 24 * time.Hour),
+			},
+			err: multierror.NewPrefixed("invalid license",
+				errors.New("license expired on 2027-01-01T00:00:00Z"),
+			).Error(),
+		},
+		{
+			name: "fails due to a missing file",
+			params: SetParams{
+				API:      api.NewMock(),
+				Region:   "us-east-1",
+				Filename: filepath.Join(t.TempDir(), "missing.json"),
+			},
+			err: "no such file or directory",
+		},
+		{
+			name: "succeeds from a reader",
+			params: SetParams{
+				API:    api.NewMock(setAssertion()),
+				Region: "us-east-1",
+				Reader: strings.NewReader(licenseJSON),
+				Now:    testNow,
+			},
+		},
+		{
+			name: "succeeds from a file",
+			params: SetParams{
+				API:      api.NewMock(setAssertion()),
+				Region:   "us-east-1",
+				Filename: licenseFile,
+				Now:      testNow,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Set(tt.params)
+			if tt.err != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DeleteParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid license delete params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: DeleteParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "DELETE",
+						Path:   licensePath,
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				Region: "us-east-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Delete(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
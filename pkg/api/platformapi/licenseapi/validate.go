@@ -0,0 +1,138 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package licenseapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+var (
+	errLicenseCannotBeNil = errors.New("license cannot be empty")
+	errReaderCannotBeNil  = errors.New("reader cannot be nil")
+)
+
+// ParseLicense decodes a license JSON document. Both the license file format,
+// where the license is nested under a "license" key, and the bare license
+// object are supported.
+func ParseLicense(r io.Reader) (*models.LicenseObject, error) {
+	if r == nil {
+		return nil, errReaderCannotBeNil
+	}
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var license models.LicenseObject
+	if err := json.Unmarshal(b, &license); err != nil {
+		return nil, fmt.Errorf("failed to parse license: %w", err)
+	}
+
+	if license.License != nil {
+		return &license, nil
+	}
+
+	var info models.LicenseInfo
+	if err := json.Unmarshal(b, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse license: %w", err)
+	}
+
+	if info.UID == nil && info.Signature == nil {
+		return nil, errLicenseCannotBeNil
+	}
+
+	return &models.LicenseObject{License: &info}, nil
+}
+
+// ValidateLicense ensures that all the license signature fields are present
+// and that the license is valid at the specified time, which defaults to the
+// current time when zero. It doesn't verify the license signature, which is
+// done by the API.
+func ValidateLicense(license *models.LicenseInfo, now time.Time) error {
+	var merr = multierror.NewPrefixed("invalid license")
+	if license == nil {
+		return merr.Append(errLicenseCannotBeNil).ErrorOrNil()
+	}
+
+	var required = []struct {
+		field string
+		value *string
+	}{
+		{"uid", license.UID},
+		{"type", license.Type},
+		{"issuer", license.Issuer},
+		{"issued_to", license.IssuedTo},
+		{"signature", license.Signature},
+	}
+	for _, r := range required {
+		if r.value == nil || *r.value == "" {
+			merr = merr.Append(fmt.Errorf("%s cannot be empty", r.field))
+		}
+	}
+
+	var dates = []struct {
+		field string
+		value *int64
+	}{
+		{"issue_date_in_millis", license.IssueDateInMillis},
+		{"start_date_in_millis", license.StartDateInMillis},
+		{"expiry_date_in_millis", license.ExpiryDateInMillis},
+	}
+	for _, d := range dates {
+		if d.value == nil || *d.value <= 0 {
+			merr = merr.Append(fmt.Errorf("%s must be set", d.field))
+		}
+	}
+
+	if err := merr.ErrorOrNil(); err != nil {
+		return err
+	}
+
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	var expiry = millisToTime(*license.ExpiryDateInMillis)
+	if *license.StartDateInMillis > *license.ExpiryDateInMillis {
+		merr = merr.Append(errors.New("start date is after the expiry date"))
+	}
+
+	if *license.IssueDateInMillis > *license.ExpiryDateInMillis {
+		merr = merr.Append(errors.New("issue date is after the expiry date"))
+	}
+
+	if !now.Before(expiry) {
+		merr = merr.Append(fmt.Errorf("license expired on %s",
+			expiry.Format(time.RFC3339),
+		))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func millisToTime(ms int64) time.Time {
+	return time.UnixMilli(ms).UTC()
+}
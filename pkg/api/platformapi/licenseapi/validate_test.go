@@ -0,0 +1,129 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package licenseapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestParseLicense(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *models.LicenseObject
+		err   string
+	}{
+		{
+			name:  "parses the license file format",
+			input: licenseJSON,
+			want:  &models.LicenseObject{License: newLicenseInfo()},
+		},
+		{
+			name:  "parses a bare license",
+			input: `{"uid":"some-uid","signature":"AAAA"}`,
+			want: &models.LicenseObject{License: &models.LicenseInfo{
+				UID: ec.String("some-uid"), Signature: ec.String("AAAA"),
+			}},
+		},
+		{
+			name:  "fails on an empty license",
+			input: `{}`,
+			err:   errLicenseCannotBeNil.Error(),
+		},
+		{
+			name:  "fails on invalid JSON",
+			input: `{"license":`,
+			err:   "failed to parse license: unexpected end of JSON input",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLicense(strings.NewReader(tt.input))
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateLicense(t *testing.T) {
+	var invalidDates = newLicenseInfo()
+	invalidDates.StartDateInMillis = ec.Int64(1830297600000)
+	invalidDates.IssueDateInMillis = ec.Int64(1830297600000)
+
+	tests := []struct {
+		name    string
+		license *models.LicenseInfo
+		now     time.Time
+		err     error
+	}{
+		{
+			name: "fails on nil license",
+			err:  multierror.NewPrefixed("invalid license", errLicenseCannotBeNil),
+		},
+		{
+			name:    "fails on missing fields",
+			license: &models.LicenseInfo{UID: ec.String("some-uid"), Signature: ec.String("")},
+			err: multierror.NewPrefixed("invalid license",
+				errors.New("type cannot be empty"),
+				errors.New("issuer cannot be empty"),
+				errors.New("issued_to cannot be empty"),
+				errors.New("signature cannot be empty"),
+				errors.New("issue_date_in_millis must be set"),
+				errors.New("start_date_in_millis must be set"),
+				errors.New("expiry_date_in_millis must be set"),
+			),
+		},
+		{
+			name:    "fails on inconsistent dates",
+			license: invalidDates,
+			now:     testNow,
+			err: multierror.NewPrefixed("invalid license",
+				errors.New("start date is after the expiry date"),
+				errors.New("issue date is after the expiry date"),
+			),
+		},
+		{
+			name:    "succeeds",
+			license: newLicenseInfo(),
+			now:     testNow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLicense(tt.license, tt.now)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package loggingapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+const (
+	// Allocator is the allocator component type.
+	Allocator = "allocator"
+	// Constructor is the constructor component type.
+	Constructor = "constructor"
+	// Runner is the runner component type.
+	Runner = "runner"
+	// Adminconsole is the adminconsole component type.
+	Adminconsole = "adminconsole"
+)
+
+// Components contains all of the component types which have logging settings.
+var Components = []string{Allocator, Constructor, Runner, Adminconsole}
+
+// Levels contains all of the valid logging levels.
+var Levels = []string{"OFF", "ERROR", "WARN", "INFO", "DEBUG", "TRACE", "ALL"}
+
+var (
+	errIDCannotBeEmpty     = errors.New("id not specified and is required for this operation")
+	errLevelsCannotBeEmpty = errors.New("logging levels not specified and are required for this operation")
+)
+
+// Params identifies the platform component whose logging settings are
+// managed. It's embedded in all of the package's parameter structures.
+type Params struct {
+	*api.API
+
+	// Required component type, one of Components.
+	Component string
+	// Required component ID.
+	ID     string
+	Region string
+}
+
+// Validate ensures the parameters are usable.
+func (params Params) Validate() error {
+	var merr = multierror.NewPrefixed("invalid logging settings params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if !slice.HasString(Components, params.Component) {
+		merr = merr.Append(fmt.Errorf(
+			"component %q is invalid, must be one of %v", params.Component, Components,
+		))
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+func validateLevels(levels map[string]string) error {
+	if len(levels) == 0 {
+		return errLevelsCannotBeEmpty
+	}
+
+	var loggers = make([]string, 0, len(levels))
+	for logger := range levels {
+		loggers = append(loggers, logger)
+	}
+	sort.Strings(loggers)
+
+	var merr = multierror.NewPrefixed("invalid logging levels")
+	for _, logger := range loggers {
+		if !slice.HasString(Levels, strings.ToUpper(levels[logger])) {
+			merr = merr.Append(fmt.Errorf(
+				"logger %q level %q is invalid, must be one of %v",
+				logger, levels[logger], Levels,
+			))
+		}
+	}
+
+	return merr.ErrorOrNil()
+}
@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package loggingapi
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+var errDurationMustBePositive = errors.New("duration must be greater than 0")
+
+// RaiseParams is consumed by Raise.
+type RaiseParams struct {
+	Params
+
+	// Required logger to logging level mapping which is temporarily patched
+	// onto the current logging settings, one of Levels.
+	Levels map[string]string
+
+	// Required duration after which the previous logging settings are
+	// automatically restored.
+	Duration time.Duration
+}
+
+// Validate ensures the parameters are usable by Raise.
+func (params RaiseParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid logging settings raise params")
+	merr = merr.Append(params.Params.Validate())
+	merr = merr.Append(validateLevels(params.Levels))
+
+	if params.Duration <= 0 {
+		merr = merr.Append(errDurationMustBePositive)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Raised is returned by Raise and tracks the temporarily raised logging
+// levels, which are reverted once the duration elapses or Revert is called,
+// whichever happens first.
+type Raised struct {
+	params   Params
+	previous *models.LoggingSettings
+
+	timer *time.Timer
+	once  sync.Once
+	done  chan struct{}
+	err   error
+}
+
+// Raise temporarily patches the specified logging levels onto the component's
+// logging settings, restoring the previous settings after the duration. Since
+// the revert runs in the background, callers which exit before the duration
+// elapses must call Revert themselves, or the levels remain raised.
+func Raise(params RaiseParams) (*Raised, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	previous, err := Get(params.Params)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil || previous.LoggingLevels == nil {
+		previous = &models.LoggingSettings{LoggingLevels: map[string]string{}}
+	}
+
+	if _, err := Update(UpdateParams{
+		Params: params.Params,
+		Levels: params.Levels,
+	}); err != nil {
+		return nil, err
+	}
+
+	var raised = Raised{
+		params:   params.Params,
+		previous: previous,
+		done:     make(chan struct{}),
+	}
+	raised.timer = time.AfterFunc(params.Duration, func() { raised.revert() })
+
+	return &raised, nil
+}
+
+// Previous returns the logging settings which are restored on revert.
+func (r *Raised) Previous() *models.LoggingSettings { return r.previous }
+
+// Done returns a channel which is closed once the logging settings have been
+// reverted.
+func (r *Raised) Done() <-chan struct{} { return r.done }
+
+// Err returns the error encountered when reverting the logging settings. It
+// should only be called after Done has been closed.
+func (r *Raised) Err() error { return r.err }
+
+// Wait blocks until the logging settings have been reverted, returning the
+// error encountered while reverting them, if any.
+func (r *Raised) Wait() error {
+	<-r.done
+	return r.err
+}
+
+// Revert restores the previous logging settings without waiting for the
+// duration to elapse. It's safe to call multiple times and concurrently with
+// the automatic revert, the settings are only restored once.
+func (r *Raised) Revert() error {
+	r.timer.Stop()
+	r.revert()
+	return r.Wait()
+}
+
+func (r *Raised) revert() {
+	r.once.Do(func() {
+		defer close(r.done)
+		_, r.err = Set(SetParams{
+			Params:   r.params,
+			Settings: r.previous,
+		})
+	})
+}
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package loggingapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func TestRaise(t *testing.T) {
+	var previous = &models.LoggingSettings{
+		LoggingLevels: map[string]string{"root": "INFO"},
+	}
+	var raised = &models.LoggingSettings{
+		LoggingLevels: map[string]string{"root": "DEBUG"},
+	}
+	var params = func(a *api.API, d time.Duration) RaiseParams {
+		return RaiseParams{
+			Params: Params{
+				API:       a,
+				Component: Allocator,
+				ID:        "some-id",
+				Region:    "us-east-1",
+			},
+			Levels:   map[string]string{"root": "DEBUG"},
+			Duration: d,
+		}
+	}
+	var raiseResponses = func(extra ...mock.Response) *api.API {
+		return api.NewMock(append([]mock.Response{
+			settingsResponse("GET", Allocator, nil, previous),
+			settingsResponse("PATCH", Allocator, `{"logging_levels":{"root":"DEBUG"}}`, raised),
+		}, extra...)...)
+	}
+
+	t.Run("fails due to parameter validation", func(t *testing.T) {
+		got, err := Raise(params(api.NewMock(), 0))
+		assert.EqualError(t, err, multierror.NewPrefixed("invalid logging settings raise params",
+			errDurationMustBePositive,
+		).Error())
+		assert.Nil(t, got)
+	})
+
+	t.Run("fails when the current settings cannot be obtained", func(t *testing.T) {
+		got, err := Raise(params(api.NewMock(mock.SampleNotFoundError()), time.Minute))
+		assert.EqualError(t, err, mock.MultierrorNotFound.Error())
+		assert.Nil(t, got)
+	})
+
+	t.Run("fails when the settings cannot be updated", func(t *testing.T) {
+		got, err := Raise(params(api.NewMock(
+			settingsResponse("GET", Allocator, nil, previous),
+			mock.SampleInternalError(),
+		), time.Minute))
+		assert.EqualError(t, err, mock.MultierrorInternalError.Error())
+		assert.Nil(t, got)
+	})
+
+	t.Run("reverts automatically after the duration", func(t *testing.T) {
+		got, err := Raise(params(raiseResponses(
+			settingsResponse("PUT", Allocator, previous, previous),
+		), time.Millisecond))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, previous, got.Previous())
+		select {
+		case <-got.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("logging settings were not reverted")
+		}
+		assert.NoError(t, got.Err())
+		assert.NoError(t, got.Revert())
+	})
+
+	t.Run("reverts when Revert is called before the duration", func(t *testing.T) {
+		got, err := Raise(params(raiseResponses(
+			settingsResponse("PUT", Allocator, previous, previous),
+		), time.Hour))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.NoError(t, got.Revert())
+		assert.NoError(t, got.Wait())
+	})
+
+	t.Run("returns the revert error", func(t *testing.T) {
+		got, err := Raise(params(raiseResponses(
+			mock.SampleInternalError(),
+		), time.Hour))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.EqualError(t, got.Revert(), mock.MultierrorInternalError.Error())
+		assert.EqualError(t, got.Err(), mock.MultierrorInternalError.Error())
+	})
+}
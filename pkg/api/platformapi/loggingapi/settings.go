@@ -0,0 +1,282 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package loggingapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+var errSettingsCannotBeNil = errors.New("logging settings not specified and are required for this operation")
+
+// Get obtains the logging settings of the specified component.
+func Get(params Params) (*models.LoggingSettings, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var ctx = api.WithRegion(context.Background(), params.Region)
+	var infra = params.V1API.PlatformInfrastructure
+	switch params.Component {
+	case Allocator:
+		res, err := infra.GetAllocatorLoggingSettings(
+			platform_infrastructure.NewGetAllocatorLoggingSettingsParams().
+				WithContext(ctx).WithAllocatorID(params.ID),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	case Constructor:
+		res, err := infra.GetConstructorLoggingSettings(
+			platform_infrastructure.NewGetConstructorLoggingSettingsParams().
+				WithContext(ctx).WithConstructorID(params.ID),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	case Runner:
+		res, err := infra.GetRunnerLoggingSettings(
+			platform_infrastructure.NewGetRunnerLoggingSettingsParams().
+				WithContext(ctx).WithRunnerID(params.ID),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	default:
+		res, err := infra.GetAdminconsoleLoggingSettings(
+			platform_infrastructure.NewGetAdminconsoleLoggingSettingsParams().
+				WithContext(ctx).WithAdminconsoleID(params.ID),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	}
+}
+
+// SetParams is consumed by Set.
+type SetParams struct {
+	Params
+
+	// Required logging settings which replace the current ones.
+	Settings *models.LoggingSettings
+}
+
+// Validate ensures the parameters are usable by Set.
+func (params SetParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid logging settings set params")
+	merr = merr.Append(params.Params.Validate())
+
+	if params.Settings == nil {
+		merr = merr.Append(errSettingsCannotBeNil)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Set replaces the logging settings of the specified component.
+func Set(params SetParams) (*models.LoggingSettings, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var settings = params.Settings
+	if settings.LoggingLevels == nil {
+		settings = &models.LoggingSettings{LoggingLevels: map[string]string{}}
+	}
+
+	var ctx = api.WithRegion(context.Background(), params.Region)
+	var infra = params.V1API.PlatformInfrastructure
+	switch params.Component {
+	case Allocator:
+		res, err := infra.SetAllocatorLoggingSettings(
+			platform_infrastructure.NewSetAllocatorLoggingSettingsParams().
+				WithContext(ctx).WithAllocatorID(params.ID).WithBody(settings),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	case Constructor:
+		res, err := infra.SetConstructorLoggingSettings(
+			platform_infrastructure.NewSetConstructorLoggingSettingsParams().
+				WithContext(ctx).WithConstructorID(params.ID).WithBody(settings),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	case Runner:
+		res, err := infra.SetRunnerLoggingSettings(
+			platform_infrastructure.NewSetRunnerLoggingSettingsParams().
+				WithContext(ctx).WithRunnerID(params.ID).WithBody(settings),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	default:
+		res, err := infra.SetAdminconsoleLoggingSettings(
+			platform_infrastructure.NewSetAdminconsoleLoggingSettingsParams().
+				WithContext(ctx).WithAdminconsoleID(params.ID).WithBody(settings),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	}
+}
+
+// UpdateParams is consumed by Update.
+type UpdateParams struct {
+	Params
+
+	// Required logger to logging level mapping which is patched onto the
+	// current logging settings, one of Levels.
+	Levels map[string]string
+}
+
+// Validate ensures the parameters are usable by Update.
+func (params UpdateParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid logging settings update params")
+	merr = merr.Append(params.Params.Validate())
+	merr = merr.Append(validateLevels(params.Levels))
+
+	return merr.ErrorOrNil()
+}
+
+// Update patches the logging settings of the specified component, leaving
+// the loggers which are not part of the specified levels untouched. Levels
+// are sent upper-cased, so they can be specified in any case.
+func Update(params UpdateParams) (*models.LoggingSettings, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var levels = make(map[string]string, len(params.Levels))
+	for logger, level := range params.Levels {
+		levels[logger] = strings.ToUpper(level)
+	}
+
+	b, err := json.Marshal(models.LoggingSettings{LoggingLevels: levels})
+	if err != nil {
+		return nil, err
+	}
+	var body = string(b)
+
+	var ctx = api.WithRegion(context.Background(), params.Region)
+	var infra = params.V1API.PlatformInfrastructure
+	switch params.Component {
+	case Allocator:
+		res, err := infra.UpdateAllocatorLoggingSettings(
+			platform_infrastructure.NewUpdateAllocatorLoggingSettingsParams().
+				WithContext(ctx).WithAllocatorID(params.ID).WithBody(body),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	case Constructor:
+		res, err := infra.UpdateConstructorLoggingSettings(
+			platform_infrastructure.NewUpdateConstructorLoggingSettingsParams().
+				WithContext(ctx).WithConstructorID(params.ID).WithBody(body),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	case Runner:
+		res, err := infra.UpdateRunnerLoggingSettings(
+			platform_infrastructure.NewUpdateRunnerLoggingSettingsParams().
+				WithContext(ctx).WithRunnerID(params.ID).WithBody(body),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	default:
+		res, err := infra.UpdateAdminconsoleLoggingSettings(
+			platform_infrastructure.NewUpdateAdminconsoleLoggingSettingsParams().
+				WithContext(ctx).WithAdminconsoleID(params.ID).WithBody(body),
+			params.AuthWriter,
+		)
+		if err != nil {
+			return nil, apierror.Wrap(err)
+		}
+		return res.Payload, nil
+	}
+}
+
+// Delete resets the logging settings of the specified component to their
+// defaults.
+func Delete(params Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	var ctx = api.WithRegion(context.Background(), params.Region)
+	var infra = params.V1API.PlatformInfrastructure
+	switch params.Component {
+	case Allocator:
+		return api.ReturnErrOnly(infra.DeleteAllocatorLoggingSettings(
+			platform_infrastructure.NewDeleteAllocatorLoggingSettingsParams().
+				WithContext(ctx).WithAllocatorID(params.ID),
+			params.AuthWriter,
+		))
+	case Constructor:
+		return api.ReturnErrOnly(infra.DeleteConstructorLoggingSettings(
+			platform_infrastructure.NewDeleteConstructorLoggingSettingsParams().
+				WithContext(ctx).WithConstructorID(params.ID),
+			params.AuthWriter,
+		))
+	case Runner:
+		return api.ReturnErrOnly(infra.DeleteRunnerLoggingSettings(
+			platform_infrastructure.NewDeleteRunnerLoggingSettingsParams().
+				WithContext(ctx).WithRunnerID(params.ID),
+			params.AuthWriter,
+		))
+	default:
+		return api.ReturnErrOnly(infra.DeleteAdminconsoleLoggingSettings(
+			platform_infrastructure.NewDeleteAdminconsoleLoggingSettingsParams().
+				WithContext(ctx).WithAdminconsoleID(params.ID),
+			params.AuthWriter,
+		))
+	}
+}
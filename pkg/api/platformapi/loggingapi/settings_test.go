@@ -0,0 +1,253 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package loggingapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+const infraPath = "/api/v1/regions/us-east-1/platform/infrastructure"
+
+var componentPaths = map[string]string{
+	Allocator:    infraPath + "/allocators/some-id/logging_settings",
+	Constructor:  infraPath + "/constructors/some-id/logging_settings",
+	Runner:       infraPath + "/runners/some-id/logging_settings",
+	Adminconsole: infraPath + "/adminconsoles/some-id/logging_settings",
+}
+
+func settingsResponse(method, component string, body, response interface{}) mock.Response {
+	var header = api.DefaultWriteMockHeaders
+	if method == "GET" {
+		header = api.DefaultReadMockHeaders
+	}
+
+	var assertion = &mock.RequestAssertion{
+		Header: header,
+		Host:   api.DefaultMockHost,
+		Method: method,
+		Path:   componentPaths[component],
+	}
+	switch b := body.(type) {
+	case string:
+		assertion.Body = mock.NewStringBody(b)
+	case nil:
+	default:
+		assertion.Body = mock.NewStructBody(b)
+	}
+
+	return mock.New200ResponseAssertion(assertion, mock.NewStructBody(response))
+}
+
+func TestParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Params
+		err    string
+	}{
+		{
+			name: "fails on empty parameters",
+			err: multierror.NewPrefixed("invalid logging settings params",
+				apierror.ErrMissingAPI,
+				errors.New(`component "" is invalid, must be one of [allocator constructor runner adminconsole]`),
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails on an unknown component",
+			params: Params{
+				API:       api.NewMock(),
+				Component: "proxy",
+				ID:        "some-id",
+				Region:    "us-east-1",
+			},
+			err: multierror.NewPrefixed("invalid logging settings params",
+				errors.New(`component "proxy" is invalid, must be one of [allocator constructor runner adminconsole]`),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: Params{
+				API:       api.NewMock(),
+				Component: Runner,
+				ID:        "some-id",
+				Region:    "us-east-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	var settings = &models.LoggingSettings{
+		LoggingLevels: map[string]string{"root": "INFO"},
+	}
+	for _, component := range Components {
+		t.Run(component, func(t *testing.T) {
+			got, err := Get(Params{
+				API:       api.NewMock(settingsResponse("GET", component, nil, settings)),
+				Component: component,
+				ID:        "some-id",
+				Region:    "us-east-1",
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, settings, got)
+		})
+	}
+
+	t.Run("fails due to API error", func(t *testing.T) {
+		got, err := Get(Params{
+			API:       api.NewMock(mock.SampleNotFoundError()),
+			Component: Allocator,
+			ID:        "some-id",
+			Region:    "us-east-1",
+		})
+		assert.EqualError(t, err, mock.MultierrorNotFound.Error())
+		assert.Nil(t, got)
+	})
+}
+
+func TestSet(t *testing.T) {
+	var settings = &models.LoggingSettings{
+		LoggingLevels: map[string]string{"root": "WARN"},
+	}
+	for _, component := range Components {
+		t.Run(component, func(t *testing.T) {
+			got, err := Set(SetParams{
+				Params: Params{
+					API:       api.NewMock(settingsResponse("PUT", component, settings, settings)),
+					Component: component,
+					ID:        "some-id",
+					Region:    "us-east-1",
+				},
+				Settings: settings,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, settings, got)
+		})
+	}
+
+	t.Run("fails due to parameter validation", func(t *testing.T) {
+		got, err := Set(SetParams{Params: Params{
+			API:       api.NewMock(),
+			Component: Allocator,
+			ID:        "some-id",
+			Region:    "us-east-1",
+		}})
+		assert.EqualError(t, err, multierror.NewPrefixed("invalid logging settings set params",
+			errSettingsCannotBeNil,
+		).Error())
+		assert.Nil(t, got)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	var settings = &models.LoggingSettings{
+		LoggingLevels: map[string]string{"root": "INFO", "some.logger": "DEBUG"},
+	}
+	for _, component := range Components {
+		t.Run(component, func(t *testing.T) {
+			got, err := Update(UpdateParams{
+				Params: Params{
+					API: api.NewMock(settingsResponse("PATCH", component,
+						`{"logging_levels":{"some.logger":"DEBUG"}}`, settings,
+					)),
+					Component: component,
+					ID:        "some-id",
+					Region:    "us-east-1",
+				},
+				Levels: map[string]string{"some.logger": "debug"},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, settings, got)
+		})
+	}
+
+	t.Run("fails due to parameter validation", func(t *testing.T) {
+		got, err := Update(UpdateParams{
+			Params: Params{
+				API:       api.NewMock(),
+				Component: Allocator,
+				ID:        "some-id",
+				Region:    "us-east-1",
+			},
+			Levels: map[string]string{"b": "LOUD", "a": "VERBOSE", "c": "debug"},
+		})
+		assert.EqualError(t, err, multierror.NewPrefixed("invalid logging settings update params",
+			multierror.NewPrefixed("invalid logging levels",
+				errors.New(`logger "a" level "VERBOSE" is invalid, must be one of [OFF ERROR WARN INFO DEBUG TRACE ALL]`),
+				errors.New(`logger "b" level "LOUD" is invalid, must be one of [OFF ERROR WARN INFO DEBUG TRACE ALL]`),
+			),
+		).Error())
+		assert.Nil(t, got)
+	})
+
+	t.Run("fails on empty levels", func(t *testing.T) {
+		_, err := Update(UpdateParams{Params: Params{
+			API:       api.NewMock(),
+			Component: Allocator,
+			ID:        "some-id",
+			Region:    "us-east-1",
+		}})
+		assert.EqualError(t, err, multierror.NewPrefixed("invalid logging settings update params",
+			errLevelsCannotBeEmpty,
+		).Error())
+	})
+}
+
+func TestDelete(t *testing.T) {
+	for _, component := range Components {
+		t.Run(component, func(t *testing.T) {
+			err := Delete(Params{
+				API:       api.NewMock(settingsResponse("DELETE", component, nil, struct{}{})),
+				Component: component,
+				ID:        "some-id",
+				Region:    "us-east-1",
+			})
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("fails due to API error", func(t *testing.T) {
+		err := Delete(Params{
+			API:       api.NewMock(mock.SampleInternalError()),
+			Component: Constructor,
+			ID:        "some-id",
+			Region:    "us-east-1",
+		})
+		assert.EqualError(t, err, mock.MultierrorInternalError.Error())
+	})
+}
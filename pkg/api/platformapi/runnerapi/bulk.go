@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runnerapi
+
+import (
+	"fmt"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// BulkRolesParams is consumed by BulkRoles.
+type BulkRolesParams struct {
+	*api.API
+	Region string
+
+	// Query DSL request which selects the runners to change.
+	Request models.SearchRequest
+
+	// Operation to apply to the matching runners' roles.
+	Operation RolesOperation
+	Roles     []string
+
+	// AllowEmpty allows the set operation with no Roles, which clears the
+	// roles of all of the matching runners.
+	AllowEmpty bool
+
+	// Assigns the runners to the roles.
+	Bless bool
+
+	// When set, the changes are computed but not applied.
+	DryRun bool
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params BulkRolesParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid runner bulk roles params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(params.Request.Validate(strfmt.Default))
+
+	switch params.Operation {
+	case SetRolesOperation:
+		if len(params.Roles) == 0 && !params.AllowEmpty {
+			merr = merr.Append(errRolesCannotBeEmpty)
+		}
+	case AddRolesOperation, RemoveRolesOperation:
+		if len(params.Roles) == 0 {
+			merr = merr.Append(errRolesCannotBeEmpty)
+		}
+	default:
+		merr = merr.Append(fmt.Errorf(
+			"operation %q is invalid, must be one of [%s %s %s]", params.Operation,
+			SetRolesOperation, AddRolesOperation, RemoveRolesOperation,
+		))
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// RolesChange describes the roles change of a single runner.
+type RolesChange struct {
+	ID      string   `json:"id"`
+	Before  []string `json:"before"`
+	After   []string `json:"after"`
+	Changed bool     `json:"changed"`
+}
+
+// BulkRoles applies a roles operation to all of the runners matching the
+// search request, returning the computed change for each of them. Runners
+// whose roles wouldn't change are not updated. The changes are applied
+// sequentially and errors don't stop the remaining runners from being
+// changed, instead they're returned once all runners have been processed.
+func BulkRoles(params BulkRolesParams) ([]RolesChange, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := Search(SearchParams{
+		API: params.API, Region: params.Region, Request: params.Request,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var changes = make([]RolesChange, 0, len(res.Runners))
+	var merr = multierror.NewPrefixed("runner bulk roles")
+	for _, runner := range res.Runners {
+		if runner == nil || runner.RunnerID == nil {
+			continue
+		}
+
+		var change = RolesChange{ID: *runner.RunnerID, Before: RoleNames(runner)}
+		change.After, change.Changed = params.Operation.apply(change.Before, params.Roles)
+		changes = append(changes, change)
+
+		if !change.Changed || params.DryRun {
+			continue
+		}
+
+		if _, err := SetRoles(SetRolesParams{
+			API:        params.API,
+			Region:     params.Region,
+			ID:         change.ID,
+			Roles:      change.After,
+			AllowEmpty: true,
+			Bless:      params.Bless,
+		}); err != nil {
+			merr = merr.Append(multierror.NewPrefixed(change.ID, err))
+		}
+	}
+
+	return changes, merr.ErrorOrNil()
+}
@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runnerapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestBulkRoles(t *testing.T) {
+	var request = models.SearchRequest{Query: &models.QueryContainer{
+		Prefix: map[string]models.PrefixQuery{"runner_id": {Value: ec.String("192.168.44.")}},
+	}}
+	var searchResponse = func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "POST",
+				Path:   runnersPath + "/_search",
+				Body:   mock.NewStructBody(request),
+			},
+			mock.NewStructBody(models.RunnerOverview{Runners: []*models.RunnerInfo{
+				newRunner("192.168.44.10", true, "allocator"),
+				newRunner("192.168.44.11", true, "allocator", "proxy"),
+			}}),
+		)
+	}
+	tests := []struct {
+		name   string
+		params BulkRolesParams
+		want   []RolesChange
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: BulkRolesParams{
+				Operation: "replace",
+			},
+			err: multierror.NewPrefixed("invalid runner bulk roles params",
+				apierror.ErrMissingAPI,
+				errors.New(`operation "replace" is invalid, must be one of [set add remove]`),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when add has no roles",
+			params: BulkRolesParams{
+				API:       api.NewMock(),
+				Region:    "us-east-1",
+				Operation: AddRolesOperation,
+			},
+			err: multierror.NewPrefixed("invalid runner bulk roles params",
+				errRolesCannotBeEmpty,
+			).Error(),
+		},
+		{
+			name: "fails when set has no roles",
+			params: BulkRolesParams{
+				API:       api.NewMock(),
+				Region:    "us-east-1",
+				Operation: SetRolesOperation,
+			},
+			err: multierror.NewPrefixed("invalid runner bulk roles params",
+				errRolesCannotBeEmpty,
+			).Error(),
+		},
+		{
+			name: "fails when the search fails",
+			params: BulkRolesParams{
+				API:       api.NewMock(mock.SampleInternalError()),
+				Region:    "us-east-1",
+				Request:   request,
+				Operation: AddRolesOperation,
+				Roles:     []string{"proxy"},
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "adds the roles to the runners which lack them",
+			params: BulkRolesParams{
+				API: api.NewMock(
+					searchResponse(),
+					setRolesResponse("192.168.44.10", nil, "allocator", "proxy"),
+				),
+				Region:    "us-east-1",
+				Request:   request,
+				Operation: AddRolesOperation,
+				Roles:     []string{"proxy"},
+			},
+			want: []RolesChange{
+				{ID: "192.168.44.10", Before: []string{"allocator"}, After: []string{"allocator", "proxy"}, Changed: true},
+				{ID: "192.168.44.11", Before: []string{"allocator", "proxy"}, After: []string{"allocator", "proxy"}},
+			},
+		},
+		{
+			name: "computes the changes on dry run",
+			params: BulkRolesParams{
+				API:        api.NewMock(searchResponse()),
+				Region:     "us-east-1",
+				Request:    request,
+				Operation:  SetRolesOperation,
+				AllowEmpty: true,
+				DryRun:     true,
+			},
+			want: []RolesChange{
+				{ID: "192.168.44.10", Before: []string{"allocator"}, After: []string{}, Changed: true},
+				{ID: "192.168.44.11", Before: []string{"allocator", "proxy"}, After: []string{}, Changed: true},
+			},
+		},
+		{
+			name: "returns the changes along with the errors",
+			params: BulkRolesParams{
+				API: api.NewMock(
+					searchResponse(),
+					mock.SampleNotFoundError(),
+					setRolesResponse("192.168.44.11", nil, "proxy"),
+				),
+				Region:    "us-east-1",
+				Request:   request,
+				Operation: RemoveRolesOperation,
+				Roles:     []string{"allocator"},
+			},
+			want: []RolesChange{
+				{ID: "192.168.44.10", Before: []string{"allocator"}, After: []string{}, Changed: true},
+				{ID: "192.168.44.11", Before: []string{"allocator", "proxy"}, After: []string{"proxy"}, Changed: true},
+			},
+			err: multierror.NewPrefixed("runner bulk roles",
+				multierror.NewPrefixed("192.168.44.10", mock.MultierrorNotFound),
+			).Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BulkRoles(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
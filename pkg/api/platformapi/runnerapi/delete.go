@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runnerapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/platformapi/allocatorapi"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+// ControlPlaneRoles contains the runner roles which host control plane
+// services.
+var ControlPlaneRoles = []string{"coordinator", "director", "proxy"}
+
+// DeleteParams is consumed by Delete.
+type DeleteParams struct {
+	*api.API
+	Region string
+	ID     string
+
+	// Skips the safety checks, deleting the runner even when it's connected
+	// or still hosts an allocator or control plane services.
+	Force bool
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params DeleteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid runner delete params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Delete deletes a runner. Unless Force is set, the runner must be
+// disconnected, must not have any control plane roles or containers, and
+// must not host an allocator, otherwise the runner is not deleted and the
+// reasons are returned.
+func Delete(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	if !params.Force {
+		if err := checkSafeDelete(params); err != nil {
+			return err
+		}
+	}
+
+	return api.ReturnErrOnly(
+		params.API.V1API.PlatformInfrastructure.DeleteRunner(
+			platform_infrastructure.NewDeleteRunnerParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithRunnerID(params.ID),
+			params.AuthWriter,
+		),
+	)
+}
+
+func checkSafeDelete(params DeleteParams) error {
+	runner, err := Show(ShowParams{API: params.API, Region: params.Region, ID: params.ID})
+	if err != nil {
+		return err
+	}
+
+	var merr = multierror.NewPrefixed(fmt.Sprintf("runner %s cannot be safely deleted", params.ID))
+	if runner.Connected != nil && *runner.Connected {
+		merr = merr.Append(errors.New("runner is connected"))
+	}
+
+	var roles []string
+	for _, role := range RoleNames(runner) {
+		if slice.HasString(ControlPlaneRoles, role) {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) > 0 {
+		merr = merr.Append(fmt.Errorf("runner has control plane roles: %s", strings.Join(roles, ", ")))
+	}
+
+	var containers []string
+	for _, c := range runner.Containers {
+		if c != nil && c.ContainerName != nil {
+			containers = append(containers, *c.ContainerName)
+		}
+	}
+	if len(containers) > 0 {
+		merr = merr.Append(fmt.Errorf("runner has containers: %s", strings.Join(containers, ", ")))
+	}
+
+	allocator, err := allocatorapi.Get(allocatorapi.GetParams{
+		API: params.API, Region: params.Region, ID: params.ID,
+	})
+	var notFound *platform_infrastructure.GetAllocatorNotFound
+	switch {
+	case errors.As(err, &notFound):
+	case err != nil:
+		merr = merr.Append(err)
+	default:
+		merr = merr.Append(fmt.Errorf(
+			"runner hosts an allocator with %d instances, vacate and delete it first",
+			len(allocator.Instances),
+		))
+	}
+
+	return merr.ErrorOrNil()
+}
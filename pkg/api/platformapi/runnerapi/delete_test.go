@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runnerapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestDelete(t *testing.T) {
+	const id = "192.168.44.10"
+	var allocatorResponse = func(instances int) mock.Response {
+		var allocator = models.AllocatorInfo{AllocatorID: ec.String(id)}
+		for i := 0; i < instances; i++ {
+			allocator.Instances = append(allocator.Instances, &models.AllocatedInstanceStatus{})
+		}
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "GET",
+				Path:   "/api/v1/regions/us-east-1/platform/infrastructure/allocators/" + id,
+			},
+			mock.NewStructBody(allocator),
+		)
+	}
+	var deleteResponse = func() mock.Response {
+		return mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "DELETE",
+				Path:   runnersPath + "/" + id,
+			},
+			mock.NewStringBody(`{}`),
+		)
+	}
+	var busyRunner = newRunner(id, true, "allocator", "proxy", "coordinator")
+	busyRunner.Containers = []*models.RunnerContainerInfo{
+		{ContainerName: ec.String("frc-proxies-proxy"), ContainerSetName: ec.String("proxies")},
+	}
+
+	tests := []struct {
+		name   string
+		params DeleteParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid runner delete params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when the runner cannot be obtained",
+			params: DeleteParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+				ID:     id,
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "refuses to delete a runner which is in use",
+			params: DeleteParams{
+				API: api.NewMock(
+					showResponse(busyRunner),
+					allocatorResponse(2),
+				),
+				Region: "us-east-1",
+				ID:     id,
+			},
+			err: multierror.NewPrefixed("runner 192.168.44.10 cannot be safely deleted",
+				errors.New("runner is connected"),
+				errors.New("runner has control plane roles: coordinator, proxy"),
+				errors.New("runner has containers: frc-proxies-proxy"),
+				errors.New("runner hosts an allocator with 2 instances, vacate and delete it first"),
+			).Error(),
+		},
+		{
+			name: "refuses to delete when the allocator cannot be checked",
+			params: DeleteParams{
+				API: api.NewMock(
+					showResponse(newRunner(id, false)),
+					mock.SampleInternalError(),
+				),
+				Region: "us-east-1",
+				ID:     id,
+			},
+			err: multierror.NewPrefixed("runner 192.168.44.10 cannot be safely deleted",
+				mock.MultierrorInternalError,
+			).Error(),
+		},
+		{
+			name: "deletes a disconnected runner without an allocator",
+			params: DeleteParams{
+				API: api.NewMock(
+					showResponse(newRunner(id, false, "allocator")),
+					mock.SampleNotFoundError(),
+					deleteResponse(),
+				),
+				Region: "us-east-1",
+				ID:     id,
+			},
+		},
+		{
+			name: "deletes the runner skipping the checks",
+			params: DeleteParams{
+				API:    api.NewMock(deleteResponse()),
+				Region: "us-east-1",
+				ID:     id,
+				Force:  true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Delete(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
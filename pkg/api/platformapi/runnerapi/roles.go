@@ -0,0 +1,255 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runnerapi
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_infrastructure"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+var errRolesCannotBeEmpty = errors.New("roles not specified and are required for the operation")
+
+// SetRolesParams is consumed by SetRoles.
+type SetRolesParams struct {
+	*api.API
+	Region string
+	ID     string
+
+	// Roles which replace the runner's current roles. An empty list removes
+	// all of the runner's roles and requires AllowEmpty.
+	Roles []string
+
+	// AllowEmpty allows an empty list of Roles, which clears the runner's
+	// roles.
+	AllowEmpty bool
+
+	// Assigns the runner to the roles.
+	Bless bool
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params SetRolesParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid runner set roles params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	if len(params.Roles) == 0 && !params.AllowEmpty {
+		merr = merr.Append(errRolesCannotBeEmpty)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// SetRoles replaces the roles of a runner.
+func SetRoles(params SetRolesParams) (*models.RunnerRolesInfo, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var body = models.RunnerRolesInfo{Roles: make([]*models.RunnerRoleInfo, 0, len(params.Roles))}
+	for _, role := range params.Roles {
+		body.Roles = append(body.Roles, &models.RunnerRoleInfo{RoleName: ec.String(role)})
+	}
+
+	var bless *bool
+	if params.Bless {
+		bless = ec.Bool(true)
+	}
+
+	res, err := params.API.V1API.PlatformInfrastructure.SetRunnerRoles(
+		platform_infrastructure.NewSetRunnerRolesParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithRunnerID(params.ID).
+			WithBless(bless).
+			WithBody(&body),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// RolesParams is consumed by AddRoles and RemoveRoles.
+type RolesParams struct {
+	*api.API
+	Region string
+	ID     string
+	Roles  []string
+
+	// Assigns the runner to the roles.
+	Bless bool
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params RolesParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid runner roles params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	if len(params.Roles) == 0 {
+		merr = merr.Append(errRolesCannotBeEmpty)
+	}
+
+	if err := ec.RequireRegionSet(params.Region); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// AddRoles adds the roles to the runner's current roles. When the runner
+// already has all of the roles, no update is performed.
+func AddRoles(params RolesParams) (*models.RunnerRolesInfo, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return changeRoles(params, AddRolesOperation)
+}
+
+// RemoveRoles removes the roles from the runner's current roles. When the
+// runner has none of the roles, no update is performed.
+func RemoveRoles(params RolesParams) (*models.RunnerRolesInfo, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return changeRoles(params, RemoveRolesOperation)
+}
+
+func changeRoles(params RolesParams, op RolesOperation) (*models.RunnerRolesInfo, error) {
+	runner, err := Show(ShowParams{API: params.API, Region: params.Region, ID: params.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	var current = RoleNames(runner)
+	var roles, changed = op.apply(current, params.Roles)
+	if !changed {
+		return &models.RunnerRolesInfo{Roles: runner.Roles}, nil
+	}
+
+	return SetRoles(SetRolesParams{
+		API:        params.API,
+		Region:     params.Region,
+		ID:         params.ID,
+		Roles:      roles,
+		AllowEmpty: true,
+		Bless:      params.Bless,
+	})
+}
+
+// RolesOperation defines how a list of roles is applied to a runner's
+// current roles.
+type RolesOperation string
+
+const (
+	// SetRolesOperation replaces the runner's roles.
+	SetRolesOperation RolesOperation = "set"
+	// AddRolesOperation adds the roles to the runner's roles.
+	AddRolesOperation RolesOperation = "add"
+	// RemoveRolesOperation removes the roles from the runner's roles.
+	RemoveRolesOperation RolesOperation = "remove"
+)
+
+// apply returns the sorted roles resulting from applying the operation to
+// the current roles and whether they differ from the current ones.
+func (op RolesOperation) apply(current, roles []string) ([]string, bool) {
+	var result []string
+	switch op {
+	case SetRolesOperation:
+		result = append(result, roles...)
+	case AddRolesOperation:
+		result = append(result, current...)
+		for _, role := range roles {
+			if !slice.HasString(result, role) {
+				result = append(result, role)
+			}
+		}
+	case RemoveRolesOperation:
+		for _, role := range current {
+			if !slice.HasString(roles, role) {
+				result = append(result, role)
+			}
+		}
+	}
+
+	result = unique(result)
+	var sortedCurrent = unique(current)
+	if len(result) != len(sortedCurrent) {
+		return result, true
+	}
+	for i := range result {
+		if result[i] != sortedCurrent[i] {
+			return result, true
+		}
+	}
+
+	return result, false
+}
+
+// RoleNames returns the sorted role names of a runner.
+func RoleNames(runner *models.RunnerInfo) []string {
+	if runner == nil {
+		return nil
+	}
+
+	var roles = make([]string, 0, len(runner.Roles))
+	for _, role := range runner.Roles {
+		if role != nil && role.RoleName != nil {
+			roles = append(roles, *role.RoleName)
+		}
+	}
+	sort.Strings(roles)
+
+	return roles
+}
+
+func unique(s []string) []string {
+	var result = make([]string, 0, len(s))
+	for _, v := range s {
+		if !slice.HasString(result, v) {
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,262 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package runnerapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const runnersPath = "/api/v1/regions/us-east-1/platform/infrastructure/runners"
+
+func newRunner(id string, connected bool, roles ...string) *models.RunnerInfo {
+	var runner = models.RunnerInfo{
+		RunnerID:  ec.String(id),
+		Connected: ec.Bool(connected),
+		Healthy:   ec.Bool(connected),
+		Roles:     newRoles(roles...).Roles,
+	}
+	return &runner
+}
+
+func newRoles(roles ...string) *models.RunnerRolesInfo {
+	var info = models.RunnerRolesInfo{Roles: make([]*models.RunnerRoleInfo, 0, len(roles))}
+	for _, role := range roles {
+		info.Roles = append(info.Roles, &models.RunnerRoleInfo{RoleName: ec.String(role)})
+	}
+	return &info
+}
+
+func showResponse(runner *models.RunnerInfo) mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultReadMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "GET",
+			Path:   runnersPath + "/" + *runner.RunnerID,
+		},
+		mock.NewStructBody(runner),
+	)
+}
+
+func setRolesResponse(id string, query map[string][]string, roles ...string) mock.Response {
+	return mock.New200ResponseAssertion(
+		&mock.RequestAssertion{
+			Header: api.DefaultWriteMockHeaders,
+			Host:   api.DefaultMockHost,
+			Method: "PUT",
+			Path:   runnersPath + "/" + id + "/roles",
+			Query:  query,
+			Body:   mock.NewStructBody(newRoles(roles...)),
+		},
+		mock.NewStructBody(newRoles(roles...)),
+	)
+}
+
+func TestSetRoles(t *testing.T) {
+	tests := []struct {
+		name   string
+		params SetRolesParams
+		want   *models.RunnerRolesInfo
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid runner set roles params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errRolesCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: SetRolesParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"allocator"},
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: SetRolesParams{
+				API:    api.NewMock(setRolesResponse("192.168.44.10", nil, "allocator", "proxy")),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"allocator", "proxy"},
+			},
+			want: newRoles("allocator", "proxy"),
+		},
+		{
+			name: "succeeds blessing the runner",
+			params: SetRolesParams{
+				API: api.NewMock(setRolesResponse("192.168.44.10",
+					map[string][]string{"bless": {"true"}}, "allocator",
+				)),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"allocator"},
+				Bless:  true,
+			},
+			want: newRoles("allocator"),
+		},
+		{
+			name: "succeeds clearing the roles when empty roles are allowed",
+			params: SetRolesParams{
+				API:        api.NewMock(setRolesResponse("192.168.44.10", nil)),
+				Region:     "us-east-1",
+				ID:         "192.168.44.10",
+				AllowEmpty: true,
+			},
+			want: newRoles(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetRoles(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddRoles(t *testing.T) {
+	tests := []struct {
+		name   string
+		params RolesParams
+		want   *models.RunnerRolesInfo
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid runner roles params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errRolesCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails when the runner cannot be obtained",
+			params: RolesParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"proxy"},
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "adds the missing roles",
+			params: RolesParams{
+				API: api.NewMock(
+					showResponse(newRunner("192.168.44.10", true, "proxy", "allocator")),
+					setRolesResponse("192.168.44.10", nil, "allocator", "director", "proxy"),
+				),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"director", "proxy"},
+			},
+			want: newRoles("allocator", "director", "proxy"),
+		},
+		{
+			name: "doesn't update when the runner has all the roles",
+			params: RolesParams{
+				API: api.NewMock(
+					showResponse(newRunner("192.168.44.10", true, "proxy", "allocator")),
+				),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"allocator"},
+			},
+			want: newRoles("proxy", "allocator"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddRoles(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRemoveRoles(t *testing.T) {
+	tests := []struct {
+		name   string
+		params RolesParams
+		want   *models.RunnerRolesInfo
+		err    string
+	}{
+		{
+			name: "removes the roles",
+			params: RolesParams{
+				API: api.NewMock(
+					showResponse(newRunner("192.168.44.10", true, "proxy", "allocator", "director")),
+					setRolesResponse("192.168.44.10", nil, "allocator"),
+				),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"director", "proxy", "coordinator"},
+			},
+			want: newRoles("allocator"),
+		},
+		{
+			name: "doesn't update when the runner has none of the roles",
+			params: RolesParams{
+				API: api.NewMock(
+					showResponse(newRunner("192.168.44.10", true, "allocator")),
+				),
+				Region: "us-east-1",
+				ID:     "192.168.44.10",
+				Roles:  []string{"proxy"},
+			},
+			want: newRoles("allocator"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RemoveRoles(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
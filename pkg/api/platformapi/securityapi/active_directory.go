@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_security"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// CreateActiveDirectoryParams is consumed by CreateActiveDirectory.
+type CreateActiveDirectoryParams struct {
+	*api.API
+
+	Config *models.ActiveDirectorySettings
+	Region string
+}
+
+// Validate ensures the parameters are usable by CreateActiveDirectory.
+func (params CreateActiveDirectoryParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid active directory security realm create params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(validateActiveDirectoryConfig(params.Config))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// UpdateActiveDirectoryParams is consumed by UpdateActiveDirectory.
+type UpdateActiveDirectoryParams struct {
+	*api.API
+
+	// Required security realm ID.
+	ID     string
+	Config *models.ActiveDirectorySettings
+	Region string
+
+	// Optional version of the security realm. When specified, the realm
+	// is only updated when its version matches.
+	Version string
+}
+
+// Validate ensures the parameters are usable by UpdateActiveDirectory.
+func (params UpdateActiveDirectoryParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid active directory security realm update params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(validateActiveDirectoryConfig(params.Config))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+func validateActiveDirectoryConfig(config *models.ActiveDirectorySettings) error {
+	if config == nil {
+		return errConfigCannotBeEmpty
+	}
+
+	// Role mappings are validated separately to obtain friendlier errors.
+	var settings = *config
+	settings.RoleMappings = nil
+
+	var merr = multierror.NewPrefixed("active directory configuration")
+	merr = merr.Append(settings.Validate(strfmt.Default))
+	merr = merr.Append(ValidateActiveDirectoryRoleMappings(config.RoleMappings))
+
+	return merr.ErrorOrNil()
+}
+
+// CreateActiveDirectory creates a new Active Directory security realm,
+// returning its version.
+func CreateActiveDirectory(params CreateActiveDirectoryParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.CreateActiveDirectoryConfiguration(
+		platform_configuration_security.NewCreateActiveDirectoryConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithBody(params.Config),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	return res.XCloudResourceVersion, nil
+}
+
+// GetActiveDirectory obtains an Active Directory security realm configuration
+// and its version.
+func GetActiveDirectory(params GetParams) (*models.ActiveDirectorySettings, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.GetActiveDirectoryConfiguration(
+		platform_configuration_security.NewGetActiveDirectoryConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithRealmID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// UpdateActiveDirectory updates an existing Active Directory security realm,
+// returning its new version.
+func UpdateActiveDirectory(params UpdateActiveDirectoryParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.UpdateActiveDirectoryConfiguration(
+		platform_configuration_security.NewUpdateActiveDirectoryConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithRealmID(params.ID).
+			WithVersion(versionOrNil(params.Version)).
+			WithBody(params.Config),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	return res.XCloudResourceVersion, nil
+}
+
+// DeleteActiveDirectory deletes an Active Directory security realm.
+func DeleteActiveDirectory(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformConfigurationSecurity.DeleteActiveDirectoryConfiguration(
+			platform_configuration_security.NewDeleteActiveDirectoryConfigurationParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithRealmID(params.ID).
+				WithVersion(versionOrNil(params.Version)),
+			params.AuthWriter,
+		),
+	)
+}
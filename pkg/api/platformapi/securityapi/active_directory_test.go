@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const adRealmPath = "/api/v1/regions/us-east-1/platform/configuration/security/realms/active-directory"
+
+func newActiveDirectorySettings() *models.ActiveDirectorySettings {
+	return &models.ActiveDirectorySettings{
+		ID:              ec.String("ad-1"),
+		Name:            ec.String("Corporate AD"),
+		BindAnonymously: ec.Bool(false),
+		DomainName:      ec.String("example.com"),
+		Urls:            []string{"ldaps://ad.example.com:636"},
+		RoleMappings: &models.ActiveDirectorySecurityRealmRoleMappingRules{
+			DefaultRoles: []string{"ece_platform_viewer"},
+			Rules: []*models.ActiveDirectorySecurityRealmRoleMappingRule{
+				NewActiveDirectoryRoleMappingRule(UserDNRule, "cn=admin,dc=example,dc=com", "ece_platform_admin"),
+			},
+		},
+	}
+}
+
+func TestCreateActiveDirectory(t *testing.T) {
+	tests := []struct {
+		name   string
+		params CreateActiveDirectoryParams
+		want   string
+		err    string
+	}{
+		{
+			name: "fails due to invalid config",
+			params: CreateActiveDirectoryParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+				Config: &models.ActiveDirectorySettings{
+					RoleMappings: &models.ActiveDirectorySecurityRealmRoleMappingRules{
+						Rules: []*models.ActiveDirectorySecurityRealmRoleMappingRule{
+							NewActiveDirectoryRoleMappingRule(GroupsRule, "admins", "ece_platform_admin"),
+						},
+					},
+				},
+			},
+			err: multierror.NewPrefixed("invalid active directory security realm create params",
+				errors.New("active directory configuration: role mappings: rule 0: type must be one of [user_dn group_dn]"),
+				errors.New("active directory configuration: validation failure list:\n"+
+					"bind_anonymously in body is required\n"+
+					"domain_name in body is required\n"+
+					"id in body is required\n"+
+					"name in body is required\n"+
+					"urls in body is required"),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: CreateActiveDirectoryParams{
+				API: api.NewMock(mock.Response{
+					Response: http.Response{
+						StatusCode: 201,
+						Header:     http.Header{"X-Cloud-Resource-Version": {"1"}},
+						Body:       mock.NewStringBody(`{}`),
+					},
+					Assert: &mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   adRealmPath,
+						Body:   mock.NewStructBody(newActiveDirectorySettings()),
+					},
+				}),
+				Region: "us-east-1",
+				Config: newActiveDirectorySettings(),
+			},
+			want: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateActiveDirectory(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetActiveDirectory(t *testing.T) {
+	got, version, err := GetActiveDirectory(GetParams{
+		API: api.NewMock(mock.Response{
+			Response: http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"X-Cloud-Resource-Version": {"2"}},
+				Body:       mock.NewStructBody(newActiveDirectorySettings()),
+			},
+			Assert: &mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "GET",
+				Path:   adRealmPath + "/ad-1",
+			},
+		}),
+		Region: "us-east-1",
+		ID:     "ad-1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, newActiveDirectorySettings(), got)
+	assert.Equal(t, "2", version)
+}
+
+func TestUpdateActiveDirectory(t *testing.T) {
+	got, err := UpdateActiveDirectory(UpdateActiveDirectoryParams{
+		API:    api.NewMock(mock.SampleInternalError()),
+		Region: "us-east-1",
+		ID:     "ad-1",
+		Config: newActiveDirectorySettings(),
+	})
+	assert.EqualError(t, err, mock.MultierrorInternalError.Error())
+	assert.Empty(t, got)
+}
+
+func TestDeleteActiveDirectory(t *testing.T) {
+	err := DeleteActiveDirectory(DeleteParams{
+		API: api.NewMock(mock.New200ResponseAssertion(
+			&mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "DELETE",
+				Path:   adRealmPath + "/ad-1",
+				Query:  map[string][]string{"version": {"2"}},
+			},
+			mock.NewStringBody(`{}`),
+		)),
+		Region:  "us-east-1",
+		ID:      "ad-1",
+		Version: "2",
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+)
+
+var (
+	errFailedParsingConfig = errors.New("failed to parse config format")
+	errReaderCannotBeNil   = errors.New("reader cannot be nil")
+)
+
+// ParseLdapConfig reads the contents of an io.Reader and tries to parse its
+// contents as YAML or JSON LDAP security realm settings.
+func ParseLdapConfig(input io.Reader) (*models.LdapSettings, error) {
+	var config models.LdapSettings
+	if err := parseConfig(input, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// ParseActiveDirectoryConfig reads the contents of an io.Reader and tries to
+// parse its contents as YAML or JSON Active Directory security realm settings.
+func ParseActiveDirectoryConfig(input io.Reader) (*models.ActiveDirectorySettings, error) {
+	var config models.ActiveDirectorySettings
+	if err := parseConfig(input, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// ParseSamlConfig reads the contents of an io.Reader and tries to parse its
+// contents as YAML or JSON SAML security realm settings.
+func ParseSamlConfig(input io.Reader) (*models.SamlSettings, error) {
+	var config models.SamlSettings
+	if err := parseConfig(input, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// parseConfig decodes the input as YAML or JSON, returning an error when
+// parsing fails in both formats.
+func parseConfig(input io.Reader, v interface{}) error {
+	if input == nil {
+		return errReaderCannotBeNil
+	}
+
+	var buf = new(bytes.Buffer)
+	if _, err := buf.ReadFrom(input); err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(buf.Bytes(), v); err == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), v); err != nil {
+		return fmt.Errorf("%s: %w", errFailedParsingConfig, err)
+	}
+
+	return nil
+}
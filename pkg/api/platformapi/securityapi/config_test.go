@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestParseLdapConfig(t *testing.T) {
+	var want = &models.LdapSettings{
+		ID:   ec.String("ldap-1"),
+		Name: ec.String("Corporate LDAP"),
+		Urls: []string{"ldap://ldap.example.com:389"},
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  *models.LdapSettings
+		err   string
+	}{
+		{
+			name:  "parses YAML",
+			input: "id: ldap-1\nname: Corporate LDAP\nurls:\n- ldap://ldap.example.com:389\n",
+			want:  want,
+		},
+		{
+			name:  "parses JSON",
+			input: `{"id":"ldap-1","name":"Corporate LDAP","urls":["ldap://ldap.example.com:389"]}`,
+			want:  want,
+		},
+		{
+			name:  "fails on invalid input",
+			input: "{id: [",
+			err:   "failed to parse config format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLdapConfig(strings.NewReader(tt.input))
+			if tt.err != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseActiveDirectoryConfig(t *testing.T) {
+	got, err := ParseActiveDirectoryConfig(strings.NewReader("id: ad-1\ndomain_name: example.com\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &models.ActiveDirectorySettings{
+		ID:         ec.String("ad-1"),
+		DomainName: ec.String("example.com"),
+	}, got)
+}
+
+func TestParseSamlConfig(t *testing.T) {
+	got, err := ParseSamlConfig(nil)
+	assert.EqualError(t, err, errReaderCannotBeNil.Error())
+	assert.Nil(t, got)
+
+	got, err = ParseSamlConfig(strings.NewReader(`{"id":"saml-1","idp":{"entity_id":"https://sso.example.com"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &models.SamlSettings{
+		ID:  ec.String("saml-1"),
+		Idp: &models.SamlIdpSettings{EntityID: ec.String("https://sso.example.com")},
+	}, got)
+}
@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_security"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// CreateLdapParams is consumed by CreateLdap.
+type CreateLdapParams struct {
+	*api.API
+
+	Config *models.LdapSettings
+	Region string
+}
+
+// Validate ensures the parameters are usable by CreateLdap.
+func (params CreateLdapParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid ldap security realm create params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(validateLdapConfig(params.Config))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// UpdateLdapParams is consumed by UpdateLdap.
+type UpdateLdapParams struct {
+	*api.API
+
+	// Required security realm ID.
+	ID     string
+	Config *models.LdapSettings
+	Region string
+
+	// Optional version of the security realm. When specified, the realm
+	// is only updated when its version matches.
+	Version string
+}
+
+// Validate ensures the parameters are usable by UpdateLdap.
+func (params UpdateLdapParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid ldap security realm update params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(validateLdapConfig(params.Config))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+func validateLdapConfig(config *models.LdapSettings) error {
+	if config == nil {
+		return errConfigCannotBeEmpty
+	}
+
+	// Role mappings are validated separately to obtain friendlier errors.
+	var settings = *config
+	settings.RoleMappings = nil
+
+	var merr = multierror.NewPrefixed("ldap configuration")
+	merr = merr.Append(settings.Validate(strfmt.Default))
+	merr = merr.Append(ValidateLdapRoleMappings(config.RoleMappings))
+
+	return merr.ErrorOrNil()
+}
+
+// CreateLdap creates a new LDAP security realm, returning its version.
+func CreateLdap(params CreateLdapParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.CreateLdapConfiguration(
+		platform_configuration_security.NewCreateLdapConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithBody(params.Config),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	return res.XCloudResourceVersion, nil
+}
+
+// GetLdap obtains an LDAP security realm configuration and its version.
+func GetLdap(params GetParams) (*models.LdapSettings, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.GetLdapConfiguration(
+		platform_configuration_security.NewGetLdapConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithRealmID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// UpdateLdap updates an existing LDAP security realm, returning its new
+// version.
+func UpdateLdap(params UpdateLdapParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.UpdateLdapConfiguration(
+		platform_configuration_security.NewUpdateLdapConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithRealmID(params.ID).
+			WithVersion(versionOrNil(params.Version)).
+			WithBody(params.Config),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	return res.XCloudResourceVersion, nil
+}
+
+// DeleteLdap deletes an LDAP security realm.
+func DeleteLdap(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformConfigurationSecurity.DeleteLdapConfiguration(
+			platform_configuration_security.NewDeleteLdapConfigurationParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithRealmID(params.ID).
+				WithVersion(versionOrNil(params.Version)),
+			params.AuthWriter,
+		),
+	)
+}
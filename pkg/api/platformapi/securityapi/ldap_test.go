@@ -0,0 +1,314 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const ldapRealmPath = "/api/v1/regions/us-east-1/platform/configuration/security/realms/ldap"
+
+func newLdapSettings() *models.LdapSettings {
+	return &models.LdapSettings{
+		ID:              ec.String("ldap-1"),
+		Name:            ec.String("Corporate LDAP"),
+		BindAnonymously: ec.Bool(true),
+		BindType:        ec.String("user_templates"),
+		Urls:            []string{"ldaps://ldap.example.com:636"},
+		UserDnTemplates: []string{"cn={0},ou=users,dc=example,dc=com"},
+		RoleMappings: &models.LdapSecurityRealmRoleMappingRules{
+			DefaultRoles: []string{},
+			Rules: []*models.LdapSecurityRealmRoleMappingRule{
+				NewLdapRoleMappingRule(GroupDNRule, "cn=admins,dc=example,dc=com", "ece_platform_admin"),
+			},
+		},
+	}
+}
+
+func newVersionResponse(code int, version string) mock.Response {
+	return mock.Response{Response: http.Response{
+		StatusCode: code,
+		Header:     http.Header{"X-Cloud-Resource-Version": {version}},
+		Body:       mock.NewStringBody(`{}`),
+	}}
+}
+
+func TestCreateLdap(t *testing.T) {
+	tests := []struct {
+		name   string
+		params CreateLdapParams
+		want   string
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid ldap security realm create params",
+				errors.New("api reference is required for the operation"),
+				errors.New("config not specified and is required for this operation"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to invalid role mappings",
+			params: CreateLdapParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+				Config: &models.LdapSettings{
+					ID:              ec.String("ldap-1"),
+					Name:            ec.String("Corporate LDAP"),
+					BindAnonymously: ec.Bool(true),
+					BindType:        ec.String("user_templates"),
+					Urls:            []string{"ldaps://ldap.example.com:636"},
+					RoleMappings: &models.LdapSecurityRealmRoleMappingRules{
+						DefaultRoles: []string{},
+						Rules: []*models.LdapSecurityRealmRoleMappingRule{
+							NewLdapRoleMappingRule(GroupDNRule, ""),
+						},
+					},
+				},
+			},
+			err: multierror.NewPrefixed("invalid ldap security realm create params",
+				errors.New("ldap configuration: role mappings: rule 0: value cannot be empty"),
+				errors.New("ldap configuration: role mappings: rule 0: at least 1 role must be specified"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: CreateLdapParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+				Config: newLdapSettings(),
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: CreateLdapParams{
+				API: api.NewMock(mock.Response{
+					Response: http.Response{
+						StatusCode: 201,
+						Header:     http.Header{"X-Cloud-Resource-Version": {"1"}},
+						Body:       mock.NewStringBody(`{}`),
+					},
+					Assert: &mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   ldapRealmPath,
+						Body:   mock.NewStructBody(newLdapSettings()),
+					},
+				}),
+				Region: "us-east-1",
+				Config: newLdapSettings(),
+			},
+			want: "1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateLdap(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetLdap(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      GetParams
+		want        *models.LdapSettings
+		wantVersion string
+		err         string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid security realm get params",
+				errors.New("api reference is required for the operation"),
+				errors.New("id not specified and is required for this operation"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: GetParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+				ID:     "ldap-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetParams{
+				API: api.NewMock(mock.Response{
+					Response: http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"X-Cloud-Resource-Version": {"3"}},
+						Body:       mock.NewStructBody(newLdapSettings()),
+					},
+					Assert: &mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   ldapRealmPath + "/ldap-1",
+					},
+				}),
+				Region: "us-east-1",
+				ID:     "ldap-1",
+			},
+			want:        newLdapSettings(),
+			wantVersion: "3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, version, err := GetLdap(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestUpdateLdap(t *testing.T) {
+	tests := []struct {
+		name   string
+		params UpdateLdapParams
+		want   string
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: UpdateLdapParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+				Config: newLdapSettings(),
+			},
+			err: multierror.NewPrefixed("invalid ldap security realm update params",
+				errors.New("id not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "succeeds with a version",
+			params: UpdateLdapParams{
+				API: api.NewMock(mock.Response{
+					Response: http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"X-Cloud-Resource-Version": {"4"}},
+						Body:       mock.NewStringBody(`{}`),
+					},
+					Assert: &mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "PUT",
+						Path:   ldapRealmPath + "/ldap-1",
+						Query:  map[string][]string{"version": {"3"}},
+						Body:   mock.NewStructBody(newLdapSettings()),
+					},
+				}),
+				Region:  "us-east-1",
+				ID:      "ldap-1",
+				Version: "3",
+				Config:  newLdapSettings(),
+			},
+			want: "4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UpdateLdap(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeleteLdap(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DeleteParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid security realm delete params",
+				errors.New("api reference is required for the operation"),
+				errors.New("id not specified and is required for this operation"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: DeleteParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+				ID:     "ldap-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: DeleteParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "DELETE",
+						Path:   ldapRealmPath + "/ldap-1",
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				Region: "us-east-1",
+				ID:     "ldap-1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DeleteLdap(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_security"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// ListParams is consumed by List.
+type ListParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by List.
+func (params ListParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid security realm list params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// List returns all of the configured security realms.
+func List(params ListParams) (*models.SecurityRealmInfoList, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.GetSecurityRealmConfigurations(
+		platform_configuration_security.NewGetSecurityRealmConfigurationsParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// ReorderParams is consumed by Reorder.
+type ReorderParams struct {
+	*api.API
+
+	// Required security realm IDs in the desired order.
+	IDs    []string
+	Region string
+}
+
+// Validate ensures the parameters are usable by Reorder.
+func (params ReorderParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid security realm reorder params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if len(params.IDs) == 0 {
+		merr = merr.Append(errors.New("at least 1 security realm id must be specified"))
+	}
+
+	var seen = make(map[string]struct{}, len(params.IDs))
+	for _, id := range params.IDs {
+		if id == "" {
+			merr = merr.Append(errors.New("security realm id cannot be empty"))
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			merr = merr.Append(fmt.Errorf("security realm id %s is specified more than once", id))
+		}
+		seen[id] = struct{}{}
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Reorder sets the order in which the security realms are evaluated.
+func Reorder(params ReorderParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformConfigurationSecurity.ReorderSecurityRealms(
+			platform_configuration_security.NewReorderSecurityRealmsParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithBody(&models.SecurityRealmsReorderRequest{Realms: params.IDs}),
+			params.AuthWriter,
+		),
+	)
+}
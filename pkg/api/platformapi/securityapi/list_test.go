@@ -0,0 +1,146 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestList(t *testing.T) {
+	var realms = &models.SecurityRealmInfoList{
+		Realms: []*models.SecurityRealmInfo{
+			{ID: ec.String("ldap-1"), Name: ec.String("Corporate LDAP"), Type: ec.String("ldap"), Enabled: ec.Bool(true)},
+			{ID: ec.String("saml-1"), Name: ec.String("Corporate SSO"), Type: ec.String("saml"), Enabled: ec.Bool(false)},
+		},
+	}
+	tests := []struct {
+		name   string
+		params ListParams
+		want   *models.SecurityRealmInfoList
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid security realm list params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: ListParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: ListParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   "/api/v1/regions/us-east-1/platform/configuration/security/realms",
+					},
+					mock.NewStructBody(realms),
+				)),
+				Region: "us-east-1",
+			},
+			want: realms,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := List(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReorder(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ReorderParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid security realm reorder params",
+				apierror.ErrMissingAPI,
+				errors.New("at least 1 security realm id must be specified"),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to empty and duplicated IDs",
+			params: ReorderParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+				IDs:    []string{"ldap-1", "", "ldap-1"},
+			},
+			err: multierror.NewPrefixed("invalid security realm reorder params",
+				errors.New("security realm id cannot be empty"),
+				errors.New("security realm id ldap-1 is specified more than once"),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: ReorderParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   "/api/v1/regions/us-east-1/platform/configuration/security/realms/_reorder",
+						Body:   mock.NewStringBody(`{"realms":["saml-1","ldap-1"]}` + "\n"),
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				Region: "us-east-1",
+				IDs:    []string{"saml-1", "ldap-1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Reorder(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
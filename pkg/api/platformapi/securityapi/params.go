@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var (
+	errIDCannotBeEmpty     = errors.New("id not specified and is required for this operation")
+	errConfigCannotBeEmpty = errors.New("config not specified and is required for this operation")
+)
+
+// GetParams is consumed by the security realm Get functions.
+type GetParams struct {
+	*api.API
+
+	// Required security realm ID.
+	ID     string
+	Region string
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params GetParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid security realm get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// DeleteParams is consumed by the security realm Delete functions.
+type DeleteParams struct {
+	*api.API
+
+	// Required security realm ID.
+	ID     string
+	Region string
+
+	// Optional version of the security realm. When specified, the realm
+	// is only deleted when its version matches.
+	Version string
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params DeleteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid security realm delete params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// versionOrNil returns nil when the version is empty, skipping the version
+// check on the server side.
+func versionOrNil(version string) *string {
+	if version == "" {
+		return nil
+	}
+	return ec.String(version)
+}
@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+	"github.com/elastic/cloud-sdk-go/pkg/util/slice"
+)
+
+const (
+	// UserDNRule maps the roles to a user distinguished name (LDAP and
+	// Active Directory).
+	UserDNRule = "user_dn"
+
+	// GroupDNRule maps the roles to a group distinguished name (LDAP and
+	// Active Directory).
+	GroupDNRule = "group_dn"
+
+	// UsernameRule maps the roles to a username (SAML).
+	UsernameRule = "username"
+
+	// GroupsRule maps the roles to a group (SAML).
+	GroupsRule = "groups"
+
+	// DNRule maps the roles to a distinguished name (SAML).
+	DNRule = "dn"
+)
+
+var (
+	ldapRuleTypes = []string{UserDNRule, GroupDNRule}
+	samlRuleTypes = []string{UsernameRule, GroupsRule, DNRule}
+)
+
+// NewLdapRoleMappingRule returns an LDAP role mapping rule which maps the
+// value of the specified rule type to the roles.
+func NewLdapRoleMappingRule(ruleType, value string, roles ...string) *models.LdapSecurityRealmRoleMappingRule {
+	return &models.LdapSecurityRealmRoleMappingRule{
+		Type: ec.String(ruleType), Value: ec.String(value), Roles: roles,
+	}
+}
+
+// NewActiveDirectoryRoleMappingRule returns an Active Directory role mapping
+// rule which maps the value of the specified rule type to the roles.
+func NewActiveDirectoryRoleMappingRule(ruleType, value string, roles ...string) *models.ActiveDirectorySecurityRealmRoleMappingRule {
+	return &models.ActiveDirectorySecurityRealmRoleMappingRule{
+		Type: ec.String(ruleType), Value: ec.String(value), Roles: roles,
+	}
+}
+
+// NewSamlRoleMappingRule returns a SAML role mapping rule which maps the
+// value of the specified rule type to the roles.
+func NewSamlRoleMappingRule(ruleType, value string, roles ...string) *models.SamlSecurityRealmRoleMappingRule {
+	return &models.SamlSecurityRealmRoleMappingRule{
+		Type: ec.String(ruleType), Value: ec.String(value), Roles: roles,
+	}
+}
+
+// ValidateLdapRoleMappings ensures the LDAP role mappings are valid.
+func ValidateLdapRoleMappings(mappings *models.LdapSecurityRealmRoleMappingRules) error {
+	if mappings == nil {
+		return nil
+	}
+
+	var merr = multierror.NewPrefixed("role mappings")
+	for i, rule := range mappings.Rules {
+		if rule == nil {
+			merr = merr.Append(fmt.Errorf("rule %d cannot be nil", i))
+			continue
+		}
+		merr = merr.Append(validateRule(i, rule.Type, rule.Value, rule.Roles, ldapRuleTypes))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// ValidateActiveDirectoryRoleMappings ensures the Active Directory role
+// mappings are valid.
+func ValidateActiveDirectoryRoleMappings(mappings *models.ActiveDirectorySecurityRealmRoleMappingRules) error {
+	if mappings == nil {
+		return nil
+	}
+
+	var merr = multierror.NewPrefixed("role mappings")
+	for i, rule := range mappings.Rules {
+		if rule == nil {
+			merr = merr.Append(fmt.Errorf("rule %d cannot be nil", i))
+			continue
+		}
+		merr = merr.Append(validateRule(i, rule.Type, rule.Value, rule.Roles, ldapRuleTypes))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// ValidateSamlRoleMappings ensures the SAML role mappings are valid.
+func ValidateSamlRoleMappings(mappings *models.SamlSecurityRealmRoleMappingRules) error {
+	if mappings == nil {
+		return nil
+	}
+
+	var merr = multierror.NewPrefixed("role mappings")
+	for i, rule := range mappings.Rules {
+		if rule == nil {
+			merr = merr.Append(fmt.Errorf("rule %d cannot be nil", i))
+			continue
+		}
+		merr = merr.Append(validateRule(i, rule.Type, rule.Value, rule.Roles, samlRuleTypes))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func validateRule(i int, ruleType, value *string, roles, validTypes []string) error {
+	var merr = multierror.NewPrefixed(fmt.Sprintf("rule %d", i))
+	if ruleType == nil || !slice.HasString(validTypes, *ruleType) {
+		merr = merr.Append(fmt.Errorf("type must be one of %v", validTypes))
+	}
+
+	if value == nil || *value == "" {
+		merr = merr.Append(errors.New("value cannot be empty"))
+	}
+
+	if len(roles) == 0 {
+		merr = merr.Append(errors.New("at least 1 role must be specified"))
+	}
+
+	return merr.ErrorOrNil()
+}
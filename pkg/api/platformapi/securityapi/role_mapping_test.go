@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func TestNewLdapRoleMappingRule(t *testing.T) {
+	assert.Equal(t, &models.LdapSecurityRealmRoleMappingRule{
+		Type:  ec.String(GroupDNRule),
+		Value: ec.String("cn=admins,dc=example,dc=com"),
+		Roles: []string{"ece_platform_admin", "ece_deployment_manager"},
+	}, NewLdapRoleMappingRule(GroupDNRule, "cn=admins,dc=example,dc=com",
+		"ece_platform_admin", "ece_deployment_manager",
+	))
+}
+
+func TestValidateLdapRoleMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings *models.LdapSecurityRealmRoleMappingRules
+		err      error
+	}{
+		{name: "nil mappings are valid"},
+		{
+			name: "valid mappings",
+			mappings: &models.LdapSecurityRealmRoleMappingRules{
+				Rules: []*models.LdapSecurityRealmRoleMappingRule{
+					NewLdapRoleMappingRule(UserDNRule, "cn=admin,dc=example,dc=com", "ece_platform_admin"),
+					NewLdapRoleMappingRule(GroupDNRule, "cn=viewers,dc=example,dc=com", "ece_platform_viewer"),
+				},
+			},
+		},
+		{
+			name: "invalid mappings",
+			mappings: &models.LdapSecurityRealmRoleMappingRules{
+				Rules: []*models.LdapSecurityRealmRoleMappingRule{
+					nil,
+					NewLdapRoleMappingRule(UsernameRule, ""),
+				},
+			},
+			err: multierror.NewPrefixed("role mappings",
+				errors.New("rule 0 cannot be nil"),
+				multierror.NewPrefixed("rule 1",
+					errors.New("type must be one of [user_dn group_dn]"),
+					errors.New("value cannot be empty"),
+					errors.New("at least 1 role must be specified"),
+				),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLdapRoleMappings(tt.mappings)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateActiveDirectoryRoleMappings(t *testing.T) {
+	assert.NoError(t, ValidateActiveDirectoryRoleMappings(&models.ActiveDirectorySecurityRealmRoleMappingRules{
+		Rules: []*models.ActiveDirectorySecurityRealmRoleMappingRule{
+			NewActiveDirectoryRoleMappingRule(GroupDNRule, "cn=admins,dc=example,dc=com", "ece_platform_admin"),
+		},
+	}))
+}
+
+func TestValidateSamlRoleMappings(t *testing.T) {
+	err := ValidateSamlRoleMappings(&models.SamlSecurityRealmRoleMappingRules{
+		Rules: []*models.SamlSecurityRealmRoleMappingRule{
+			NewSamlRoleMappingRule(GroupsRule, "ece-admins", "ece_platform_admin"),
+			NewSamlRoleMappingRule(UserDNRule, "cn=admin", "ece_platform_admin"),
+		},
+	})
+	assert.EqualError(t, err, multierror.NewPrefixed("role mappings",
+		multierror.NewPrefixed("rule 1",
+			errors.New("type must be one of [username groups dn]"),
+		),
+	).Error())
+}
@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_security"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// CreateSamlParams is consumed by CreateSaml.
+type CreateSamlParams struct {
+	*api.API
+
+	Config *models.SamlSettings
+	Region string
+}
+
+// Validate ensures the parameters are usable by CreateSaml.
+func (params CreateSamlParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid saml security realm create params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(validateSamlConfig(params.Config))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// UpdateSamlParams is consumed by UpdateSaml.
+type UpdateSamlParams struct {
+	*api.API
+
+	// Required security realm ID.
+	ID     string
+	Config *models.SamlSettings
+	Region string
+
+	// Optional version of the security realm. When specified, the realm
+	// is only updated when its version matches.
+	Version string
+}
+
+// Validate ensures the parameters are usable by UpdateSaml.
+func (params UpdateSamlParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid saml security realm update params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(validateSamlConfig(params.Config))
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+func validateSamlConfig(config *models.SamlSettings) error {
+	if config == nil {
+		return errConfigCannotBeEmpty
+	}
+
+	// Role mappings are validated separately to obtain friendlier errors.
+	var settings = *config
+	settings.RoleMappings = nil
+
+	var merr = multierror.NewPrefixed("saml configuration")
+	merr = merr.Append(settings.Validate(strfmt.Default))
+	merr = merr.Append(ValidateSamlRoleMappings(config.RoleMappings))
+
+	return merr.ErrorOrNil()
+}
+
+// CreateSaml creates a new SAML security realm, returning its version.
+func CreateSaml(params CreateSamlParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.CreateSamlConfiguration(
+		platform_configuration_security.NewCreateSamlConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithBody(params.Config),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	return res.XCloudResourceVersion, nil
+}
+
+// GetSaml obtains a SAML security realm configuration and its version.
+func GetSaml(params GetParams) (*models.SamlSettings, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.GetSamlConfiguration(
+		platform_configuration_security.NewGetSamlConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithRealmID(params.ID),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// UpdateSaml updates an existing SAML security realm, returning its new
+// version.
+func UpdateSaml(params UpdateSamlParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.UpdateSamlConfiguration(
+		platform_configuration_security.NewUpdateSamlConfigurationParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithRealmID(params.ID).
+			WithVersion(versionOrNil(params.Version)).
+			WithBody(params.Config),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	return res.XCloudResourceVersion, nil
+}
+
+// DeleteSaml deletes a SAML security realm.
+func DeleteSaml(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return api.ReturnErrOnly(
+		params.V1API.PlatformConfigurationSecurity.DeleteSamlConfiguration(
+			platform_configuration_security.NewDeleteSamlConfigurationParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithRealmID(params.ID).
+				WithVersion(versionOrNil(params.Version)),
+			params.AuthWriter,
+		),
+	)
+}
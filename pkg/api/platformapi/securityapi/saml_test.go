@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const samlRealmPath = "/api/v1/regions/us-east-1/platform/configuration/security/realms/saml"
+
+func newSamlSettings() *models.SamlSettings {
+	return &models.SamlSettings{
+		ID:   ec.String("saml-1"),
+		Name: ec.String("Corporate SSO"),
+		Attributes: &models.SamlAttributeSettings{
+			Principal: ec.String("nameid"),
+			Groups:    ec.String("groups"),
+		},
+		Idp: &models.SamlIdpSettings{
+			EntityID:     ec.String("https://sso.example.com"),
+			MetadataPath: ec.String("https://sso.example.com/metadata"),
+		},
+		Sp: &models.SamlSpSettings{
+			Acs:      ec.String("https://ece.example.com:12443/api/v1/users/auth/saml/_callback"),
+			EntityID: ec.String("https://ece.example.com:12443"),
+			Logout:   ec.String("https://ece.example.com:12443/logout"),
+		},
+		RoleMappings: &models.SamlSecurityRealmRoleMappingRules{
+			DefaultRoles: []string{},
+			Rules: []*models.SamlSecurityRealmRoleMappingRule{
+				NewSamlRoleMappingRule(GroupsRule, "ece-admins", "ece_platform_admin"),
+			},
+		},
+	}
+}
+
+func TestCreateSaml(t *testing.T) {
+	got, err := CreateSaml(CreateSamlParams{
+		API: api.NewMock(mock.Response{
+			Response: http.Response{
+				StatusCode: 201,
+				Header:     http.Header{"X-Cloud-Resource-Version": {"1"}},
+				Body:       mock.NewStringBody(`{}`),
+			},
+			Assert: &mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "POST",
+				Path:   samlRealmPath,
+				Body:   mock.NewStructBody(newSamlSettings()),
+			},
+		}),
+		Region: "us-east-1",
+		Config: newSamlSettings(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", got)
+}
+
+func TestGetSaml(t *testing.T) {
+	got, version, err := GetSaml(GetParams{
+		API: api.NewMock(mock.Response{
+			Response: http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"X-Cloud-Resource-Version": {"5"}},
+				Body:       mock.NewStructBody(newSamlSettings()),
+			},
+			Assert: &mock.RequestAssertion{
+				Header: api.DefaultReadMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "GET",
+				Path:   samlRealmPath + "/saml-1",
+			},
+		}),
+		Region: "us-east-1",
+		ID:     "saml-1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, newSamlSettings(), got)
+	assert.Equal(t, "5", version)
+}
+
+func TestUpdateSaml(t *testing.T) {
+	got, err := UpdateSaml(UpdateSamlParams{
+		API: api.NewMock(mock.Response{
+			Response: http.Response{
+				StatusCode: 200,
+				Header:     http.Header{"X-Cloud-Resource-Version": {"6"}},
+				Body:       mock.NewStringBody(`{}`),
+			},
+			Assert: &mock.RequestAssertion{
+				Header: api.DefaultWriteMockHeaders,
+				Host:   api.DefaultMockHost,
+				Method: "PUT",
+				Path:   samlRealmPath + "/saml-1",
+				Body:   mock.NewStructBody(newSamlSettings()),
+			},
+		}),
+		Region: "us-east-1",
+		ID:     "saml-1",
+		Config: newSamlSettings(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "6", got)
+}
+
+func TestDeleteSaml(t *testing.T) {
+	err := DeleteSaml(DeleteParams{
+		API:    api.NewMock(mock.SampleNotFoundError()),
+		Region: "us-east-1",
+		ID:     "saml-1",
+	})
+	assert.EqualError(t, err, mock.MultierrorNotFound.Error())
+}
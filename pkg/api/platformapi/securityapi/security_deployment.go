@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_security"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errRequestCannotBeEmpty = errors.New("request not specified and is required for this operation")
+
+// GetSecurityDeploymentParams is consumed by GetSecurityDeployment.
+type GetSecurityDeploymentParams struct {
+	*api.API
+
+	Region string
+}
+
+// Validate ensures the parameters are usable by GetSecurityDeployment.
+func (params GetSecurityDeploymentParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid security deployment get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// GetSecurityDeployment returns the ECE security deployment.
+func GetSecurityDeployment(params GetSecurityDeploymentParams) (*models.SecurityDeployment, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.GetSecurityDeployment(
+		platform_configuration_security.NewGetSecurityDeploymentParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// CreateSecurityDeploymentParams is consumed by CreateSecurityDeployment.
+type CreateSecurityDeploymentParams struct {
+	*api.API
+	planutil.TrackParams
+
+	// Optional create request. When omitted, the security deployment is
+	// created with the platform defaults.
+	Request *models.SecurityDeploymentCreateRequest
+	Region  string
+}
+
+// Validate ensures the parameters are usable by CreateSecurityDeployment.
+func (params CreateSecurityDeploymentParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid security deployment create params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(params.TrackParams.Validate())
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// CreateSecurityDeployment creates the ECE security deployment, returning the
+// ID from the API response. The plan change is tracked when TrackParams.Track
+// is set.
+func CreateSecurityDeployment(params CreateSecurityDeploymentParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	var request = params.Request
+	if request == nil {
+		request = new(models.SecurityDeploymentCreateRequest)
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.CreateSecurityDeployment(
+		platform_configuration_security.NewCreateSecurityDeploymentParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithBody(request),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	var id string
+	if res.Payload != nil && res.Payload.ID != nil {
+		id = *res.Payload.ID
+	}
+
+	return id, trackSecurityDeployment(params.API, params.Region, params.TrackParams)
+}
+
+// UpdateSecurityDeploymentParams is consumed by UpdateSecurityDeployment.
+type UpdateSecurityDeploymentParams struct {
+	*api.API
+	planutil.TrackParams
+
+	// Required update request, containing the topology and / or version.
+	Request *models.SecurityDeploymentUpdateRequest
+	Region  string
+}
+
+// Validate ensures the parameters are usable by UpdateSecurityDeployment.
+func (params UpdateSecurityDeploymentParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid security deployment update params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Request == nil {
+		merr = merr.Append(errRequestCannotBeEmpty)
+	}
+
+	merr = merr.Append(params.TrackParams.Validate())
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// UpdateSecurityDeployment updates the ECE security deployment topology or
+// version, returning the ID from the API response. The plan change is tracked
+// when TrackParams.Track is set.
+func UpdateSecurityDeployment(params UpdateSecurityDeploymentParams) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationSecurity.UpdateSecurityDeployment(
+		platform_configuration_security.NewUpdateSecurityDeploymentParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithBody(params.Request),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return "", apierror.Wrap(err)
+	}
+
+	var id string
+	if res.Payload != nil && res.Payload.ID != nil {
+		id = *res.Payload.ID
+	}
+
+	return id, trackSecurityDeployment(params.API, params.Region, params.TrackParams)
+}
+
+// EnsureSecurityDeployment creates the ECE security deployment when it does
+// not exist yet, otherwise the existing security deployment is returned
+// untouched. The returned boolean is true when the security deployment has
+// been created by this call. A concurrent creation (409 Conflict) is treated
+// as the security deployment already existing.
+func EnsureSecurityDeployment(params CreateSecurityDeploymentParams) (*models.SecurityDeployment, bool, error) {
+	if err := params.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	var getParams = GetSecurityDeploymentParams{
+		API: params.API, Region: params.Region,
+	}
+
+	dep, err := GetSecurityDeployment(getParams)
+	if err == nil {
+		return dep, false, nil
+	}
+
+	var notFound *platform_configuration_security.GetSecurityDeploymentNotFound
+	if !errors.As(err, &notFound) {
+		return nil, false, err
+	}
+
+	var created = true
+	if _, err := CreateSecurityDeployment(params); err != nil {
+		var conflict *platform_configuration_security.CreateSecurityDeploymentConflict
+		if !errors.As(err, &conflict) {
+			return nil, false, err
+		}
+		created = false
+	}
+
+	dep, err = GetSecurityDeployment(getParams)
+	if err != nil {
+		return nil, created, err
+	}
+
+	return dep, created, nil
+}
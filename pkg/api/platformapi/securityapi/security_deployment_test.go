@@ -0,0 +1,350 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+	planmock "github.com/elastic/cloud-sdk-go/pkg/plan/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const securityDeploymentPath = "/api/v1/regions/us-east-1/platform/configuration/security/deployment"
+
+var (
+	securityDeployment = &models.SecurityDeployment{
+		ClusterID:      ec.String("cde7b6b605424a54ce9d56316eab13a1"),
+		DeploymentID:   mock.ValidClusterID,
+		HasPendingPlan: ec.Bool(false),
+		IsEnabled:      ec.Bool(true),
+		IsHealthy:      ec.Bool(true),
+		Name:           ec.String("security-cluster"),
+		Status:         ec.String("started"),
+		Version:        "8.6.0",
+	}
+	securityPendingPlan = planmock.Generate(planmock.GenerateConfig{
+		ID: mock.ValidClusterID,
+		Elasticsearch: []planmock.GeneratedResourceConfig{{
+			ID: "cde7b6b605424a54ce9d56316eab13a1",
+			PendingLog: planmock.NewPlanStepLog(
+				planmock.NewPlanStep("step-1", "success"),
+				planmock.NewPlanStep("step-2", "pending"),
+			),
+		}},
+	})
+	securityCurrentPlan = planmock.Generate(planmock.GenerateConfig{
+		ID: mock.ValidClusterID,
+		Elasticsearch: []planmock.GeneratedResourceConfig{{
+			ID: "cde7b6b605424a54ce9d56316eab13a1",
+			CurrentLog: planmock.NewPlanStepLog(
+				planmock.NewPlanStep("step-1", "success"),
+				planmock.NewPlanStep("plan-completed", "success"),
+			),
+		}},
+	})
+	durationRegexp      = regexp.MustCompile(`(?mi).\(.*plan duration.*`)
+	securityTrackOutput = fmt.Sprintf(
+		"Deployment [%s] - [Elasticsearch][cde7b6b605424a54ce9d56316eab13a1]: running step \"step-2\"\n\x1b[92;mDeployment [%s] - [Elasticsearch][cde7b6b605424a54ce9d56316eab13a1]: finished running all the plan steps\x1b[0m\n",
+		mock.ValidClusterID, mock.ValidClusterID,
+	)
+)
+
+func TestGetSecurityDeployment(t *testing.T) {
+	tests := []struct {
+		name   string
+		params GetSecurityDeploymentParams
+		want   *models.SecurityDeployment
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid security deployment get params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: GetSecurityDeploymentParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetSecurityDeploymentParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   securityDeploymentPath,
+					},
+					mock.NewStructBody(securityDeployment),
+				)),
+				Region: "us-east-1",
+			},
+			want: securityDeployment,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetSecurityDeployment(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateSecurityDeployment(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tests := []struct {
+		name    string
+		params  CreateSecurityDeploymentParams
+		want    string
+		wantOut string
+		err     string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: CreateSecurityDeploymentParams{
+				TrackParams: planutil.TrackParams{Track: true},
+			},
+			err: multierror.NewPrefixed("invalid security deployment create params",
+				apierror.ErrMissingAPI,
+				multierror.NewPrefixed("plan tracking", errors.New("output device cannot be nil")),
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "succeeds with the default request",
+			params: CreateSecurityDeploymentParams{
+				API: api.NewMock(mock.New201ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   securityDeploymentPath,
+						Body:   mock.NewStringBody(`{}` + "\n"),
+					},
+					mock.NewStructBody(models.IDResponse{ID: ec.String(mock.ValidClusterID)}),
+				)),
+				Region: "us-east-1",
+			},
+			want: mock.ValidClusterID,
+		},
+		{
+			name: "succeeds and tracks the change",
+			params: CreateSecurityDeploymentParams{
+				API: api.NewMock(
+					mock.New201ResponseAssertion(
+						&mock.RequestAssertion{
+							Header: api.DefaultWriteMockHeaders,
+							Host:   api.DefaultMockHost,
+							Method: "POST",
+							Path:   securityDeploymentPath,
+							Body:   mock.NewStringBody(`{"name":"security-cluster","version":"8.6.0"}` + "\n"),
+						},
+						mock.NewStructBody(models.IDResponse{ID: ec.String(mock.ValidClusterID)}),
+					),
+					mock.New200StructResponse(securityDeployment),
+					mock.New200StructResponse(securityPendingPlan),
+					mock.New200StructResponse(securityCurrentPlan),
+					mock.New200StructResponse(securityCurrentPlan),
+				),
+				Region: "us-east-1",
+				Request: &models.SecurityDeploymentCreateRequest{
+					Name:    "security-cluster",
+					Version: "8.6.0",
+				},
+				TrackParams: planutil.TrackParams{
+					Track:          true,
+					Output:         output.NewDevice(buf),
+					MaxPollRetries: 1,
+					TrackFrequency: time.Nanosecond,
+				},
+			},
+			want:    mock.ValidClusterID,
+			wantOut: securityTrackOutput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer buf.Reset()
+			got, err := CreateSecurityDeployment(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOut, durationRegexp.ReplaceAllString(buf.String(), ""))
+		})
+	}
+}
+
+func TestUpdateSecurityDeployment(t *testing.T) {
+	tests := []struct {
+		name   string
+		params UpdateSecurityDeploymentParams
+		want   string
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: UpdateSecurityDeploymentParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed("invalid security deployment update params",
+				errRequestCannotBeEmpty,
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: UpdateSecurityDeploymentParams{
+				API:     api.NewMock(mock.SampleInternalError()),
+				Region:  "us-east-1",
+				Request: &models.SecurityDeploymentUpdateRequest{Version: "8.7.0"},
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+		{
+			name: "succeeds",
+			params: UpdateSecurityDeploymentParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "PUT",
+						Path:   securityDeploymentPath,
+						Body:   mock.NewStringBody(`{"topology":{"zone_count":3},"version":"8.7.0"}` + "\n"),
+					},
+					mock.NewStructBody(models.IDResponse{ID: ec.String(mock.ValidClusterID)}),
+				)),
+				Region: "us-east-1",
+				Request: &models.SecurityDeploymentUpdateRequest{
+					Topology: &models.SecurityDeploymentTopology{ZoneCount: 3},
+					Version:  "8.7.0",
+				},
+			},
+			want: mock.ValidClusterID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UpdateSecurityDeployment(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEnsureSecurityDeployment(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      CreateSecurityDeploymentParams
+		want        *models.SecurityDeployment
+		wantCreated bool
+		err         string
+	}{
+		{
+			name: "returns the existing security deployment",
+			params: CreateSecurityDeploymentParams{
+				API:    api.NewMock(mock.New200StructResponse(securityDeployment)),
+				Region: "us-east-1",
+			},
+			want: securityDeployment,
+		},
+		{
+			name: "creates the security deployment when not found",
+			params: CreateSecurityDeploymentParams{
+				API: api.NewMock(
+					mock.SampleNotFoundError(),
+					mock.New201Response(mock.NewStructBody(models.IDResponse{
+						ID: ec.String(mock.ValidClusterID),
+					})),
+					mock.New200StructResponse(securityDeployment),
+				),
+				Region: "us-east-1",
+			},
+			want:        securityDeployment,
+			wantCreated: true,
+		},
+		{
+			name: "treats a creation conflict as existing",
+			params: CreateSecurityDeploymentParams{
+				API: api.NewMock(
+					mock.SampleNotFoundError(),
+					mock.New409Response(mock.NewStructBody(models.BasicFailedReply{
+						Errors: []*models.BasicFailedReplyElement{{
+							Code:    ec.String("security_deployment.already_exists"),
+							Message: ec.String("already exists"),
+						}},
+					})),
+					mock.New200StructResponse(securityDeployment),
+				),
+				Region: "us-east-1",
+			},
+			want: securityDeployment,
+		},
+		{
+			name: "fails when the get returns an unexpected error",
+			params: CreateSecurityDeploymentParams{
+				API:    api.NewMock(mock.SampleInternalError()),
+				Region: "us-east-1",
+			},
+			err: mock.MultierrorInternalError.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, created, err := EnsureSecurityDeployment(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantCreated, created)
+		})
+	}
+}
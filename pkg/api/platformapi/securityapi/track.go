@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package securityapi
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	"github.com/elastic/cloud-sdk-go/pkg/plan/planutil"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+)
+
+// trackSecurityDeployment tracks the security deployment pending plan. Since
+// the create and update responses don't state which kind of ID is returned,
+// the security deployment is obtained to find its deployment ID, falling back
+// to tracking the Elasticsearch resource when the deployment ID is empty.
+func trackSecurityDeployment(a *api.API, region string, track planutil.TrackParams) error {
+	if !track.Track {
+		return nil
+	}
+
+	dep, err := GetSecurityDeployment(GetSecurityDeploymentParams{
+		API: a, Region: region,
+	})
+	if err != nil {
+		return err
+	}
+
+	var params = plan.TrackChangeParams{
+		API:          a,
+		DeploymentID: dep.DeploymentID,
+	}
+
+	if params.DeploymentID == "" && dep.ClusterID != nil {
+		params.ResourceID = *dep.ClusterID
+		params.Kind = util.Elasticsearch
+	}
+
+	return track.TrackChange(params)
+}
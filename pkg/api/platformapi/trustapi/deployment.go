@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustapi
+
+import (
+	"errors"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/deputil"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var (
+	errTrustCannotBeNil = errors.New("trust settings not specified and are required for this operation")
+	errNoCurrentPlan    = errors.New("the elasticsearch resource has no current plan")
+)
+
+// GetDeploymentTrust returns the trust settings of the deployment's
+// Elasticsearch resource. When Params.RefID is empty, it's auto-discovered.
+func GetDeploymentTrust(params depresourceapi.Params) (*models.ElasticsearchClusterTrustSettings, error) {
+	params.Kind = util.Elasticsearch
+	if err := params.Validate(); err != nil {
+		return nil, multierror.NewPrefixed("deployment trust get", err)
+	}
+
+	res, err := deploymentapi.GetElasticsearch(deploymentapi.GetParams{
+		API:          params.API,
+		DeploymentID: params.DeploymentID,
+		RefID:        params.RefID,
+		QueryParams:  deputil.QueryParams{ShowSettings: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Info == nil || res.Info.Settings == nil || res.Info.Settings.Trust == nil {
+		return new(models.ElasticsearchClusterTrustSettings), nil
+	}
+
+	return res.Info.Settings.Trust, nil
+}
+
+// SetDeploymentTrustParams is consumed by SetDeploymentTrust.
+type SetDeploymentTrustParams struct {
+	depresourceapi.Params
+
+	// Required trust settings which replace the current ones.
+	Trust *models.ElasticsearchClusterTrustSettings
+}
+
+// Validate ensures the parameters are usable by SetDeploymentTrust.
+func (params *SetDeploymentTrustParams) Validate() error {
+	var merr = multierror.NewPrefixed("deployment trust set")
+	if params.Trust == nil {
+		merr = merr.Append(errTrustCannotBeNil)
+	}
+
+	params.Kind = util.Elasticsearch
+	merr = merr.Append(params.Params.Validate())
+
+	return merr.ErrorOrNil()
+}
+
+// SetDeploymentTrust replaces the trust settings of the deployment's
+// Elasticsearch resource through a deployment update request, which contains
+// the resource's current plan and settings. Any other deployment resources
+// are left untouched.
+func SetDeploymentTrust(params SetDeploymentTrustParams) (*models.DeploymentUpdateResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := deploymentapi.GetElasticsearch(deploymentapi.GetParams{
+		API:          params.API,
+		DeploymentID: params.DeploymentID,
+		RefID:        params.RefID,
+		QueryParams:  deputil.QueryParams{ShowPlans: true, ShowSettings: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Info == nil || res.Info.PlanInfo == nil ||
+		res.Info.PlanInfo.Current == nil || res.Info.PlanInfo.Current.Plan == nil {
+		return nil, multierror.NewPrefixed("deployment trust set", errNoCurrentPlan)
+	}
+
+	var settings = res.Info.Settings
+	if settings == nil {
+		settings = new(models.ElasticsearchClusterSettings)
+	}
+	// Metadata cannot be sent as part of the update request.
+	settings.Metadata = nil
+	settings.Trust = params.Trust
+
+	return deploymentapi.Update(deploymentapi.UpdateParams{
+		API:          params.API,
+		DeploymentID: params.DeploymentID,
+		Request: &models.DeploymentUpdateRequest{
+			PruneOrphans: ec.Bool(false),
+			Resources: &models.DeploymentUpdateResources{
+				Elasticsearch: []*models.ElasticsearchPayload{{
+					RefID:    ec.String(params.RefID),
+					Region:   res.Region,
+					Plan:     res.Info.PlanInfo.Current.Plan,
+					Settings: settings,
+				}},
+			},
+		},
+	})
+}
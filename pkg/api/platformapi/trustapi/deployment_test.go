@@ -0,0 +1,227 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const esResourcePath = "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed/elasticsearch/main-elasticsearch"
+
+func newESResourceInfo(trust *models.ElasticsearchClusterTrustSettings) *models.ElasticsearchResourceInfo {
+	return &models.ElasticsearchResourceInfo{
+		RefID:  ec.String("main-elasticsearch"),
+		Region: ec.String("ece-region"),
+		Info: &models.ElasticsearchClusterInfo{
+			Settings: &models.ElasticsearchClusterSettings{
+				Metadata: &models.ClusterMetadataSettings{Name: "my-deployment"},
+				Trust:    trust,
+			},
+			PlanInfo: &models.ElasticsearchClusterPlansInfo{
+				Current: &models.ElasticsearchClusterPlanInfo{
+					Plan: &models.ElasticsearchClusterPlan{
+						Elasticsearch: &models.ElasticsearchConfiguration{Version: "8.6.0"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetDeploymentTrust(t *testing.T) {
+	var trust = &models.ElasticsearchClusterTrustSettings{
+		External: []*models.ExternalTrustRelationship{{
+			TrustRelationshipID: ec.String("2"),
+			TrustAll:            ec.Bool(true),
+		}},
+	}
+	tests := []struct {
+		name   string
+		params depresourceapi.Params
+		want   *models.ElasticsearchClusterTrustSettings
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: depresourceapi.Params{
+				API:   api.NewMock(),
+				RefID: "main-elasticsearch",
+			},
+			err: multierror.NewPrefixed("deployment trust get",
+				multierror.NewPrefixed("deployment resource",
+					errors.New(`id "" is invalid`),
+				),
+			).Error(),
+		},
+		{
+			name: "returns empty settings when unset",
+			params: depresourceapi.Params{
+				API: api.NewMock(mock.New200StructResponse(
+					newESResourceInfo(nil),
+				)),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			},
+			want: new(models.ElasticsearchClusterTrustSettings),
+		},
+		{
+			name: "succeeds",
+			params: depresourceapi.Params{
+				API: api.NewMock(mock.New200StructResponse(
+					newESResourceInfo(trust),
+				)),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			},
+			want: trust,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetDeploymentTrust(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetDeploymentTrust(t *testing.T) {
+	var trust = &models.ElasticsearchClusterTrustSettings{
+		External: []*models.ExternalTrustRelationship{{
+			TrustRelationshipID: ec.String("2"),
+			TrustAll:            ec.Bool(false),
+			TrustAllowlist:      []string{"abc"},
+		}},
+	}
+	tests := []struct {
+		name   string
+		params SetDeploymentTrustParams
+		want   *models.DeploymentUpdateResponse
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: SetDeploymentTrustParams{Params: depresourceapi.Params{
+				API:          api.NewMock(),
+				DeploymentID: mock.ValidClusterID,
+				RefID:        "main-elasticsearch",
+			}},
+			err: multierror.NewPrefixed("deployment trust set",
+				errTrustCannotBeNil,
+			).Error(),
+		},
+		{
+			name: "fails when there's no current plan",
+			params: SetDeploymentTrustParams{
+				Params: depresourceapi.Params{
+					API: api.NewMock(mock.New200StructResponse(
+						models.ElasticsearchResourceInfo{Info: &models.ElasticsearchClusterInfo{}},
+					)),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Trust: trust,
+			},
+			err: multierror.NewPrefixed("deployment trust set",
+				errNoCurrentPlan,
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: SetDeploymentTrustParams{
+				Params: depresourceapi.Params{
+					API: api.NewMock(
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultReadMockHeaders,
+								Host:   api.DefaultMockHost,
+								Method: "GET",
+								Path:   esResourcePath,
+								Query: map[string][]string{
+									"show_metadata":      {"false"},
+									"show_plan_defaults": {"false"},
+									"show_plan_logs":     {"false"},
+									"show_plans":         {"true"},
+									"show_settings":      {"true"},
+									"show_system_alerts": {"5"},
+								},
+							},
+							mock.NewStructBody(newESResourceInfo(nil)),
+						),
+						mock.New200ResponseAssertion(
+							&mock.RequestAssertion{
+								Header: api.DefaultWriteMockHeaders,
+								Host:   api.DefaultMockHost,
+								Method: "PUT",
+								Path:   "/api/v1/deployments/320b7b540dfc967a7a649c18e2fce4ed",
+								Query: map[string][]string{
+									"hide_pruned_orphans": {"false"},
+									"skip_snapshot":       {"false"},
+									"validate_only":       {"false"},
+								},
+								Body: mock.NewStructBody(models.DeploymentUpdateRequest{
+									PruneOrphans: ec.Bool(false),
+									Resources: &models.DeploymentUpdateResources{
+										Elasticsearch: []*models.ElasticsearchPayload{{
+											RefID:  ec.String("main-elasticsearch"),
+											Region: ec.String("ece-region"),
+											Plan: &models.ElasticsearchClusterPlan{
+												Elasticsearch: &models.ElasticsearchConfiguration{Version: "8.6.0"},
+											},
+											Settings: &models.ElasticsearchClusterSettings{Trust: trust},
+										}},
+									},
+								}),
+							},
+							mock.NewStructBody(models.DeploymentUpdateResponse{ID: ec.String(mock.ValidClusterID)}),
+						),
+					),
+					DeploymentID: mock.ValidClusterID,
+					RefID:        "main-elasticsearch",
+				},
+				Trust: trust,
+			},
+			want: &models.DeploymentUpdateResponse{ID: ec.String(mock.ValidClusterID)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetDeploymentTrust(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
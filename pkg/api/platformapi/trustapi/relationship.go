@@ -0,0 +1,299 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/platform_configuration_trust_relationships"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var (
+	errIDCannotBeEmpty      = errors.New("id not specified and is required for this operation")
+	errRequestCannotBeEmpty = errors.New("request not specified and is required for this operation")
+)
+
+// ListParams is consumed by List.
+type ListParams struct {
+	*api.API
+
+	Region string
+
+	// Optional filter of trust relationships to return, defaults to "all".
+	Filter string
+
+	// Includes the public CA certificates in the response.
+	IncludeCertificate bool
+}
+
+// Validate ensures the parameters are usable by List.
+func (params ListParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid trust relationship list params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// List returns the platform trust relationships.
+func List(params ListParams) (*models.TrustRelationshipsListResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var p = platform_configuration_trust_relationships.NewGetTrustRelationshipsParams().
+		WithContext(api.WithRegion(context.Background(), params.Region)).
+		WithIncludeCertificate(ec.Bool(params.IncludeCertificate))
+	if params.Filter != "" {
+		p.SetFilter(ec.String(params.Filter))
+	}
+
+	res, err := params.V1API.PlatformConfigurationTrustRelationships.GetTrustRelationships(
+		p, params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// GetParams is consumed by Get.
+type GetParams struct {
+	*api.API
+
+	// Required trust relationship ID.
+	ID     string
+	Region string
+
+	// Includes the public CA certificate in the response.
+	IncludeCertificate bool
+}
+
+// Validate ensures the parameters are usable by Get.
+func (params GetParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid trust relationship get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Get returns the trust relationship matching the ID and its version, which
+// can be used for optimistic concurrency control on Delete.
+func Get(params GetParams) (*models.TrustRelationshipGetResponse, string, error) {
+	if err := params.Validate(); err != nil {
+		return nil, "", err
+	}
+
+	res, err := params.V1API.PlatformConfigurationTrustRelationships.GetTrustRelationship(
+		platform_configuration_trust_relationships.NewGetTrustRelationshipParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithTrustRelationshipID(params.ID).
+			WithIncludeCertificate(ec.Bool(params.IncludeCertificate)),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, "", apierror.Wrap(err)
+	}
+
+	return res.Payload, res.XCloudResourceVersion, nil
+}
+
+// CreateParams is consumed by Create.
+type CreateParams struct {
+	*api.API
+
+	// Required trust relationship definition.
+	Request *models.TrustRelationshipCreateRequest
+	Region  string
+}
+
+// Validate ensures the parameters are usable by Create.
+func (params CreateParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid trust relationship create params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.Request == nil {
+		merr = merr.Append(errRequestCannotBeEmpty)
+	} else {
+		merr = merr.Append(params.Request.Validate(strfmt.Default))
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Create creates a new trust relationship with a remote environment.
+func Create(params CreateParams) (*models.TrustRelationshipCreateResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformConfigurationTrustRelationships.CreateTrustRelationship(
+		platform_configuration_trust_relationships.NewCreateTrustRelationshipParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithBody(params.Request),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// UpdateParams is consumed by Update.
+type UpdateParams struct {
+	*api.API
+
+	// Required trust relationship ID.
+	ID string
+
+	// Required trust relationship changes.
+	Request *models.TrustRelationshipUpdateRequest
+	Region  string
+}
+
+// Validate ensures the parameters are usable by Update.
+func (params UpdateParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid trust relationship update params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	if params.Request == nil {
+		merr = merr.Append(errRequestCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// Update updates the trust relationship matching the ID.
+func Update(params UpdateParams) (*models.TrustRelationshipUpdateResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	res, err := params.V1API.PlatformConfigurationTrustRelationships.UpdateTrustRelationship(
+		platform_configuration_trust_relationships.NewUpdateTrustRelationshipParams().
+			WithContext(api.WithRegion(context.Background(), params.Region)).
+			WithTrustRelationshipID(params.ID).
+			WithBody(params.Request),
+		params.AuthWriter,
+	)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
+
+// DeleteParams is consumed by Delete.
+type DeleteParams struct {
+	*api.API
+
+	// Required trust relationship ID.
+	ID     string
+	Region string
+
+	// Optional version as returned by Get, when specified the deletion fails
+	// if the trust relationship has been modified since it was obtained.
+	Version string
+}
+
+// Validate ensures the parameters are usable by Delete.
+func (params DeleteParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid trust relationship delete params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	if params.ID == "" {
+		merr = merr.Append(errIDCannotBeEmpty)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(params.Region))
+
+	if _, err := versionOrNil(params.Version); err != nil {
+		merr = merr.Append(err)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Delete deletes the trust relationship matching the ID.
+func Delete(params DeleteParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	version, _ := versionOrNil(params.Version)
+	return api.ReturnErrOnly(
+		params.V1API.PlatformConfigurationTrustRelationships.DeleteTrustRelationship(
+			platform_configuration_trust_relationships.NewDeleteTrustRelationshipParams().
+				WithContext(api.WithRegion(context.Background(), params.Region)).
+				WithTrustRelationshipID(params.ID).
+				WithVersion(version),
+			params.AuthWriter,
+		),
+	)
+}
+
+// versionOrNil parses the version, returning nil when it's empty, which skips
+// the version check on the server side.
+func versionOrNil(version string) (*int64, error) {
+	if version == "" {
+		return nil, nil
+	}
+
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("version %q is not a valid number", version)
+	}
+	return &v, nil
+}
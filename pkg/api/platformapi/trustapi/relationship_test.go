@@ -0,0 +1,323 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const relationshipsPath = "/api/v1/regions/us-east-1/platform/configuration/trust-relationships"
+
+func TestList(t *testing.T) {
+	var list = &models.TrustRelationshipsListResponse{
+		TrustRelationships: []*models.TrustRelationshipGetResponse{
+			{ID: ec.String("1"), Name: ec.String("local"), Local: ec.Bool(true)},
+		},
+	}
+	tests := []struct {
+		name   string
+		params ListParams
+		want   *models.TrustRelationshipsListResponse
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid trust relationship list params",
+				apierror.ErrMissingAPI,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "succeeds with filter and certificates",
+			params: ListParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   relationshipsPath,
+						Query: map[string][]string{
+							"filter":              {"local"},
+							"include_certificate": {"true"},
+						},
+					},
+					mock.NewStructBody(list),
+				)),
+				Region:             "us-east-1",
+				Filter:             "local",
+				IncludeCertificate: true,
+			},
+			want: list,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := List(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	var relationship = &models.TrustRelationshipGetResponse{
+		ID: ec.String("1"), Name: ec.String("remote"), Local: ec.Bool(false),
+		InstallationID: "abc", TrustByDefault: ec.Bool(false),
+	}
+	tests := []struct {
+		name        string
+		params      GetParams
+		want        *models.TrustRelationshipGetResponse
+		wantVersion string
+		err         string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid trust relationship get params",
+				apierror.ErrMissingAPI,
+				errIDCannotBeEmpty,
+				errors.New("region not specified and is required for this operation"),
+			).Error(),
+		},
+		{
+			name: "fails due to API error",
+			params: GetParams{
+				API:    api.NewMock(mock.SampleNotFoundError()),
+				Region: "us-east-1",
+				ID:     "1",
+			},
+			err: mock.MultierrorNotFound.Error(),
+		},
+		{
+			name: "succeeds",
+			params: GetParams{
+				API: api.NewMock(mock.Response{
+					Response: http.Response{
+						StatusCode: 200,
+						Header:     http.Header{"X-Cloud-Resource-Version": {"3"}},
+						Body:       mock.NewStructBody(relationship),
+					},
+					Assert: &mock.RequestAssertion{
+						Header: api.DefaultReadMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "GET",
+						Path:   relationshipsPath + "/1",
+						Query:  map[string][]string{"include_certificate": {"false"}},
+					},
+				}),
+				Region: "us-east-1",
+				ID:     "1",
+			},
+			want:        relationship,
+			wantVersion: "3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, version, err := Get(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	var response = &models.TrustRelationshipCreateResponse{
+		ID: ec.String("2"), Name: ec.String("remote"), Local: ec.Bool(false),
+		TrustByDefault: ec.Bool(true), AccountIds: []string{},
+	}
+	tests := []struct {
+		name   string
+		params CreateParams
+		want   *models.TrustRelationshipCreateResponse
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: CreateParams{
+				API:     api.NewMock(),
+				Region:  "us-east-1",
+				Request: &models.TrustRelationshipCreateRequest{},
+			},
+			err: multierror.NewPrefixed("invalid trust relationship create params",
+				errors.New("validation failure list:\n"+
+					"name in body is required\n"+
+					"public_ca_cert in body is required"),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: CreateParams{
+				API: api.NewMock(mock.New201ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "POST",
+						Path:   relationshipsPath,
+						Body:   mock.NewStringBody(`{"account_ids":[],"installation_id":"abc","name":"remote","public_ca_cert":"CERT","trust_by_default":true}` + "\n"),
+					},
+					mock.NewStructBody(response),
+				)),
+				Region: "us-east-1",
+				Request: &models.TrustRelationshipCreateRequest{
+					AccountIds:     []string{},
+					InstallationID: "abc",
+					Name:           ec.String("remote"),
+					PublicCaCert:   ec.String("CERT"),
+					TrustByDefault: ec.Bool(true),
+				},
+			},
+			want: response,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Create(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var response = &models.TrustRelationshipUpdateResponse{
+		ID: ec.String("2"), Name: ec.String("remote"), Local: ec.Bool(false),
+		TrustByDefault: ec.Bool(false), AccountIds: []string{},
+	}
+	tests := []struct {
+		name   string
+		params UpdateParams
+		want   *models.TrustRelationshipUpdateResponse
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: UpdateParams{
+				API:    api.NewMock(),
+				Region: "us-east-1",
+			},
+			err: multierror.NewPrefixed("invalid trust relationship update params",
+				errIDCannotBeEmpty,
+				errRequestCannotBeEmpty,
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: UpdateParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "PUT",
+						Path:   relationshipsPath + "/2",
+						Body:   mock.NewStringBody(`{"account_ids":null,"trust_by_default":false}` + "\n"),
+					},
+					mock.NewStructBody(response),
+				)),
+				Region:  "us-east-1",
+				ID:      "2",
+				Request: &models.TrustRelationshipUpdateRequest{TrustByDefault: ec.Bool(false)},
+			},
+			want: response,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Update(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DeleteParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: DeleteParams{
+				API:     api.NewMock(),
+				Region:  "us-east-1",
+				Version: "v3",
+			},
+			err: multierror.NewPrefixed("invalid trust relationship delete params",
+				errIDCannotBeEmpty,
+				errors.New(`version "v3" is not a valid number`),
+			).Error(),
+		},
+		{
+			name: "succeeds",
+			params: DeleteParams{
+				API: api.NewMock(mock.New200ResponseAssertion(
+					&mock.RequestAssertion{
+						Header: api.DefaultWriteMockHeaders,
+						Host:   api.DefaultMockHost,
+						Method: "DELETE",
+						Path:   relationshipsPath + "/2",
+						Query:  map[string][]string{"version": {"3"}},
+					},
+					mock.NewStringBody(`{}`),
+				)),
+				Region:  "us-east-1",
+				ID:      "2",
+				Version: "3",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Delete(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustapi
+
+import (
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client/trusted_environments"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+// GetTrustedEnvironmentsParams is consumed by GetTrustedEnvironments.
+type GetTrustedEnvironmentsParams struct {
+	*api.API
+
+	// Optional organization ID, defaults to the authenticated user's
+	// organization.
+	OrganizationID string
+}
+
+// Validate ensures the parameters are usable by GetTrustedEnvironments.
+func (params GetTrustedEnvironmentsParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid trusted environments get params")
+	if params.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// GetTrustedEnvironments returns the environments trusted by the ESS
+// organization.
+func GetTrustedEnvironments(params GetTrustedEnvironmentsParams) (*models.ElasticsearchClusterTrustSettings, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var p = trusted_environments.NewGetTrustedEnvsParams()
+	if params.OrganizationID != "" {
+		p.SetOrganizationID(ec.String(params.OrganizationID))
+	}
+
+	res, err := params.V1API.TrustedEnvironments.GetTrustedEnvs(p, params.AuthWriter)
+	if err != nil {
+		return nil, apierror.Wrap(err)
+	}
+
+	return res.Payload, nil
+}
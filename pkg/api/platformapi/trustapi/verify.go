@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustapi
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/deploymentapi/depresourceapi"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var errNoLocalRelationship = errors.New("no local trust relationship found")
+
+// Environment identifies one of the sides of a trust verification.
+type Environment struct {
+	*api.API
+
+	Region string
+
+	// Optional deployment which is expected to trust the other environment.
+	DeploymentID string
+}
+
+func (env Environment) validate(name string) error {
+	var merr = multierror.NewPrefixed(name)
+	if env.API == nil {
+		merr = merr.Append(apierror.ErrMissingAPI)
+	}
+
+	merr = merr.Append(ec.RequireRegionSet(env.Region))
+
+	return merr.ErrorOrNil()
+}
+
+// VerifyParams is consumed by VerifyBidirectional.
+type VerifyParams struct {
+	Local  Environment
+	Remote Environment
+}
+
+// Validate ensures the parameters are usable by VerifyBidirectional.
+func (params VerifyParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid trust verify params")
+	merr = merr.Append(params.Local.validate("local"))
+	merr = merr.Append(params.Remote.validate("remote"))
+
+	return merr.ErrorOrNil()
+}
+
+// TrustStatus describes whether an environment trusts another one.
+type TrustStatus struct {
+	// RelationshipID is the ID of the trust relationship pointing to the
+	// other environment, empty when there's none.
+	RelationshipID string `json:"relationship_id,omitempty"`
+
+	// Trusted is true when the environment has a trust relationship with the
+	// other environment.
+	Trusted bool `json:"trusted"`
+
+	// DeploymentTrusted is true when the environment's deployment trusts
+	// the other environment, either because the trust relationship is
+	// trusted by default or through the deployment trust settings. Only set
+	// when a DeploymentID is specified for the environment.
+	DeploymentTrusted *bool `json:"deployment_trusted,omitempty"`
+}
+
+func (s TrustStatus) ok() bool {
+	return s.Trusted && (s.DeploymentTrusted == nil || *s.DeploymentTrusted)
+}
+
+// Verification is returned by VerifyBidirectional.
+type Verification struct {
+	LocalInstallationID  string `json:"local_installation_id"`
+	RemoteInstallationID string `json:"remote_installation_id"`
+
+	// LocalToRemote describes the trust of the local environment towards
+	// the remote environment.
+	LocalToRemote TrustStatus `json:"local_to_remote"`
+
+	// RemoteToLocal describes the trust of the remote environment towards
+	// the local environment.
+	RemoteToLocal TrustStatus `json:"remote_to_local"`
+}
+
+// Bidirectional returns true when both environments trust each other and
+// when specified, both deployments trust the other environment.
+func (v Verification) Bidirectional() bool {
+	return v.LocalToRemote.ok() && v.RemoteToLocal.ok()
+}
+
+// VerifyBidirectional verifies that the local and remote environments trust
+// each other by matching each environment's trust relationships against the
+// other environment's local trust relationship (by installation ID or public
+// CA certificate). When a DeploymentID is specified for an environment, its
+// trust settings are also verified.
+func VerifyBidirectional(params VerifyParams) (*Verification, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	local, err := listRelationships(params.Local)
+	if err != nil {
+		return nil, multierror.NewPrefixed("local", err)
+	}
+
+	remote, err := listRelationships(params.Remote)
+	if err != nil {
+		return nil, multierror.NewPrefixed("remote", err)
+	}
+
+	localSelf, err := findLocal(local)
+	if err != nil {
+		return nil, multierror.NewPrefixed("local", err)
+	}
+
+	remoteSelf, err := findLocal(remote)
+	if err != nil {
+		return nil, multierror.NewPrefixed("remote", err)
+	}
+
+	var v = Verification{
+		LocalInstallationID:  localSelf.InstallationID,
+		RemoteInstallationID: remoteSelf.InstallationID,
+	}
+
+	if v.LocalToRemote, err = trustStatus(params.Local, local, remoteSelf); err != nil {
+		return nil, multierror.NewPrefixed("local", err)
+	}
+
+	if v.RemoteToLocal, err = trustStatus(params.Remote, remote, localSelf); err != nil {
+		return nil, multierror.NewPrefixed("remote", err)
+	}
+
+	return &v, nil
+}
+
+func listRelationships(env Environment) ([]*models.TrustRelationshipGetResponse, error) {
+	res, err := List(ListParams{
+		API: env.API, Region: env.Region, IncludeCertificate: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.TrustRelationships, nil
+}
+
+func findLocal(relationships []*models.TrustRelationshipGetResponse) (*models.TrustRelationshipGetResponse, error) {
+	for _, r := range relationships {
+		if r != nil && r.Local != nil && *r.Local {
+			return r, nil
+		}
+	}
+
+	return nil, errNoLocalRelationship
+}
+
+// findTrusting returns the non-local trust relationship which points to the
+// other environment's local trust relationship.
+func findTrusting(relationships []*models.TrustRelationshipGetResponse, other *models.TrustRelationshipGetResponse) *models.TrustRelationshipGetResponse {
+	var otherCA = strings.TrimSpace(other.PublicCaCert)
+	for _, r := range relationships {
+		if r == nil || (r.Local != nil && *r.Local) {
+			continue
+		}
+
+		if other.InstallationID != "" && r.InstallationID == other.InstallationID {
+			return r
+		}
+
+		if otherCA != "" && strings.TrimSpace(r.PublicCaCert) == otherCA {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func trustStatus(env Environment, relationships []*models.TrustRelationshipGetResponse, other *models.TrustRelationshipGetResponse) (TrustStatus, error) {
+	var status TrustStatus
+	var relationship = findTrusting(relationships, other)
+	if relationship != nil {
+		status.Trusted = true
+		if relationship.ID != nil {
+			status.RelationshipID = *relationship.ID
+		}
+	}
+
+	if env.DeploymentID == "" {
+		return status, nil
+	}
+
+	var trusted bool
+	status.DeploymentTrusted = &trusted
+	if relationship == nil {
+		return status, nil
+	}
+
+	if relationship.TrustByDefault != nil && *relationship.TrustByDefault {
+		trusted = true
+		return status, nil
+	}
+
+	settings, err := GetDeploymentTrust(depresourceapi.Params{
+		API: env.API, DeploymentID: env.DeploymentID,
+	})
+	if err != nil {
+		return status, multierror.NewPrefixed("deployment "+env.DeploymentID, err)
+	}
+
+	for _, external := range settings.External {
+		if external == nil || external.TrustRelationshipID == nil ||
+			*external.TrustRelationshipID != status.RelationshipID {
+			continue
+		}
+		trusted = (external.TrustAll != nil && *external.TrustAll) ||
+			len(external.TrustAllowlist) > 0
+	}
+
+	return status, nil
+}
@@ -0,0 +1,191 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package trustapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api"
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+func newRelationship(id, installationID, ca string, local, byDefault bool) *models.TrustRelationshipGetResponse {
+	return &models.TrustRelationshipGetResponse{
+		ID:             ec.String(id),
+		Name:           ec.String(id),
+		InstallationID: installationID,
+		PublicCaCert:   ca,
+		Local:          ec.Bool(local),
+		TrustByDefault: ec.Bool(byDefault),
+	}
+}
+
+func newRelationshipList(relationships ...*models.TrustRelationshipGetResponse) mock.Response {
+	return mock.New200StructResponse(models.TrustRelationshipsListResponse{
+		TrustRelationships: relationships,
+	})
+}
+
+func TestVerifyBidirectional(t *testing.T) {
+	tests := []struct {
+		name              string
+		params            VerifyParams
+		want              *Verification
+		wantBidirectional bool
+		err               string
+	}{
+		{
+			name: "fails due to parameter validation",
+			params: VerifyParams{
+				Local: Environment{API: api.NewMock(), Region: "us-east-1"},
+			},
+			err: multierror.NewPrefixed("invalid trust verify params",
+				multierror.NewPrefixed("remote",
+					apierror.ErrMissingAPI,
+					errors.New("region not specified and is required for this operation"),
+				),
+			).Error(),
+		},
+		{
+			name: "fails when the remote has no local relationship",
+			params: VerifyParams{
+				Local: Environment{Region: "us-east-1", API: api.NewMock(
+					newRelationshipList(newRelationship("local", "aaa", "CA-A", true, false)),
+				)},
+				Remote: Environment{Region: "us-east-1", API: api.NewMock(
+					newRelationshipList(),
+				)},
+			},
+			err: multierror.NewPrefixed("remote", errNoLocalRelationship).Error(),
+		},
+		{
+			name: "reports one-way trust",
+			params: VerifyParams{
+				Local: Environment{Region: "us-east-1", API: api.NewMock(
+					newRelationshipList(
+						newRelationship("local", "aaa", "CA-A", true, false),
+						newRelationship("to-b", "bbb", "CA-B", false, true),
+					),
+				)},
+				Remote: Environment{Region: "us-east-1", API: api.NewMock(
+					newRelationshipList(newRelationship("local", "bbb", "CA-B", true, false)),
+				)},
+			},
+			want: &Verification{
+				LocalInstallationID:  "aaa",
+				RemoteInstallationID: "bbb",
+				LocalToRemote:        TrustStatus{RelationshipID: "to-b", Trusted: true},
+			},
+		},
+		{
+			name: "reports bidirectional trust matching by installation ID and CA",
+			params: VerifyParams{
+				Local: Environment{Region: "us-east-1", API: api.NewMock(
+					newRelationshipList(
+						newRelationship("local", "aaa", "CA-A", true, false),
+						newRelationship("to-b", "bbb", "", false, true),
+					),
+				)},
+				Remote: Environment{Region: "us-east-1", API: api.NewMock(
+					newRelationshipList(
+						newRelationship("local", "bbb", "CA-B", true, false),
+						newRelationship("to-a", "", "CA-A\n", false, false),
+					),
+				)},
+			},
+			want: &Verification{
+				LocalInstallationID:  "aaa",
+				RemoteInstallationID: "bbb",
+				LocalToRemote:        TrustStatus{RelationshipID: "to-b", Trusted: true},
+				RemoteToLocal:        TrustStatus{RelationshipID: "to-a", Trusted: true},
+			},
+			wantBidirectional: true,
+		},
+		{
+			name: "verifies the deployment trust settings",
+			params: VerifyParams{
+				Local: Environment{
+					Region:       "us-east-1",
+					DeploymentID: mock.ValidClusterID,
+					API: api.NewMock(
+						newRelationshipList(
+							newRelationship("local", "aaa", "CA-A", true, false),
+							newRelationship("to-b", "bbb", "", false, true),
+						),
+					),
+				},
+				Remote: Environment{
+					Region:       "us-east-1",
+					DeploymentID: mock.ValidClusterID,
+					API: api.NewMock(
+						newRelationshipList(
+							newRelationship("local", "bbb", "CA-B", true, false),
+							newRelationship("to-a", "aaa", "", false, false),
+						),
+						mock.New200StructResponse(models.DeploymentGetResponse{
+							Resources: &models.DeploymentResources{
+								Elasticsearch: []*models.ElasticsearchResourceInfo{{
+									RefID: ec.String("main-elasticsearch"),
+								}},
+							},
+						}),
+						mock.New200StructResponse(newESResourceInfo(
+							&models.ElasticsearchClusterTrustSettings{
+								External: []*models.ExternalTrustRelationship{{
+									TrustRelationshipID: ec.String("to-a"),
+									TrustAll:            ec.Bool(false),
+								}},
+							},
+						)),
+					),
+				},
+			},
+			want: &Verification{
+				LocalInstallationID:  "aaa",
+				RemoteInstallationID: "bbb",
+				LocalToRemote: TrustStatus{
+					RelationshipID: "to-b", Trusted: true, DeploymentTrusted: ec.Bool(true),
+				},
+				RemoteToLocal: TrustStatus{
+					RelationshipID: "to-a", Trusted: true, DeploymentTrusted: ec.Bool(false),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyBidirectional(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			if got != nil {
+				assert.Equal(t, tt.wantBidirectional, got.Bidirectional())
+			}
+		})
+	}
+}
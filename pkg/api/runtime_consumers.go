@@ -28,5 +28,6 @@ func AddTypeConsumers(rtime *client.Runtime) *client.Runtime {
 	rtime.Consumers["application/zip"] = runtime.ByteStreamConsumer()
 	rtime.Producers["application/zip"] = runtime.ByteStreamProducer()
 	rtime.Producers["multipart/form-data"] = runtime.ByteStreamProducer()
+	rtime.Producers["application/text"] = runtime.TextProducer()
 	return rtime
 }
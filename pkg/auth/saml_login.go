@@ -0,0 +1,352 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/apierror"
+	"github.com/elastic/cloud-sdk-go/pkg/client"
+	"github.com/elastic/cloud-sdk-go/pkg/client/authentication"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+const (
+	defaultSAMLTimeout = time.Minute * 5
+	samlCallbackPath   = "/saml/callback"
+)
+
+var (
+	errSAMLOpenURLCannotBeNil = errors.New("auth: saml open url function cannot be nil")
+	errSAMLNoRedirect         = errors.New("auth: saml init did not redirect to the identity provider")
+	errSAMLNoToken            = errors.New("auth: saml callback did not return a token")
+	errSAMLTimeout            = errors.New("auth: timed out waiting for the saml response")
+
+	// samlState generates the relay state which is sent to the identity
+	// provider and verified on the callback.
+	samlState = defaultSAMLState
+)
+
+func defaultSAMLState() (string, error) {
+	var b = make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// SAMLLogin uses SAML single sign-on to login against the API. Doing so
+// obtains a JWT token which is then persisted in the TokenHandler.
+//
+// The login flow initiates the SAML protocol, opening the identity provider
+// URL where the user authenticates. The identity provider then sends the SAML
+// response to a local loopback callback listener started on the
+// CallbackAddress. Last, the SAML response is exchanged for a JWT token.
+//
+// The identity provider must be configured with http://<CallbackAddress>/saml/callback
+// as an allowed assertion consumer service URL, i.e. when the CallbackAddress
+// is 127.0.0.1:8400 the URL is http://127.0.0.1:8400/saml/callback.
+type SAMLLogin struct {
+	Holder TokenHandler
+
+	// OpenURL is called with the identity provider URL where the user needs
+	// to authenticate, i.e. opening it in a browser or printing it.
+	OpenURL func(url string) error
+
+	// HTTPClient used to perform the SAML requests, which must be the same
+	// client set in the API config so its transport settings are kept.
+	// api.NewAPI sets it to the API config client when it's empty.
+	// Redirects are never followed.
+	HTTPClient *http.Client
+
+	// CallbackAddress is the loopback host and port where the callback
+	// listener is started, i.e. 127.0.0.1:8400. The port must be fixed,
+	// since it's part of the URL configured in the identity provider.
+	CallbackAddress string
+
+	// Timeout to wait for the user to authenticate, defaults to 5 minutes.
+	Timeout time.Duration
+
+	// refreshMu serializes the token refreshes.
+	refreshMu sync.Mutex
+}
+
+// NewSAMLLogin creates a SAMLLogin which calls openURL with the identity
+// provider URL. It does not automatically login against the API until Login
+// is called, and its HTTPClient and CallbackAddress need to be set before.
+func NewSAMLLogin(openURL func(url string) error) (*SAMLLogin, error) {
+	if openURL == nil {
+		return nil, errSAMLOpenURLCannotBeNil
+	}
+
+	return &SAMLLogin{
+		Holder:  new(GenericHolder),
+		OpenURL: openURL,
+	}, nil
+}
+
+// Validate ensures the validity of the data container.
+func (t *SAMLLogin) Validate() error {
+	var merr = multierror.NewPrefixed("auth")
+	if t.OpenURL == nil {
+		merr = merr.Append(errors.New("open url function cannot be nil"))
+	}
+
+	if t.HTTPClient == nil {
+		merr = merr.Append(errors.New("http client cannot be nil"))
+	}
+
+	if t.CallbackAddress == "" {
+		merr = merr.Append(errors.New("callback address cannot be empty"))
+	} else if !isLoopback(t.CallbackAddress) {
+		merr = merr.Append(errors.New("callback address must be a loopback address"))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// AuthenticateRequest authenticates a runtime.ClientRequest. Implements the
+// runtime.ClientAuthInfoWriter interface using the JWT Bearer token.
+func (t *SAMLLogin) AuthenticateRequest(c runtime.ClientRequest, r strfmt.Registry) error {
+	return httptransport.BearerToken(t.Holder.Token()).AuthenticateRequest(c, r)
+}
+
+// AuthRequest adds the Authorization header to an http.Request
+func (t *SAMLLogin) AuthRequest(req *http.Request) *http.Request {
+	req.Header.Add("Authorization", "Bearer "+t.Holder.Token())
+	return req
+}
+
+// Login performs the SAML login flow persisting the obtained token. When the
+// TokenHandler loads a persisted token which hasn't expired, it's used
+// instead, without performing the login flow.
+func (t *SAMLLogin) Login(ctx context.Context, c *client.Rest) error {
+	if c == nil {
+		return errLoginClientEmpty
+	}
+
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	if token, err := t.Holder.Load(); err == nil {
+		if exp, err := TokenExpiry(token); err == nil && time.Now().Before(exp) {
+			return nil
+		}
+	}
+
+	state, err := samlState()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", t.CallbackAddress)
+	if err != nil {
+		return fmt.Errorf("auth: failed starting the saml callback listener: %w", err)
+	}
+
+	var callback = newSAMLCallback(state)
+	var server = http.Server{Handler: callback, ReadHeaderTimeout: time.Minute}
+	go server.Serve(listener) //nolint
+	defer server.Close()
+
+	var idpURL string
+	if err := c.Authentication.SamlInit(
+		authentication.NewSamlInitParams().WithContext(ctx).WithState(ec.String(state)),
+		nil, t.captureLocation(&idpURL),
+	); err != nil {
+		return multierror.NewPrefixed("failed to initiate the saml login", apierror.Wrap(err))
+	}
+	if idpURL == "" {
+		return errSAMLNoRedirect
+	}
+
+	if err := t.OpenURL(idpURL); err != nil {
+		return err
+	}
+
+	var timeout = t.Timeout
+	if timeout == 0 {
+		timeout = defaultSAMLTimeout
+	}
+
+	var samlResponse string
+	select {
+	case samlResponse = <-callback.response:
+	case <-time.After(timeout):
+		return errSAMLTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	var redirect string
+	if err := c.Authentication.SamlCallback(
+		authentication.NewSamlCallbackParams().WithContext(ctx).
+			WithSAMLResponse(samlResponse).
+			WithRelayState(ec.String(state)),
+		nil, t.captureLocation(&redirect),
+	); err != nil {
+		return multierror.NewPrefixed("failed to exchange the saml response", apierror.Wrap(err))
+	}
+
+	token, err := redirectToken(redirect)
+	if err != nil {
+		return err
+	}
+
+	return t.Holder.Update(token)
+}
+
+// RefreshToken creates a goroutine which will run in the background refreshing
+// the token before it expires, in the same way as UserLogin.RefreshToken.
+func (t *SAMLLogin) RefreshToken(params RefreshTokenParams) error {
+	return params.refreshLoop(func() string { return t.Holder.Token() }, t.Refresh)
+}
+
+// Refresh refreshes the current JWT token. Since the SAML login flow requires
+// the user to authenticate, a token which can't be refreshed, i.e. because it
+// has already expired, requires calling Login again. Concurrent calls are
+// serialized, and calls which waited for a refresh that updated the token
+// return without refreshing it again.
+func (t *SAMLLogin) Refresh(c *client.Rest) error {
+	if c == nil {
+		return errLoginClientEmpty
+	}
+
+	var token = t.Holder.Token()
+	t.refreshMu.Lock()
+	defer t.refreshMu.Unlock()
+
+	if t.Holder.Token() != token {
+		return nil
+	}
+
+	return refreshToken(c, t, t.Holder)
+}
+
+// captureLocation returns a client option which doesn't follow redirects
+// and captures the Location header of a redirect response.
+func (t *SAMLLogin) captureLocation(location *string) authentication.ClientOption {
+	var c = *t.HTTPClient
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	return func(op *runtime.ClientOperation) {
+		op.Client = &c
+		var reader = op.Reader
+		op.Reader = runtime.ClientResponseReaderFunc(
+			func(res runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+				if res.Code() == http.StatusFound {
+					*location = res.GetHeader("Location")
+					return nil, nil
+				}
+				return reader.ReadResponse(res, consumer)
+			},
+		)
+	}
+}
+
+// redirectToken obtains the token from the fragment of the redirect URL
+// returned by the SAML callback.
+func redirectToken(redirect string) (string, error) {
+	u, err := url.Parse(redirect)
+	if err != nil {
+		return "", errSAMLNoToken
+	}
+
+	var fragment = u.Fragment
+	if i := strings.Index(fragment, "?"); i >= 0 {
+		fragment = fragment[i+1:]
+	}
+
+	values, err := url.ParseQuery(fragment)
+	if err != nil || values.Get("token") == "" {
+		return "", errSAMLNoToken
+	}
+
+	return values.Get("token"), nil
+}
+
+// isLoopback returns true when the address host is a loopback address.
+func isLoopback(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	var ip = net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// samlCallback is the http.Handler which receives the SAML response from the
+// identity provider, either as a form post or as query parameters.
+type samlCallback struct {
+	state    string
+	response chan string
+	once     sync.Once
+}
+
+func newSAMLCallback(state string) *samlCallback {
+	return &samlCallback{state: state, response: make(chan string, 1)}
+}
+
+func (h *samlCallback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != samlCallbackPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid saml response", http.StatusBadRequest)
+		return
+	}
+
+	if r.Form.Get("RelayState") != h.state {
+		http.Error(w, "invalid saml relay state", http.StatusBadRequest)
+		return
+	}
+
+	var response = r.Form.Get("SAMLResponse")
+	if response == "" {
+		http.Error(w, "missing saml response", http.StatusBadRequest)
+		return
+	}
+
+	h.once.Do(func() { h.response <- response })
+	fmt.Fprintln(w, "SAML response received, you can close this window and return to the terminal.")
+}
@@ -0,0 +1,433 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/api/mock"
+	"github.com/elastic/cloud-sdk-go/pkg/client"
+	"github.com/elastic/cloud-sdk-go/pkg/models"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/sync"
+	"github.com/elastic/cloud-sdk-go/pkg/util/ec"
+)
+
+var _ Writer = new(SAMLLogin)
+
+// loadedHolder is a TokenHandler which loads a persisted token.
+type loadedHolder struct {
+	GenericHolder
+	persisted string
+}
+
+func (h *loadedHolder) Load() (string, error) {
+	if h.persisted == "" {
+		return "", ErrNoTokenAvailable
+	}
+	return h.persisted, h.Update(h.persisted)
+}
+
+func newRedirect(location string) mock.Response {
+	return mock.Response{Response: http.Response{
+		StatusCode: 302,
+		Header:     http.Header{"Location": []string{location}},
+		Body:       mock.NewStringBody(""),
+	}}
+}
+
+// freeLoopbackAddress returns a loopback address with a free port.
+func freeLoopbackAddress(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().String()
+}
+
+// postSAMLResponse simulates the identity provider posting the SAML response
+// to the callback listener, returning the response status code.
+func postSAMLResponse(address, state, response string) (int, error) {
+	res, err := http.PostForm("http://"+address+samlCallbackPath, url.Values{
+		"RelayState":   []string{state},
+		"SAMLResponse": []string{response},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	return res.StatusCode, nil
+}
+
+func TestNewSAMLLogin(t *testing.T) {
+	got, err := NewSAMLLogin(nil)
+	assert.Equal(t, errSAMLOpenURLCannotBeNil, err)
+	assert.Nil(t, got)
+
+	got, err = NewSAMLLogin(func(string) error { return nil })
+	assert.NoError(t, err)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, new(GenericHolder), got.Holder)
+	}
+}
+
+func TestSAMLLogin_Login(t *testing.T) {
+	samlState = func() (string, error) { return "some-state", nil }
+	defer func() { samlState = defaultSAMLState }()
+
+	var validToken = newExpiringJWT(time.Hour)
+	var idpURL = "https://idp.example.com/sso?SAMLRequest=some-request"
+	var cancelled, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		login     func(address string) *SAMLLogin
+		rc        *client.Rest
+		respond   bool
+		wantToken string
+		wantURL   string
+		err       string
+	}{
+		{
+			name:  "fails due to empty client",
+			login: func(string) *SAMLLogin { return &SAMLLogin{Holder: new(GenericHolder)} },
+			err:   errLoginClientEmpty.Error(),
+		},
+		{
+			name:  "fails due to parameter validation",
+			login: func(string) *SAMLLogin { return &SAMLLogin{Holder: new(GenericHolder)} },
+			rc:    newMock(),
+			err: multierror.NewPrefixed("auth",
+				errors.New("http client cannot be nil"),
+				errors.New("callback address cannot be empty"),
+			).Error(),
+		},
+		{
+			name: "fails when the callback address isn't a loopback address",
+			login: func(string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          &loadedHolder{persisted: validToken},
+					HTTPClient:      mock.NewClient(),
+					CallbackAddress: "0.0.0.0:8400",
+				}
+			},
+			rc: newMock(),
+			err: multierror.NewPrefixed("auth",
+				errors.New("callback address must be a loopback address"),
+			).Error(),
+		},
+		{
+			name: "uses a persisted token which hasn't expired",
+			login: func(address string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          &loadedHolder{persisted: validToken},
+					HTTPClient:      mock.NewClient(),
+					CallbackAddress: address,
+				}
+			},
+			rc:        newMock(),
+			wantToken: validToken,
+		},
+		{
+			name: "fails due to saml init API error",
+			login: func(address string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          new(GenericHolder),
+					CallbackAddress: address,
+					HTTPClient: mock.NewClient(mock.Response{Response: http.Response{
+						Body:       mock.NewStructBody(failedReply),
+						StatusCode: 502,
+					}}),
+				}
+			},
+			rc: newMock(),
+			err: multierror.NewPrefixed("failed to initiate the saml login",
+				errors.New("api error: code: message"),
+			).Error(),
+		},
+		{
+			name: "fails when saml init doesn't redirect",
+			login: func(address string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          new(GenericHolder),
+					CallbackAddress: address,
+					HTTPClient:      mock.NewClient(newRedirect("")),
+				}
+			},
+			rc:  newMock(),
+			err: errSAMLNoRedirect.Error(),
+		},
+		{
+			name: "fails when the saml response isn't received on time",
+			login: func(address string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          new(GenericHolder),
+					CallbackAddress: address,
+					HTTPClient:      mock.NewClient(newRedirect(idpURL)),
+					Timeout:         time.Millisecond * 10,
+				}
+			},
+			rc:      newMock(),
+			wantURL: idpURL,
+			err:     errSAMLTimeout.Error(),
+		},
+		{
+			name: "fails when the context is cancelled",
+			ctx:  cancelled,
+			login: func(address string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          new(GenericHolder),
+					CallbackAddress: address,
+					HTTPClient:      mock.NewClient(newRedirect(idpURL)),
+				}
+			},
+			rc:      newMock(),
+			wantURL: idpURL,
+			err:     context.Canceled.Error(),
+		},
+		{
+			name: "fails when the saml callback doesn't return a token",
+			login: func(address string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          new(GenericHolder),
+					CallbackAddress: address,
+					HTTPClient: mock.NewClient(
+						newRedirect(idpURL),
+						newRedirect("https://ece.example.com/#/login?error=unauthorized"),
+					),
+				}
+			},
+			rc:      newMock(),
+			respond: true,
+			wantURL: idpURL,
+			err:     errSAMLNoToken.Error(),
+		},
+		{
+			name: "logs in exchanging the saml response for a token",
+			login: func(address string) *SAMLLogin {
+				return &SAMLLogin{
+					Holder:          &loadedHolder{},
+					CallbackAddress: address,
+					HTTPClient: mock.NewClient(
+						newRedirect(idpURL),
+						newRedirect("https://ece.example.com/#/login?token="+validToken+"&state=some-state"),
+					),
+				}
+			},
+			rc:        newMock(),
+			respond:   true,
+			wantURL:   idpURL,
+			wantToken: validToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var address = freeLoopbackAddress(t)
+			var login = tt.login(address)
+
+			var gotURL string
+			login.OpenURL = func(u string) error {
+				gotURL = u
+				if !tt.respond {
+					return nil
+				}
+
+				// Responses with an invalid relay state are rejected.
+				code, err := postSAMLResponse(address, "other-state", "some-response")
+				if err != nil {
+					return err
+				}
+				assert.Equal(t, http.StatusBadRequest, code)
+
+				code, err = postSAMLResponse(address, "some-state", "some-response")
+				if err != nil {
+					return err
+				}
+				assert.Equal(t, http.StatusOK, code)
+				return nil
+			}
+
+			var ctx = tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			err := login.Login(ctx, tt.rc)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantURL, gotURL)
+			assert.Equal(t, tt.wantToken, login.Holder.Token())
+		})
+	}
+}
+
+func TestSAMLLogin_AuthRequest(t *testing.T) {
+	var login = &SAMLLogin{Holder: &GenericHolder{token: "some"}}
+	var req = login.AuthRequest(&http.Request{Header: make(http.Header)})
+	assert.Equal(t, http.Header{"Authorization": []string{"Bearer some"}}, req.Header)
+}
+
+func TestSAMLLogin_Refresh(t *testing.T) {
+	tests := []struct {
+		name      string
+		rc        *client.Rest
+		wantToken string
+		err       string
+	}{
+		{
+			name:      "fails due to empty client",
+			wantToken: "old",
+			err:       errLoginClientEmpty.Error(),
+		},
+		{
+			name: "refreshes the token",
+			rc: newMock(mock.Response{Response: http.Response{
+				Body:       mock.NewStructBody(models.TokenResponse{Token: ec.String("refreshed")}),
+				StatusCode: 200,
+			}}),
+			wantToken: "refreshed",
+		},
+		{
+			name: "fails when the token can't be refreshed",
+			rc: newMock(mock.Response{Response: http.Response{
+				Body:       mock.NewStructBody(failedReply),
+				StatusCode: 401,
+			}}),
+			wantToken: "old",
+			err: multierror.NewPrefixed("failed to refresh the loaded token",
+				errors.New("api error: code: message"),
+			).Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var login = &SAMLLogin{Holder: &GenericHolder{token: "old"}}
+			err := login.Refresh(tt.rc)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantToken, login.Holder.Token())
+		})
+	}
+}
+
+func TestSAMLLogin_RefreshToken(t *testing.T) {
+	var login = &SAMLLogin{Holder: &GenericHolder{
+		token: newExpiringJWT(2 * time.Second),
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var errDevice = sync.NewBuffer()
+	err := login.RefreshToken(RefreshTokenParams{
+		Context:       ctx,
+		Frequency:     time.Hour,
+		RefreshBefore: time.Hour,
+		ErrorDevice:   errDevice,
+		Client: newMock(mock.Response{Response: http.Response{
+			Body:       mock.NewStructBody(models.TokenResponse{Token: ec.String("refreshed")}),
+			StatusCode: 200,
+		}}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var deadline = time.After(5 * time.Second)
+	for login.Holder.Token() != "refreshed" {
+		select {
+		case <-deadline:
+			t.Fatal("SAMLLogin.RefreshToken() didn't refresh the token before its expiry")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+
+	assert.EqualError(t, login.RefreshToken(RefreshTokenParams{}), multierror.NewPrefixed("auth",
+		errors.New("errorDevice cannot be nil"),
+		errors.New("rest client cannot be nil"),
+	).Error())
+}
+
+func TestRedirectToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		redirect string
+		want     string
+		err      error
+	}{
+		{
+			name:     "fails on an invalid URL",
+			redirect: "://",
+			err:      errSAMLNoToken,
+		},
+		{
+			name:     "fails when the fragment has no token",
+			redirect: "https://ece.example.com/#/login",
+			err:      errSAMLNoToken,
+		},
+		{
+			name:     "returns the token from a fragment route",
+			redirect: "https://ece.example.com/#/login?token=some-token&state=some",
+			want:     "some-token",
+		},
+		{
+			name:     "returns the token from the fragment",
+			redirect: "https://ece.example.com/#token=some-token",
+			want:     "some-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := redirectToken(tt.redirect)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	for address, want := range map[string]bool{
+		"127.0.0.1:0":    true,
+		"localhost:8080": true,
+		"[::1]:8080":     true,
+		"0.0.0.0:0":      false,
+		"10.0.0.1:8080":  false,
+		"127.0.0.1":      false,
+	} {
+		if got := isLoopback(address); got != want {
+			t.Errorf("isLoopback(%s) = %v, want %v", address, got, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package store
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound must be returned by the Backend implementations when a key
+// doesn't exist.
+var ErrNotFound = errors.New("store: key not found")
+
+// Backend is the interface which abstracts the secret storage where the
+// profiles, credentials and tokens are persisted. Implementations must be
+// safe for concurrent use.
+type Backend interface {
+	// Get returns the value of the key or ErrNotFound.
+	Get(key string) ([]byte, error)
+
+	// Set creates or replaces the value of the key.
+	Set(key string, value []byte) error
+
+	// Delete removes the key, returning ErrNotFound when it doesn't exist.
+	Delete(key string) error
+
+	// Keys returns the sorted list of keys which start with the prefix.
+	Keys(prefix string) ([]string, error)
+}
+
+// MemoryBackend is a Backend which keeps the values in memory, useful for
+// testing or when the values don't need to be persisted.
+type MemoryBackend struct {
+	mu     sync.RWMutex
+	values map[string][]byte
+}
+
+// NewMemoryBackend initializes a new MemoryBackend.
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{values: make(map[string][]byte)}
+}
+
+// Get returns the value of the key or ErrNotFound.
+func (b *MemoryBackend) Get(key string) ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	value, ok := b.values[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return append([]byte(nil), value...), nil
+}
+
+// Set creates or replaces the value of the key.
+func (b *MemoryBackend) Set(key string, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// Delete removes the key, returning ErrNotFound when it doesn't exist.
+func (b *MemoryBackend) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.values[key]; !ok {
+		return ErrNotFound
+	}
+
+	delete(b.values, key)
+	return nil
+}
+
+// Keys returns the sorted list of keys which start with the prefix.
+func (b *MemoryBackend) Keys(prefix string) ([]string, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return keysWithPrefix(b.values, prefix), nil
+}
+
+// keysWithPrefix returns the sorted keys of the map which start with prefix.
+func keysWithPrefix(values map[string][]byte, prefix string) []string {
+	var keys = make([]string, 0, len(values))
+	for k := range values {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
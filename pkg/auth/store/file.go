@@ -0,0 +1,440 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package store
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/pbkdf2"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+const (
+	fileVersion = 1
+	fileKDF     = "pbkdf2-sha256"
+	keyLength   = 32
+	saltLength  = 16
+
+	// maxKDFIterations is the upper bound of the iterations read from the
+	// file, which prevents a tampered file from stalling the key derivation.
+	maxKDFIterations = 10000000
+)
+
+var (
+	// ErrDecrypt is returned when the file backend contents can't be
+	// decrypted, which usually means that the passphrase or key file don't
+	// match the ones which were used to encrypt it.
+	ErrDecrypt = errors.New("store: failed to decrypt the file, invalid passphrase or key file")
+
+	// kdfIterations is the number of PBKDF2 iterations used to derive the
+	// encryption key when the file is written.
+	kdfIterations = 600000
+
+	// fileLockTimeout is the time to wait for the lock file to be released
+	// by another process.
+	fileLockTimeout = 10 * time.Second
+
+	// fileLockStale is the age after which a lock file is considered to be
+	// left behind by a process which exited without releasing it.
+	fileLockStale = time.Minute
+
+	fileLockRetry = 10 * time.Millisecond
+
+	errFilePathCannotBeEmpty   = errors.New("path must not be empty")
+	errFileSecretCannotBeEmpty = errors.New("one of passphrase or key file must be specified")
+	errFileSecretBothSet       = errors.New("only one of passphrase or key file can be specified")
+)
+
+// FileParams is used to configure a FileBackend.
+type FileParams struct {
+	// Path of the encrypted file, it is created when it doesn't exist.
+	Path string
+
+	// Passphrase used to derive the encryption key.
+	Passphrase string
+
+	// KeyFile whose contents are used to derive the encryption key, it can
+	// be created with GenerateKeyFile.
+	KeyFile string
+}
+
+// Validate ensures the parameters are usable by NewFileBackend.
+func (params FileParams) Validate() error {
+	var merr = multierror.NewPrefixed("invalid file backend params")
+	if params.Path == "" {
+		merr = merr.Append(errFilePathCannotBeEmpty)
+	}
+
+	if params.Passphrase == "" && params.KeyFile == "" {
+		merr = merr.Append(errFileSecretCannotBeEmpty)
+	}
+
+	if params.Passphrase != "" && params.KeyFile != "" {
+		merr = merr.Append(errFileSecretBothSet)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// FileBackend is a Backend which persists the values in a single file which
+// is encrypted at rest with AES-256-GCM, using a key derived from either a
+// passphrase or a key file with PBKDF2-HMAC-SHA256. The file is rewritten on
+// every change with a new nonce.
+//
+// Concurrent access from multiple processes is serialized with an advisory
+// lock file next to the file, <path>.lock, which is held while the file is
+// read and written.
+type FileBackend struct {
+	path   string
+	secret []byte
+
+	mu sync.Mutex
+	// salt, iterations and key are cached to avoid deriving the key on each
+	// operation.
+	salt       []byte
+	iterations int
+	key        []byte
+}
+
+// fileEnvelope is the on-disk format of the FileBackend.
+type fileEnvelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Data       []byte `json:"data"`
+}
+
+// NewFileBackend initializes a new FileBackend from a set of parameters. When
+// the file already exists it's decrypted to verify the passphrase or key file.
+func NewFileBackend(params FileParams) (*FileBackend, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var secret = []byte(params.Passphrase)
+	if params.KeyFile != "" {
+		contents, err := os.ReadFile(params.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("store: failed reading key file: %w", err)
+		}
+
+		secret = bytes.TrimSpace(contents)
+		if len(secret) == 0 {
+			return nil, fmt.Errorf("store: key file %s is empty", params.KeyFile)
+		}
+	}
+
+	var backend = FileBackend{path: params.Path, secret: secret}
+	unlock, err := backend.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if _, err := backend.load(); err != nil {
+		return nil, err
+	}
+
+	return &backend, nil
+}
+
+// GenerateKeyFile creates a new key file with a random hex encoded key which
+// can be used as the FileParams.KeyFile. It fails if the file already exists.
+func GenerateKeyFile(path string) error {
+	var key = make([]byte, keyLength)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Get returns the value of the key or ErrNotFound.
+func (b *FileBackend) Get(key string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	unlock, err := b.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	values, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return value, nil
+}
+
+// Set creates or replaces the value of the key.
+func (b *FileBackend) Set(key string, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	unlock, err := b.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	values, err := b.load()
+	if err != nil {
+		return err
+	}
+
+	values[key] = value
+	return b.save(values)
+}
+
+// Delete removes the key, returning ErrNotFound when it doesn't exist.
+func (b *FileBackend) Delete(key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	unlock, err := b.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	values, err := b.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := values[key]; !ok {
+		return ErrNotFound
+	}
+
+	delete(values, key)
+	return b.save(values)
+}
+
+// Keys returns the sorted list of keys which start with the prefix.
+func (b *FileBackend) Keys(prefix string) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	unlock, err := b.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	values, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return keysWithPrefix(values, prefix), nil
+}
+
+// lock acquires the lock file, waiting up to fileLockTimeout for it to be
+// released by another process. Lock files older than fileLockStale are
+// removed. The returned function releases the lock.
+func (b *FileBackend) lock() (func(), error) {
+	var path = b.path + ".lock"
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("store: failed creating directory: %w", err)
+	}
+
+	var deadline = time.Now().Add(fileLockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("store: failed creating lock file: %w", err)
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > fileLockStale {
+			os.Remove(path)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("store: timed out waiting for the lock file %s", path)
+		}
+		time.Sleep(fileLockRetry)
+	}
+}
+
+// load reads and decrypts the file, returning an empty set of values when
+// the file doesn't exist.
+func (b *FileBackend) load() (map[string][]byte, error) {
+	var values = make(map[string][]byte)
+	contents, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("store: failed reading file: %w", err)
+	}
+
+	var envelope fileEnvelope
+	if err := json.Unmarshal(contents, &envelope); err != nil {
+		return nil, fmt.Errorf("store: failed decoding file %s: %w", b.path, err)
+	}
+
+	if envelope.Version != fileVersion || envelope.KDF != fileKDF {
+		return nil, fmt.Errorf("store: unsupported file version %d with kdf %s",
+			envelope.Version, envelope.KDF,
+		)
+	}
+
+	if envelope.Iterations <= 0 || envelope.Iterations > maxKDFIterations {
+		return nil, fmt.Errorf("store: invalid kdf iterations %d in file %s",
+			envelope.Iterations, b.path,
+		)
+	}
+
+	if !bytes.Equal(b.salt, envelope.Salt) || b.iterations != envelope.Iterations {
+		b.salt = envelope.Salt
+		b.iterations = envelope.Iterations
+		b.key = pbkdf2.Key(b.secret, envelope.Salt, envelope.Iterations, keyLength, sha256.New)
+	}
+
+	aead, err := newAEAD(b.key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := aead.Open(nil, envelope.Nonce, envelope.Data, nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("store: failed decoding file %s: %w", b.path, err)
+	}
+
+	return values, nil
+}
+
+// save encrypts the values and atomically replaces the file contents.
+func (b *FileBackend) save(values map[string][]byte) error {
+	if b.key == nil {
+		b.salt = make([]byte, saltLength)
+		if _, err := rand.Read(b.salt); err != nil {
+			return err
+		}
+		b.iterations = kdfIterations
+		b.key = pbkdf2.Key(b.secret, b.salt, b.iterations, keyLength, sha256.New)
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(b.key)
+	if err != nil {
+		return err
+	}
+
+	var nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(fileEnvelope{
+		Version:    fileVersion,
+		KDF:        fileKDF,
+		Iterations: b.iterations,
+		Salt:       b.salt,
+		Nonce:      nonce,
+		Data:       aead.Seal(nil, nonce, data, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(b.path, contents)
+}
+
+// writeFileAtomic writes the contents to a temporary file which then replaces
+// the file, so the file is never left partially written.
+func writeFileAtomic(path string, contents []byte) error {
+	var dir = filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("store: failed creating directory: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("store: failed writing file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(contents); err != nil {
+		f.Close()
+		return fmt.Errorf("store: failed writing file: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("store: failed writing file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("store: failed writing file: %w", err)
+	}
+
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
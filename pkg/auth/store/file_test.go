@@ -0,0 +1,296 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func init() {
+	// Lowers the key derivation cost to keep the tests fast.
+	kdfIterations = 1000
+}
+
+func TestNewFileBackend(t *testing.T) {
+	var dir = t.TempDir()
+	var emptyKeyFile = filepath.Join(dir, "empty.key")
+	if err := os.WriteFile(emptyKeyFile, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var existing = filepath.Join(dir, "existing.json")
+	b, err := NewFileBackend(FileParams{Path: existing, Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Set("key", []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		params FileParams
+		err    string
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: multierror.NewPrefixed("invalid file backend params",
+				errFilePathCannotBeEmpty,
+				errFileSecretCannotBeEmpty,
+			).Error(),
+		},
+		{
+			name: "fails when both passphrase and key file are set",
+			params: FileParams{
+				Path:       existing,
+				Passphrase: "secret",
+				KeyFile:    emptyKeyFile,
+			},
+			err: multierror.NewPrefixed("invalid file backend params",
+				errFileSecretBothSet,
+			).Error(),
+		},
+		{
+			name:   "fails when the key file doesn't exist",
+			params: FileParams{Path: existing, KeyFile: filepath.Join(dir, "missing.key")},
+			err:    "store: failed reading key file: open " + filepath.Join(dir, "missing.key") + ": no such file or directory",
+		},
+		{
+			name:   "fails when the key file is empty",
+			params: FileParams{Path: existing, KeyFile: emptyKeyFile},
+			err:    "store: key file " + emptyKeyFile + " is empty",
+		},
+		{
+			name:   "fails when the passphrase doesn't match",
+			params: FileParams{Path: existing, Passphrase: "wrong"},
+			err:    ErrDecrypt.Error(),
+		},
+		{
+			name:   "succeeds with an existing file",
+			params: FileParams{Path: existing, Passphrase: "secret"},
+		},
+		{
+			name:   "succeeds with a new file",
+			params: FileParams{Path: filepath.Join(dir, "new.json"), Passphrase: "secret"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFileBackend(tt.params)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
+
+func TestFileBackend(t *testing.T) {
+	var dir = t.TempDir()
+	var keyFile = filepath.Join(dir, "store.key")
+	if err := GenerateKeyFile(keyFile); err != nil {
+		t.Fatal(err)
+	}
+	assert.Error(t, GenerateKeyFile(keyFile), "overwrites an existing key file")
+
+	var path = filepath.Join(dir, "nested", "store.json")
+	b, err := NewFileBackend(FileParams{Path: path, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b.Get("profiles/a")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, ErrNotFound, b.Delete("profiles/a"))
+
+	assert.NoError(t, b.Set("profiles/b", []byte("some-b")))
+	assert.NoError(t, b.Set("profiles/a", []byte("some-a")))
+	assert.NoError(t, b.Set("tokens/a", []byte("some-token")))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, strings.Contains(string(contents), "some-token"), "stores the values in plain text")
+
+	// A new backend with the same key file reads the values.
+	reopened, err := NewFileBackend(FileParams{Path: path, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := reopened.Get("tokens/a")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("some-token"), got)
+
+	keys, err := reopened.Keys("profiles/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"profiles/a", "profiles/b"}, keys)
+
+	assert.NoError(t, reopened.Delete("profiles/a"))
+	keys, err = b.Keys("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"profiles/b", "tokens/a"}, keys)
+
+	// A different key file can't decrypt the values.
+	var otherKeyFile = filepath.Join(dir, "other.key")
+	if err := GenerateKeyFile(otherKeyFile); err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewFileBackend(FileParams{Path: path, KeyFile: otherKeyFile})
+	assert.True(t, errors.Is(err, ErrDecrypt))
+}
+
+func TestFileBackendIterations(t *testing.T) {
+	var dir = t.TempDir()
+	var path = filepath.Join(dir, "store.json")
+	b, err := NewFileBackend(FileParams{Path: path, Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, b.Set("key", []byte("value")))
+
+	var readEnvelope = func() fileEnvelope {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var envelope fileEnvelope
+		if err := json.Unmarshal(contents, &envelope); err != nil {
+			t.Fatal(err)
+		}
+		return envelope
+	}
+
+	// A file written with a different number of iterations keeps them when
+	// it's rewritten.
+	var original = kdfIterations
+	kdfIterations = original * 2
+	defer func() { kdfIterations = original }()
+
+	b, err = NewFileBackend(FileParams{Path: path, Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, b.Set("other", []byte("other-value")))
+	assert.Equal(t, original, readEnvelope().Iterations)
+
+	reopened, err := NewFileBackend(FileParams{Path: path, Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reopened.Get("key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), got)
+
+	for _, iterations := range []int{0, -1, maxKDFIterations + 1} {
+		var envelope = readEnvelope()
+		envelope.Iterations = iterations
+		contents, err := json.Marshal(envelope)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var invalid = filepath.Join(dir, "invalid.json")
+		if err := os.WriteFile(invalid, contents, 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := NewFileBackend(FileParams{Path: invalid, Passphrase: "secret"})
+		assert.EqualError(t, err, fmt.Sprintf(
+			"store: invalid kdf iterations %d in file %s", iterations, invalid,
+		))
+		assert.Nil(t, got)
+	}
+}
+
+func TestFileBackendLock(t *testing.T) {
+	var dir = t.TempDir()
+	var path = filepath.Join(dir, "store.json")
+	var lockPath = path + ".lock"
+
+	var original = fileLockTimeout
+	fileLockTimeout = 50 * time.Millisecond
+	defer func() { fileLockTimeout = original }()
+
+	b, err := NewFileBackend(FileParams{Path: path, Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewFileBackend(FileParams{Path: path, Passphrase: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Backends for the same file don't lose each other's changes.
+	var wg sync.WaitGroup
+	for i, backend := range []*FileBackend{b, other} {
+		wg.Add(1)
+		go func(i int, backend *FileBackend) {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				assert.NoError(t, backend.Set(fmt.Sprintf("key-%d-%d", i, j), []byte("value")))
+			}
+		}(i, backend)
+	}
+	wg.Wait()
+
+	keys, err := b.Keys("key-")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 10)
+
+	_, err = os.Stat(lockPath)
+	assert.True(t, os.IsNotExist(err), "leaves the lock file behind")
+
+	// A lock file held by another process times out.
+	if err := os.WriteFile(lockPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.Get("key-0-0")
+	assert.EqualError(t, err, fmt.Sprintf("store: timed out waiting for the lock file %s", lockPath))
+
+	// A stale lock file is removed.
+	var stale = time.Now().Add(-2 * fileLockStale)
+	if err := os.Chtimes(lockPath, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	got, err := b.Get("key-0-0")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), got)
+}
@@ -0,0 +1,278 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/elastic/cloud-sdk-go/pkg/auth"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+// AuthType is the authentication mechanism used by a profile.
+type AuthType string
+
+const (
+	// APIKeyAuth authenticates using an API key.
+	APIKeyAuth AuthType = "apikey"
+	// UserLoginAuth authenticates using a username and password, caching the
+	// obtained JWT token.
+	UserLoginAuth AuthType = "user_login"
+)
+
+const (
+	profilePrefix     = "profiles/"
+	credentialsPrefix = "credentials/"
+	tokenPrefix       = "tokens/"
+)
+
+var (
+	// ErrProfileNotFound is returned when a profile doesn't exist.
+	ErrProfileNotFound = errors.New("store: profile not found")
+
+	errBackendCannotBeNil = errors.New("store: backend cannot be nil")
+)
+
+// Profile is a named set of settings used to connect to an API endpoint.
+type Profile struct {
+	// Name which identifies the profile.
+	Name string `json:"name"`
+
+	// Host of the API endpoint.
+	Host string `json:"host"`
+
+	// Region is the optional default region for the profile.
+	Region string `json:"region,omitempty"`
+
+	// AuthType used to authenticate against the endpoint.
+	AuthType AuthType `json:"auth_type"`
+
+	// Username used when AuthType is UserLoginAuth.
+	Username string `json:"username,omitempty"`
+}
+
+// Credentials are the secrets used to authenticate a profile.
+type Credentials struct {
+	APIKey   string `json:"api_key,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// Validate ensures that the profile can be saved with the credentials.
+func (p Profile) Validate(c Credentials) error {
+	var merr = multierror.NewPrefixed("invalid profile")
+	if p.Name == "" {
+		merr = merr.Append(errors.New("name must not be empty"))
+	}
+
+	if strings.Contains(p.Name, "/") {
+		merr = merr.Append(errors.New("name must not contain /"))
+	}
+
+	if p.Host == "" {
+		merr = merr.Append(errors.New("host must not be empty"))
+	}
+
+	switch p.AuthType {
+	case APIKeyAuth:
+		if c.APIKey == "" {
+			merr = merr.Append(errors.New("api key must not be empty"))
+		}
+	case UserLoginAuth:
+		if p.Username == "" {
+			merr = merr.Append(errors.New("username must not be empty"))
+		}
+		if c.Password == "" {
+			merr = merr.Append(errors.New("password must not be empty"))
+		}
+	default:
+		merr = merr.Append(fmt.Errorf("auth type \"%s\" is not supported", p.AuthType))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Store manages the profiles, credentials and cached tokens persisted in a
+// Backend.
+type Store struct {
+	backend Backend
+}
+
+// NewStore initializes a new Store which persists in the backend.
+func NewStore(backend Backend) (*Store, error) {
+	if backend == nil {
+		return nil, errBackendCannotBeNil
+	}
+
+	return &Store{backend: backend}, nil
+}
+
+// Save creates or replaces a profile and its credentials. Any cached token
+// of an existing profile is removed since it may no longer be valid.
+func (s *Store) Save(p Profile, c Credentials) error {
+	if err := p.Validate(c); err != nil {
+		return err
+	}
+
+	if err := s.set(profilePrefix+p.Name, p); err != nil {
+		return err
+	}
+
+	if err := s.set(credentialsPrefix+p.Name, c); err != nil {
+		return err
+	}
+
+	return s.delete(tokenPrefix + p.Name)
+}
+
+// Get returns the named profile or ErrProfileNotFound.
+func (s *Store) Get(name string) (*Profile, error) {
+	var p Profile
+	if err := s.get(profilePrefix+name, &p); err != nil {
+		return nil, profileError(name, err)
+	}
+
+	return &p, nil
+}
+
+// List returns all of the profiles sorted by name.
+func (s *Store) List() ([]Profile, error) {
+	keys, err := s.backend.Keys(profilePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles = make([]Profile, 0, len(keys))
+	for _, key := range keys {
+		var p Profile
+		if err := s.get(key, &p); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles, nil
+}
+
+// Credentials returns the credentials of the named profile.
+func (s *Store) Credentials(name string) (*Credentials, error) {
+	var c Credentials
+	if err := s.get(credentialsPrefix+name, &c); err != nil {
+		return nil, profileError(name, err)
+	}
+
+	return &c, nil
+}
+
+// Delete removes the named profile along with its credentials and cached
+// token.
+func (s *Store) Delete(name string) error {
+	if err := s.backend.Delete(profilePrefix + name); err != nil {
+		return profileError(name, err)
+	}
+
+	if err := s.delete(credentialsPrefix + name); err != nil {
+		return err
+	}
+
+	return s.delete(tokenPrefix + name)
+}
+
+// TokenHolder returns an auth.TokenHandler which persists the JWT token of
+// the named profile, so it can be reused between runs.
+func (s *Store) TokenHolder(name string) *TokenHolder {
+	return &TokenHolder{backend: s.backend, key: tokenPrefix + name}
+}
+
+// AuthWriter returns the auth.Writer of the named profile. For UserLoginAuth
+// profiles, the returned *auth.UserLogin persists its token in the store and
+// is loaded with the cached token when there's one.
+func (s *Store) AuthWriter(name string) (auth.Writer, error) {
+	p, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.Credentials(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.AuthType == APIKeyAuth {
+		return auth.NewAPIKey(c.APIKey)
+	}
+
+	login, err := auth.NewUserLogin(p.Username, c.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var holder = s.TokenHolder(name)
+	if _, err := holder.Load(); err != nil && !errors.Is(err, auth.ErrNoTokenAvailable) {
+		return nil, err
+	}
+	login.Holder = holder
+
+	return login, nil
+}
+
+func (s *Store) get(key string, v interface{}) error {
+	b, err := s.backend.Get(key)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("store: failed decoding %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *Store) set(key string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return s.backend.Set(key, b)
+}
+
+// delete removes the key, ignoring it when it doesn't exist.
+func (s *Store) delete(key string) error {
+	if err := s.backend.Delete(key); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// profileError returns an error which wraps ErrProfileNotFound when the
+// backend key isn't found.
+func profileError(name string, err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	return err
+}
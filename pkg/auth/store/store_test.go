@@ -0,0 +1,198 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/auth"
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+)
+
+func TestNewStore(t *testing.T) {
+	got, err := NewStore(nil)
+	assert.Equal(t, errBackendCannotBeNil, err)
+	assert.Nil(t, got)
+
+	got, err = NewStore(NewMemoryBackend())
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+}
+
+func TestStoreSave(t *testing.T) {
+	tests := []struct {
+		name        string
+		profile     Profile
+		credentials Credentials
+		err         string
+	}{
+		{
+			name: "fails due to profile validation",
+			profile: Profile{
+				Name:     "some/profile",
+				AuthType: "token",
+			},
+			err: multierror.NewPrefixed("invalid profile",
+				errors.New("name must not contain /"),
+				errors.New("host must not be empty"),
+				errors.New(`auth type "token" is not supported`),
+			).Error(),
+		},
+		{
+			name:    "fails due to missing api key",
+			profile: Profile{Name: "some", Host: "https://some", AuthType: APIKeyAuth},
+			err: multierror.NewPrefixed("invalid profile",
+				errors.New("api key must not be empty"),
+			).Error(),
+		},
+		{
+			name:    "fails due to missing username and password",
+			profile: Profile{Name: "some", Host: "https://some", AuthType: UserLoginAuth},
+			err: multierror.NewPrefixed("invalid profile",
+				errors.New("username must not be empty"),
+				errors.New("password must not be empty"),
+			).Error(),
+		},
+		{
+			name:        "succeeds",
+			profile:     Profile{Name: "some", Host: "https://some", AuthType: APIKeyAuth},
+			credentials: Credentials{APIKey: "some-key"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := NewStore(NewMemoryBackend())
+			err := s.Save(tt.profile, tt.credentials)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+			got, err := s.Get(tt.profile.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, &tt.profile, got)
+
+			creds, err := s.Credentials(tt.profile.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, &tt.credentials, creds)
+		})
+	}
+}
+
+func TestStoreProfiles(t *testing.T) {
+	var backend = NewMemoryBackend()
+	s, _ := NewStore(backend)
+
+	var ece = Profile{
+		Name:     "ece",
+		Host:     "https://ece.example.com:12443",
+		Region:   "ece-region",
+		AuthType: UserLoginAuth,
+		Username: "admin",
+	}
+	var ess = Profile{
+		Name:     "ess",
+		Host:     "https://api.elastic-cloud.com",
+		AuthType: APIKeyAuth,
+	}
+	assert.NoError(t, s.Save(ess, Credentials{APIKey: "some-key"}))
+	assert.NoError(t, s.Save(ece, Credentials{Password: "some-password"}))
+	assert.NoError(t, s.TokenHolder("ece").Update("some-token"))
+
+	got, err := s.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []Profile{ece, ess}, got)
+
+	_, err = s.Get("missing")
+	assert.EqualError(t, err, "store: profile not found: missing")
+	assert.True(t, errors.Is(err, ErrProfileNotFound))
+
+	assert.NoError(t, s.Delete("ece"))
+	assert.True(t, errors.Is(s.Delete("ece"), ErrProfileNotFound))
+
+	keys, err := backend.Keys("")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"credentials/ess", "profiles/ess"}, keys)
+}
+
+func TestStoreAuthWriter(t *testing.T) {
+	s, _ := NewStore(NewMemoryBackend())
+	assert.NoError(t, s.Save(
+		Profile{Name: "ess", Host: "https://ess", AuthType: APIKeyAuth},
+		Credentials{APIKey: "some-key"},
+	))
+	assert.NoError(t, s.Save(
+		Profile{Name: "ece", Host: "https://ece", AuthType: UserLoginAuth, Username: "admin"},
+		Credentials{Password: "some-password"},
+	))
+	assert.NoError(t, s.Save(
+		Profile{Name: "new", Host: "https://new", AuthType: UserLoginAuth, Username: "admin"},
+		Credentials{Password: "some-password"},
+	))
+	assert.NoError(t, s.TokenHolder("ece").Update("some-token"))
+
+	_, err := s.AuthWriter("missing")
+	assert.True(t, errors.Is(err, ErrProfileNotFound))
+
+	got, err := s.AuthWriter("ess")
+	assert.NoError(t, err)
+	var key = auth.APIKey("some-key")
+	assert.Equal(t, &key, got)
+
+	got, err = s.AuthWriter("ece")
+	assert.NoError(t, err)
+	if assert.IsType(t, &auth.UserLogin{}, got) {
+		var login = got.(*auth.UserLogin)
+		assert.Equal(t, "admin", login.Username)
+		assert.Equal(t, "some-password", login.Password)
+		assert.Equal(t, "some-token", login.Holder.Token())
+	}
+
+	got, err = s.AuthWriter("new")
+	assert.NoError(t, err)
+	if assert.IsType(t, &auth.UserLogin{}, got) {
+		var holder = got.(*auth.UserLogin).Holder
+		assert.Equal(t, "", holder.Token())
+
+		// Updating the token persists it for the next runs.
+		assert.NoError(t, holder.Update("new-token"))
+		token, err := s.TokenHolder("new").Load()
+		assert.NoError(t, err)
+		assert.Equal(t, "new-token", token)
+	}
+}
+
+func TestTokenHolder(t *testing.T) {
+	var backend = NewMemoryBackend()
+	var holder auth.TokenHandler = &TokenHolder{backend: backend, key: "tokens/some"}
+
+	_, err := holder.Load()
+	assert.Equal(t, auth.ErrNoTokenAvailable, err)
+	assert.Equal(t, "", holder.Token())
+
+	assert.NoError(t, holder.Update("some-token"))
+	assert.Equal(t, "some-token", holder.Token())
+
+	got, err := backend.Get("tokens/some")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("some-token"), got)
+}
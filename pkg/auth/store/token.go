@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package store
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/elastic/cloud-sdk-go/pkg/auth"
+)
+
+// TokenHolder is an implementation of auth.TokenHandler which persists the
+// token of a profile in the store backend.
+type TokenHolder struct {
+	backend Backend
+	key     string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// Load returns the persisted token, or auth.ErrNoTokenAvailable when there's
+// none. The loaded token becomes the current token.
+func (t *TokenHolder) Load() (string, error) {
+	b, err := t.backend.Get(t.key)
+	if errors.Is(err, ErrNotFound) || (err == nil && len(b) == 0) {
+		return "", auth.ErrNoTokenAvailable
+	}
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = string(b)
+
+	return t.token, nil
+}
+
+// Update replaces the token with a new one and persists it.
+func (t *TokenHolder) Update(s string) error {
+	if err := t.backend.Set(t.key, []byte(s)); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = s
+
+	return nil
+}
+
+// Token returns current token.
+func (t *TokenHolder) Token() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.token
+}
@@ -0,0 +1,58 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	errTokenMalformed = errors.New("auth: token is not a valid JWT")
+	errTokenNoExpiry  = errors.New("auth: token has no expiry")
+)
+
+// TokenExpiry returns the expiry time of a JWT token from its "exp" claim.
+// The token signature is not verified.
+func TokenExpiry(token string) (time.Time, error) {
+	var parts = strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errTokenMalformed
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, errTokenMalformed
+	}
+
+	var claims struct {
+		Exp *float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, errTokenMalformed
+	}
+
+	if claims.Exp == nil {
+		return time.Time{}, errTokenNoExpiry
+	}
+
+	return time.Unix(int64(*claims.Exp), 0), nil
+}
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newJWT returns an unsigned JWT token with the specified payload.
+func newJWT(payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
+
+func TestTokenExpiry(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  time.Time
+		err   error
+	}{
+		{
+			name:  "fails on a token which isn't a JWT",
+			token: "sometoken",
+			err:   errTokenMalformed,
+		},
+		{
+			name:  "fails on an invalid payload encoding",
+			token: "header.!!!.signature",
+			err:   errTokenMalformed,
+		},
+		{
+			name:  "fails on an invalid payload",
+			token: newJWT(`not json`),
+			err:   errTokenMalformed,
+		},
+		{
+			name:  "fails on a token without expiry",
+			token: newJWT(`{"sub":"admin"}`),
+			err:   errTokenNoExpiry,
+		},
+		{
+			name:  "returns the token expiry",
+			token: newJWT(`{"sub":"admin","exp":1767225600}`),
+			want:  time.Unix(1767225600, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TokenExpiry(tt.token)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -18,12 +18,14 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -41,6 +43,7 @@ import (
 
 var (
 	defaultRefreshTickerTime = time.Minute * 1
+	defaultRefreshBefore     = time.Minute * 2
 	errLoginClientEmpty      = errors.New("auth: login client cannot be empty")
 )
 
@@ -52,6 +55,9 @@ var (
 type UserLogin struct {
 	Username, Password string
 	Holder             TokenHandler
+
+	// refreshMu serializes the token refreshes.
+	refreshMu sync.Mutex
 }
 
 // NewUserLogin creates a UserLogin from a username and password. It does not
@@ -84,9 +90,24 @@ func (t *UserLogin) Validate() error {
 // RefreshTokenParams is used to refresh a bearer token, which is necessary
 // before its validity expires.
 type RefreshTokenParams struct {
-	Client           *client.Rest
-	Frequency        time.Duration
-	ErrorDevice      io.Writer
+	Client *client.Rest
+
+	// Frequency at which the token is refreshed when its expiry can't be
+	// obtained from the token, also used to retry failed refreshes.
+	Frequency time.Duration
+
+	// RefreshBefore is the time before the token expiry when the token is
+	// refreshed. Defaults to 2 minutes.
+	RefreshBefore time.Duration
+
+	ErrorDevice io.Writer
+
+	// Context stops refreshing the token when it's cancelled. When set, the
+	// InterruptChannel isn't used and no signal handlers are installed.
+	Context context.Context
+
+	// InterruptChannel stops refreshing the token on Interrupt and SIGTERM
+	// signals when no Context is set.
 	InterruptChannel chan os.Signal
 }
 
@@ -102,6 +123,14 @@ func (params *RefreshTokenParams) Validate() error {
 		merr = merr.Append(errors.New("rest client cannot be nil"))
 	}
 
+	if params.Frequency < 0 {
+		merr = merr.Append(errors.New("frequency cannot be negative"))
+	}
+
+	if params.RefreshBefore < 0 {
+		merr = merr.Append(errors.New("refresh before cannot be negative"))
+	}
+
 	return merr.ErrorOrNil()
 }
 
@@ -110,6 +139,35 @@ func (params *RefreshTokenParams) fillValues() {
 	if params.Frequency.Nanoseconds() == 0 {
 		params.Frequency = defaultRefreshTickerTime
 	}
+
+	if params.RefreshBefore.Nanoseconds() == 0 {
+		params.RefreshBefore = defaultRefreshBefore
+	}
+}
+
+// nextRefresh returns the time to wait until the token is refreshed. When
+// the token expiry is known, the token is refreshed RefreshBefore its expiry
+// or halfway through its remaining validity, whichever comes last. Otherwise,
+// or when the last refresh failed, the Frequency is used.
+func (params *RefreshTokenParams) nextRefresh(token string, refreshed, failed bool) time.Duration {
+	exp, err := TokenExpiry(token)
+	if err != nil || failed {
+		return params.Frequency
+	}
+
+	var remaining = time.Until(exp)
+	if remaining <= 0 {
+		// Avoids refreshing continuously when a fresh token is expired.
+		if refreshed {
+			return params.Frequency
+		}
+		return 0
+	}
+
+	if next := remaining - params.RefreshBefore; next > remaining/2 {
+		return next
+	}
+	return remaining / 2
 }
 
 // Login calls the authentication/login endpoint with a username and password
@@ -146,29 +204,50 @@ func (t *UserLogin) AuthRequest(req *http.Request) *http.Request {
 }
 
 // RefreshToken creates a goroutine which will run in the background refreshing
-// the token every Frequency. It does not refresh the token until the first
-// period has passed.
+// the token before it expires, as obtained from the JWT "exp" claim. When the
+// token expiry is unknown, the token is refreshed every Frequency, not
+// refreshing it until the first period has passed. Refresh errors are sent to
+// the ErrorDevice and retried after Frequency. The goroutine stops when the
+// Context is cancelled or, if no Context is set, on interrupt signals.
 func (t *UserLogin) RefreshToken(params RefreshTokenParams) error {
+	return params.refreshLoop(func() string { return t.Holder.Token() }, t.Refresh)
+}
+
+// refreshLoop validates the parameters and starts the goroutine which calls
+// refresh when the token obtained from token needs to be refreshed.
+func (params RefreshTokenParams) refreshLoop(token func() string, refresh func(*client.Rest) error) error {
 	if err := params.Validate(); err != nil {
 		return err
 	}
 
-	if params.InterruptChannel == nil {
-		params.InterruptChannel = make(chan os.Signal, 1)
+	var ctx = params.Context
+	if ctx == nil {
+		if params.InterruptChannel == nil {
+			params.InterruptChannel = make(chan os.Signal, 1)
+		}
+		signal.Notify(params.InterruptChannel, os.Interrupt, syscall.SIGTERM)
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(context.Background())
+		go func() {
+			<-params.InterruptChannel
+			cancel()
+		}()
 	}
-	signal.Notify(params.InterruptChannel, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
-		ticker := time.NewTicker(params.Frequency)
-		defer ticker.Stop()
+		var refreshed, failed bool
 		for {
+			timer := time.NewTimer(params.nextRefresh(token(), refreshed, failed))
 			select {
-			case <-ticker.C:
-				if err := t.RefreshTokenOnce(params.Client); err != nil {
+			case <-timer.C:
+				refreshed, failed = true, false
+				if err := refresh(params.Client); err != nil {
 					fmt.Fprintln(params.ErrorDevice, err)
-					continue
+					failed = true
 				}
-			case <-params.InterruptChannel:
+			case <-ctx.Done():
+				timer.Stop()
 				return
 			}
 		}
@@ -177,18 +256,61 @@ func (t *UserLogin) RefreshToken(params RefreshTokenParams) error {
 	return nil
 }
 
-// RefreshTokenOnce refreshRefreshTokenOncees the current JWT token once.
+// Refresh refreshes the current JWT token. If the token can't be refreshed,
+// i.e. because it has already expired, it logs in again with the username
+// and password when they're set. Concurrent calls are serialized, and calls
+// which waited for a refresh that updated the token return without
+// refreshing it again.
+func (t *UserLogin) Refresh(c *client.Rest) error {
+	if c == nil {
+		return errLoginClientEmpty
+	}
+
+	var token = t.Holder.Token()
+	t.refreshMu.Lock()
+	defer t.refreshMu.Unlock()
+
+	if t.Holder.Token() != token {
+		return nil
+	}
+
+	err := t.refreshTokenOnce(c)
+	if err == nil || t.Username == "" || t.Password == "" {
+		return err
+	}
+
+	if lerr := t.Login(c); lerr != nil {
+		return multierror.NewPrefixed("auth", err, lerr)
+	}
+
+	return nil
+}
+
+// RefreshTokenOnce refreshes the current JWT token once.
 func (t *UserLogin) RefreshTokenOnce(c *client.Rest) error {
 	if c == nil {
 		return errLoginClientEmpty
 	}
 
+	t.refreshMu.Lock()
+	defer t.refreshMu.Unlock()
+
+	return t.refreshTokenOnce(c)
+}
+
+func (t *UserLogin) refreshTokenOnce(c *client.Rest) error {
+	return refreshToken(c, t, t.Holder)
+}
+
+// refreshToken exchanges the current token for a new one, authenticating the
+// request with the writer and persisting the new token in the holder.
+func refreshToken(c *client.Rest, writer runtime.ClientAuthInfoWriter, holder TokenHandler) error {
 	res, err := c.Authentication.RefreshToken(
-		authentication.NewRefreshTokenParams(), t,
+		authentication.NewRefreshTokenParams(), writer,
 	)
 	if err != nil {
 		return multierror.NewPrefixed("failed to refresh the loaded token", apierror.Wrap(err))
 	}
 
-	return t.Holder.Update(*res.Payload.Token)
+	return holder.Update(*res.Payload.Token)
 }
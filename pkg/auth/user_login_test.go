@@ -18,10 +18,13 @@
 package auth
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"reflect"
+	gosync "sync"
 	"testing"
 	"time"
 
@@ -312,6 +315,19 @@ func TestUserLogin_RefreshToken(t *testing.T) {
 				errors.New("rest client cannot be nil"),
 			),
 		},
+		{
+			name: "returns an error on negative durations",
+			args: args{params: RefreshTokenParams{
+				Frequency:     -time.Second,
+				RefreshBefore: -time.Minute,
+				ErrorDevice:   sync.NewBuffer(),
+				Client:        newMock(),
+			}},
+			err: multierror.NewPrefixed("auth",
+				errors.New("frequency cannot be negative"),
+				errors.New("refresh before cannot be negative"),
+			),
+		},
 		{
 			name: "Refresh token",
 			fields: fields{
@@ -399,3 +415,204 @@ func TestUserLogin_RefreshToken(t *testing.T) {
 		})
 	}
 }
+
+// newExpiringJWT returns an unsigned JWT token which expires in d.
+func newExpiringJWT(d time.Duration) string {
+	return newJWT(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(d).Unix()))
+}
+
+func TestRefreshTokenParams_nextRefresh(t *testing.T) {
+	var params = RefreshTokenParams{
+		Frequency:     time.Minute,
+		RefreshBefore: 2 * time.Minute,
+	}
+	tests := []struct {
+		name      string
+		token     string
+		refreshed bool
+		failed    bool
+		min, max  time.Duration
+	}{
+		{
+			name:  "uses the frequency when the token has no expiry",
+			token: "sometoken",
+			min:   time.Minute,
+			max:   time.Minute,
+		},
+		{
+			name:   "uses the frequency when the last refresh failed",
+			token:  newExpiringJWT(time.Hour),
+			failed: true,
+			min:    time.Minute,
+			max:    time.Minute,
+		},
+		{
+			name:  "refreshes before the token expires",
+			token: newExpiringJWT(time.Hour),
+			min:   57 * time.Minute,
+			max:   58 * time.Minute,
+		},
+		{
+			name:  "refreshes halfway through a short lived token",
+			token: newExpiringJWT(3 * time.Minute),
+			min:   89 * time.Second,
+			max:   90 * time.Second,
+		},
+		{
+			name:  "refreshes an expired token immediately",
+			token: newExpiringJWT(-time.Minute),
+		},
+		{
+			name:      "uses the frequency when a refreshed token is expired",
+			token:     newExpiringJWT(-time.Minute),
+			refreshed: true,
+			min:       time.Minute,
+			max:       time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := params.nextRefresh(tt.token, tt.refreshed, tt.failed)
+			if got < tt.min || got > tt.max {
+				t.Errorf("RefreshTokenParams.nextRefresh() = %v, want between %v and %v", got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestUserLogin_Refresh(t *testing.T) {
+	var tokenResponse = func(token string) mock.Response {
+		return mock.Response{Response: http.Response{
+			Body:       mock.NewStructBody(models.TokenResponse{Token: ec.String(token)}),
+			StatusCode: 200,
+		}}
+	}
+	var failedResponse = func() mock.Response {
+		return mock.Response{Response: http.Response{
+			Body:       mock.NewStructBody(failedReply),
+			StatusCode: 401,
+		}}
+	}
+	tests := []struct {
+		name      string
+		login     *UserLogin
+		rc        *client.Rest
+		wantToken string
+		err       string
+	}{
+		{
+			name:      "fails due to empty client",
+			login:     &UserLogin{Holder: &GenericHolder{token: "old"}},
+			wantToken: "old",
+			err:       "auth: login client cannot be empty",
+		},
+		{
+			name:      "refreshes the token",
+			login:     &UserLogin{Username: "some", Password: "pass", Holder: &GenericHolder{token: "old"}},
+			rc:        newMock(tokenResponse("refreshed")),
+			wantToken: "refreshed",
+		},
+		{
+			name:      "logs in again when the token can't be refreshed",
+			login:     &UserLogin{Username: "some", Password: "pass", Holder: &GenericHolder{token: "old"}},
+			rc:        newMock(failedResponse(), tokenResponse("logged in")),
+			wantToken: "logged in",
+		},
+		{
+			name:      "fails without username and password to login again",
+			login:     &UserLogin{Holder: &GenericHolder{token: "old"}},
+			rc:        newMock(failedResponse()),
+			wantToken: "old",
+			err:       "failed to refresh the loaded token: 1 error occurred:\n\t* api error: code: message\n\n",
+		},
+		{
+			name:      "fails when it can't login again",
+			login:     &UserLogin{Username: "some", Password: "pass", Holder: &GenericHolder{token: "old"}},
+			rc:        newMock(failedResponse(), failedResponse()),
+			wantToken: "old",
+			err: multierror.NewPrefixed("auth",
+				multierror.NewPrefixed("failed to refresh the loaded token", errors.New("api error: code: message")),
+				multierror.NewPrefixed("failed to login with user/password", errors.New("api error: code: message")),
+			).Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.login.Refresh(tt.rc)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantToken, tt.login.Holder.Token())
+		})
+	}
+}
+
+func TestUserLogin_RefreshConcurrent(t *testing.T) {
+	var login = &UserLogin{Holder: &GenericHolder{token: "old"}}
+	// No responses are set, since the waiting refreshes must not call the API.
+	var rc = newMock()
+
+	login.refreshMu.Lock()
+	var wg gosync.WaitGroup
+	var errs = make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- login.Refresh(rc)
+		}()
+	}
+
+	// Simulates an in-flight refresh which updates the token.
+	<-time.After(time.Millisecond * 50)
+	if err := login.Holder.Update("refreshed"); err != nil {
+		t.Fatal(err)
+	}
+	login.refreshMu.Unlock()
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, "refreshed", login.Holder.Token())
+}
+
+func TestUserLogin_RefreshTokenContext(t *testing.T) {
+	var refreshBefore = time.Hour
+	var login = &UserLogin{Holder: &GenericHolder{
+		token: newExpiringJWT(2 * time.Second),
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var errDevice = sync.NewBuffer()
+	err := login.RefreshToken(RefreshTokenParams{
+		Context:       ctx,
+		Frequency:     time.Hour,
+		RefreshBefore: refreshBefore,
+		ErrorDevice:   errDevice,
+		Client: newMock(mock.Response{Response: http.Response{
+			Body:       mock.NewStructBody(models.TokenResponse{Token: ec.String("refreshed")}),
+			StatusCode: 200,
+		}}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The token is refreshed halfway through its remaining validity, since
+	// it expires before RefreshBefore.
+	var deadline = time.After(5 * time.Second)
+	for login.Holder.Token() != "refreshed" {
+		select {
+		case <-deadline:
+			t.Fatal("UserLogin.RefreshToken() didn't refresh the token before its expiry")
+		case <-time.After(time.Millisecond * 10):
+		}
+	}
+	cancel()
+
+	assert.Equal(t, "", errDevice.String())
+}
@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planutil
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+)
+
+// TrackParams can be embedded in any structure which triggers a plan change,
+// allowing the change to be optionally tracked until it has finished.
+type TrackParams struct {
+	// Track the resulting plan change until it finishes.
+	Track bool
+
+	// Output device where the plan progress will be sent. Required when
+	// Track is set.
+	Output *output.Device
+
+	// OutputFormat to use, either "text" or "json".
+	OutputFormat string
+
+	// Maximum number of errors to allow the plan status poller to tolerate.
+	// Defaults to util.DefaultRetries.
+	MaxPollRetries uint8
+
+	// Poll frequency. Defaults to util.DefaultPollFrequency.
+	TrackFrequency time.Duration
+}
+
+// Validate ensures the parameters are usable by the consuming function.
+func (params TrackParams) Validate() error {
+	if !params.Track {
+		return nil
+	}
+
+	var merr = multierror.NewPrefixed("plan tracking")
+	if params.Output == nil {
+		merr = merr.Append(errors.New("output device cannot be nil"))
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// Config returns the tracking frequency configuration with the defaults
+// applied to the unset values.
+func (params TrackParams) Config() plan.TrackFrequencyConfig {
+	var retries = int(params.MaxPollRetries)
+	if retries == 0 {
+		retries = util.DefaultRetries
+	}
+
+	var frequency = params.TrackFrequency
+	if frequency.Nanoseconds() == 0 {
+		frequency = util.DefaultPollFrequency
+	}
+
+	return plan.TrackFrequencyConfig{
+		PollFrequency: frequency,
+		MaxRetries:    retries,
+	}
+}
+
+// TrackChange tracks the plan change identified by the parameters when Track
+// is set, otherwise it's a no-op. The tracking configuration of the parameters
+// is replaced with Config.
+func (params TrackParams) TrackChange(change plan.TrackChangeParams) error {
+	if !params.Track {
+		return nil
+	}
+
+	change.Config = params.Config()
+	return TrackChange(TrackChangeParams{
+		TrackChangeParams: change,
+		Writer:            params.Output,
+		Format:            params.OutputFormat,
+	})
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package planutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/cloud-sdk-go/pkg/multierror"
+	"github.com/elastic/cloud-sdk-go/pkg/plan"
+	"github.com/elastic/cloud-sdk-go/pkg/util"
+)
+
+func TestTrackParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params TrackParams
+		err    error
+	}{
+		{
+			name: "succeeds when tracking is disabled",
+		},
+		{
+			name:   "fails when tracking without an output device",
+			params: TrackParams{Track: true},
+			err: multierror.NewPrefixed("plan tracking",
+				errors.New("output device cannot be nil"),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err.Error())
+		})
+	}
+}
+
+func TestTrackParamsConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		params TrackParams
+		want   plan.TrackFrequencyConfig
+	}{
+		{
+			name: "returns the defaults",
+			want: plan.TrackFrequencyConfig{
+				PollFrequency: util.DefaultPollFrequency,
+				MaxRetries:    util.DefaultRetries,
+			},
+		},
+		{
+			name:   "returns the specified values",
+			params: TrackParams{MaxPollRetries: 5, TrackFrequency: time.Second},
+			want: plan.TrackFrequencyConfig{
+				PollFrequency: time.Second,
+				MaxRetries:    5,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.params.Config())
+		})
+	}
+}
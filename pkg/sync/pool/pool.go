@@ -79,6 +79,9 @@ var (
 	ErrCannotWaitOnStoppedPool = errors.New("pool: cannot wait for workers to finish on a stopped pool")
 	// ErrCannotGetLeftovers is returned when the pool is not in a stopped state.
 	ErrCannotGetLeftovers = errors.New("pool: cannot get the work leftovers on a non stopped pool")
+	// ErrWorkLeftover is the progress error of the work items which are sent
+	// to the leftovers when the pool is stopped.
+	ErrWorkLeftover = errors.New("pool: work not completed before the pool was stopped")
 
 	failFastSetStopMsg = `pool: fail fast is set and received an error, stopping pool...`
 )
@@ -136,6 +139,9 @@ type Pool struct {
 	// failFast can be set to stop all the pool when any of the workers returns
 	// with an error.
 	failFast bool
+
+	// progress tracks the progress of the work items.
+	progress *Progress
 }
 
 // Signals contains all of the channels that are used to trigger different
@@ -231,6 +237,11 @@ func NewPool(params Params) (*Pool, error) {
 	// the max leftover size should be the buffer + the number of workers.
 	var leftoverBuffer = queueBuffer + params.Size
 
+	var progress = params.Progress
+	if progress == nil {
+		progress = NewProgress()
+	}
+
 	var pool = Pool{
 		size:      params.Size,
 		run:       params.Run,
@@ -251,6 +262,7 @@ func NewPool(params Params) (*Pool, error) {
 		errors:   make(chan error),
 		writer:   params.Writer,
 		failFast: params.FailFast,
+		progress: progress,
 	}
 
 	go pool.monitor(nil)
@@ -291,6 +303,7 @@ func (p *Pool) Start() error {
 			Leftovers:   p.leftovers,
 			Run:         p.run,
 			StopTimeout: p.timeouts.Stop,
+			Progress:    p.progress,
 		})
 	}
 
@@ -401,6 +414,10 @@ func (p *Pool) Wait() error {
 	}
 }
 
+// Progress returns the Progress of the pool, which can be used to subscribe
+// to the work item events or to obtain its metrics.
+func (p *Pool) Progress() *Progress { return p.progress }
+
 // Result returns the results from the work that was done by the workers,
 // namely returns any error in the multierror format.
 func (p *Pool) Result() error {
@@ -459,7 +476,7 @@ func (p *Pool) Stop() error {
 	})
 
 	close(p.queue)
-	drain(p.queue, p.leftovers)
+	drain(p.queue, p.leftovers, p.progress)
 
 	if err != nil && err == ErrStopOperationTimedOut {
 		p.setStatus(StoppedTimeout)
@@ -475,10 +492,11 @@ func (p *Pool) Stop() error {
 
 // drain dumps the items from the first queue to the second queue, it assumes
 // that the channel is already closed, since this function is only useful for
-// buffered queues.
-func drain(from <-chan Validator, to chan<- Validator) {
+// buffered queues. The drained items are recorded as failed in the progress.
+func drain(from <-chan Validator, to chan<- Validator, progress *Progress) {
 	for w := range from {
 		to <- w
+		progress.record(ItemFailed, w, ErrWorkLeftover)
 	}
 }
 
@@ -510,12 +528,16 @@ func (p *Pool) Add(work ...Validator) ([]Validator, error) {
 	var err error
 	var leftover []Validator
 	for _, w := range work {
+		// The item is recorded before it's sent to the queue, otherwise a
+		// worker could start processing it before it's recorded as queued.
+		p.progress.record(ItemQueued, w, nil)
 		select {
 		case p.queue <- w:
 			p.signals.Added <- struct{}{}
 		case <-time.After(p.timeouts.Add):
 			err = ErrAddOperationTimedOut
 			leftover = append(leftover, w)
+			p.progress.record(ItemFailed, w, err)
 		}
 	}
 
@@ -19,6 +19,7 @@ package pool_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -210,3 +211,29 @@ func ExamplePool_failfast() {
 	l, err := p.Leftovers()
 	fmt.Fprintln(output, "leftovers:", len(l), "leftovers errors:", err)
 }
+
+// This example shows how to create a new TypedPool and obtain the results of
+// the processed items.
+func ExampleTypedPool() {
+	p, err := pool.NewTypedPool(pool.TypedParams[string, int]{
+		Size:    2,
+		Retries: 1,
+		Run: func(ctx context.Context, s string) (int, error) {
+			if s == "" {
+				return 0, errors.New("empty string")
+			}
+			return len(s), nil
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, r := range p.Run(context.Background(), "hello", "", "pool") {
+		fmt.Printf("%q: value=%d attempts=%d err=%v\n", r.Item, r.Value, r.Attempts, r.Err)
+	}
+	// Output:
+	// "hello": value=5 attempts=1 err=<nil>
+	// "": value=0 attempts=2 err=empty string
+	// "pool": value=4 attempts=1 err=<nil>
+}
@@ -64,6 +64,10 @@ type Params struct {
 	// FailFast can be set to stop all the pool when any of the workers returns
 	// with an error.
 	FailFast bool
+
+	// Progress can be set to track the progress of the work items, when nil
+	// a new Progress is created, which can be obtained through Pool.Progress.
+	Progress *Progress
 }
 
 // Timeout is an object that encloses different Pool operation timeouts.
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pool
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType is the type of a progress Event.
+type EventType string
+
+const (
+	// ItemQueued is sent when an item is added to the pool.
+	ItemQueued EventType = "queued"
+	// ItemStarted is sent when a worker starts processing an item.
+	ItemStarted EventType = "started"
+	// ItemFinished is sent when an item is processed successfully.
+	ItemFinished EventType = "finished"
+	// ItemFailed is sent when an item is processed with an error, or it's
+	// not processed at all.
+	ItemFailed EventType = "failed"
+)
+
+// Event is a change in the progress of a pool's work item.
+type Event struct {
+	Type EventType
+	Item interface{}
+	Err  error
+	Time time.Time
+}
+
+// Metrics is a snapshot of a pool's progress.
+type Metrics struct {
+	// Number of items which have been queued.
+	Queued uint32
+	// Number of items which are being processed.
+	Processing uint32
+	// Number of items which have been processed successfully.
+	Done uint32
+	// Number of items which have failed.
+	Failed uint32
+
+	// Elapsed time since the first item was queued.
+	Elapsed time.Duration
+	// Throughput in completed items per second.
+	Throughput float64
+	// ETA is the estimated time to complete the remaining items based on
+	// the throughput, 0 when it can't be estimated yet.
+	ETA time.Duration
+}
+
+// Completed returns the number of items which have either finished or failed.
+func (m Metrics) Completed() uint32 { return m.Done + m.Failed }
+
+// Remaining returns the number of items which haven't completed.
+func (m Metrics) Remaining() uint32 { return m.Queued - m.Completed() }
+
+// Progress tracks the progress of a pool, keeping counters of the items
+// and sending events to its subscribers. It's safe for concurrent use and a
+// nil Progress discards any progress.
+type Progress struct {
+	mu          sync.Mutex
+	now         func() time.Time
+	start       time.Time
+	metrics     Metrics
+	subscribers map[chan Event]struct{}
+}
+
+// NewProgress initializes a new Progress.
+func NewProgress() *Progress {
+	return &Progress{
+		now:         time.Now,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe returns a channel where the progress events are sent, along with
+// a function to unsubscribe, which closes the channel. Events are sent with
+// a best effort approach, if the channel buffer is full the event is dropped
+// so a slow subscriber never blocks the pool workers. The channel returned by
+// a nil Progress is already closed.
+func (p *Progress) Subscribe(buffer int) (<-chan Event, func()) {
+	var c = make(chan Event, buffer)
+	if p == nil {
+		close(c)
+		return c, func() {}
+	}
+
+	p.mu.Lock()
+	p.subscribers[c] = struct{}{}
+	p.mu.Unlock()
+
+	var once sync.Once
+	return c, func() {
+		once.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			delete(p.subscribers, c)
+			close(c)
+		})
+	}
+}
+
+// Metrics returns a snapshot of the progress.
+func (p *Progress) Metrics() Metrics {
+	if p == nil {
+		return Metrics{}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var m = p.metrics
+	if p.start.IsZero() {
+		return m
+	}
+
+	m.Elapsed = p.now().Sub(p.start)
+	if m.Elapsed > 0 && m.Completed() > 0 {
+		m.Throughput = float64(m.Completed()) / m.Elapsed.Seconds()
+		m.ETA = time.Duration(float64(m.Remaining()) / m.Throughput * float64(time.Second))
+	}
+
+	return m
+}
+
+// record updates the counters for an event of an item which isn't being
+// processed and sends it to the subscribers.
+func (p *Progress) record(t EventType, item interface{}, err error) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var now = p.now()
+	switch t {
+	case ItemQueued:
+		if p.start.IsZero() {
+			p.start = now
+		}
+		p.metrics.Queued++
+	case ItemStarted:
+		p.metrics.Processing++
+	case ItemFailed:
+		p.metrics.Failed++
+	}
+
+	p.publish(Event{Type: t, Item: item, Err: err, Time: now})
+}
+
+// recordResult records an item which was being processed either as finished
+// or failed.
+func (p *Progress) recordResult(item interface{}, err error) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var t = ItemFinished
+	p.metrics.Processing--
+	if err != nil {
+		t = ItemFailed
+		p.metrics.Failed++
+	} else {
+		p.metrics.Done++
+	}
+
+	p.publish(Event{Type: t, Item: item, Err: err, Time: p.now()})
+}
+
+// publish sends the event to the subscribers without blocking. It must be
+// called with the lock held.
+func (p *Progress) publish(event Event) {
+	for c := range p.subscribers {
+		select {
+		case c <- event:
+		default:
+		}
+	}
+}
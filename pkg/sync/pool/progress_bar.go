@@ -0,0 +1,187 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pool
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultProgressBarWidth is the default number of characters of the bar.
+	DefaultProgressBarWidth = 30
+	// DefaultProgressBarFrequency is the default frequency at which the
+	// progress bar is redrawn.
+	DefaultProgressBarFrequency = time.Second
+)
+
+var (
+	errProgressBarWriterCannotBeNil   = errors.New("pool: progress bar writer cannot be nil")
+	errProgressBarProgressCannotBeNil = errors.New("pool: progress bar progress cannot be nil")
+)
+
+// ProgressBarParams is used to configure a ProgressBar.
+type ProgressBarParams struct {
+	// Writer is the device where the progress bar is drawn, commonly an
+	// *output.Device.
+	Writer io.Writer
+	// Progress which is drawn.
+	Progress *Progress
+
+	// Width of the bar in characters, defaults to DefaultProgressBarWidth.
+	Width int
+	// Frequency at which the bar is redrawn, defaults to
+	// DefaultProgressBarFrequency.
+	Frequency time.Duration
+}
+
+// Validate verifies that the parameters are valid and returns a multierror if
+// any invalid parameters are found.
+func (params ProgressBarParams) Validate() error {
+	var merr = new(multierror.Error)
+
+	if params.Writer == nil {
+		merr = multierror.Append(merr, errProgressBarWriterCannotBeNil)
+	}
+
+	if params.Progress == nil {
+		merr = multierror.Append(merr, errProgressBarProgressCannotBeNil)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+func (params *ProgressBarParams) fillDefaults() {
+	if params.Width <= 0 {
+		params.Width = DefaultProgressBarWidth
+	}
+
+	if params.Frequency <= 0 {
+		params.Frequency = DefaultProgressBarFrequency
+	}
+}
+
+// ProgressBar periodically draws the metrics of a Progress to a writer,
+// redrawing the same line on each update.
+type ProgressBar struct {
+	params ProgressBarParams
+
+	stop    chan struct{}
+	stopped chan struct{}
+
+	mu        sync.Mutex
+	started   bool
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewProgressBar initializes a new ProgressBar from a set of parameters.
+func NewProgressBar(params ProgressBarParams) (*ProgressBar, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	params.fillDefaults()
+
+	return &ProgressBar{
+		params:  params,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}, nil
+}
+
+// Start starts drawing the progress bar in the background until Stop is
+// called. Calling it more than once has no effect.
+func (b *ProgressBar) Start() {
+	b.startOnce.Do(func() {
+		b.mu.Lock()
+		b.started = true
+		b.mu.Unlock()
+
+		go b.run()
+	})
+}
+
+// Stop draws the progress bar one last time and stops drawing it. It has no
+// effect when the progress bar hasn't been started.
+func (b *ProgressBar) Stop() {
+	b.mu.Lock()
+	var started = b.started
+	b.mu.Unlock()
+
+	if !started {
+		return
+	}
+
+	b.stopOnce.Do(func() { close(b.stop) })
+	<-b.stopped
+}
+
+func (b *ProgressBar) run() {
+	defer close(b.stopped)
+
+	var ticker = time.NewTicker(b.params.Frequency)
+	defer ticker.Stop()
+
+	b.draw()
+	for {
+		select {
+		case <-ticker.C:
+			b.draw()
+		case <-b.stop:
+			b.draw()
+			fmt.Fprintln(b.params.Writer)
+			return
+		}
+	}
+}
+
+func (b *ProgressBar) draw() {
+	fmt.Fprint(b.params.Writer, "\r", formatProgress(b.params.Progress.Metrics(), b.params.Width))
+}
+
+// formatProgress formats the metrics as a progress bar line with the
+// following format:
+//
+//	[=========>          ] 3/10 30% (1 failed, 2 processing) ETA 1m10s
+func formatProgress(m Metrics, width int) string {
+	var ratio float64
+	if m.Queued > 0 {
+		ratio = float64(m.Completed()) / float64(m.Queued)
+	}
+
+	var filled = int(ratio * float64(width))
+	var bar = strings.Repeat("=", filled)
+	if filled < width {
+		bar += ">" + strings.Repeat(" ", width-filled-1)
+	}
+
+	var eta = "-"
+	if m.ETA > 0 {
+		eta = m.ETA.Round(time.Second).String()
+	}
+
+	return fmt.Sprintf("[%s] %d/%d %d%% (%d failed, %d processing) ETA %s",
+		bar, m.Completed(), m.Queued, int(ratio*100), m.Failed, m.Processing, eta,
+	)
+}
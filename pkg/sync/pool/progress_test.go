@@ -0,0 +1,479 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/elastic/cloud-sdk-go/pkg/output"
+)
+
+type errValidator struct {
+	id  int
+	err error
+}
+
+func (v errValidator) Validate() error { return v.err }
+
+func newTestProgress(now time.Time) *Progress {
+	var p = NewProgress()
+	p.now = func() time.Time { return now }
+	return p
+}
+
+func TestProgressMetrics(t *testing.T) {
+	var start = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var someErr = errors.New("some error")
+	tests := []struct {
+		name    string
+		events  func(p *Progress)
+		elapsed time.Duration
+		want    Metrics
+	}{
+		{
+			name: "returns empty metrics when nothing is queued",
+		},
+		{
+			name: "returns no ETA when nothing is completed",
+			events: func(p *Progress) {
+				p.record(ItemQueued, 1, nil)
+				p.record(ItemQueued, 2, nil)
+				p.record(ItemStarted, 1, nil)
+			},
+			elapsed: 10 * time.Second,
+			want: Metrics{
+				Queued:     2,
+				Processing: 1,
+				Elapsed:    10 * time.Second,
+			},
+		},
+		{
+			name: "returns the counters, throughput and ETA",
+			events: func(p *Progress) {
+				for i := 0; i < 10; i++ {
+					p.record(ItemQueued, i, nil)
+				}
+				for i := 0; i < 6; i++ {
+					p.record(ItemStarted, i, nil)
+				}
+				for i := 0; i < 4; i++ {
+					p.recordResult(i, nil)
+				}
+				p.recordResult(4, someErr)
+			},
+			elapsed: 10 * time.Second,
+			want: Metrics{
+				Queued:     10,
+				Processing: 1,
+				Done:       4,
+				Failed:     1,
+				Elapsed:    10 * time.Second,
+				Throughput: 0.5,
+				ETA:        10 * time.Second,
+			},
+		},
+		{
+			name: "counts items which weren't processed as failed",
+			events: func(p *Progress) {
+				p.record(ItemQueued, 1, nil)
+				p.record(ItemQueued, 2, nil)
+				p.record(ItemStarted, 1, nil)
+				p.recordResult(1, nil)
+				p.record(ItemFailed, 2, ErrNotProcessed)
+			},
+			elapsed: 2 * time.Second,
+			want: Metrics{
+				Queued:     2,
+				Done:       1,
+				Failed:     1,
+				Elapsed:    2 * time.Second,
+				Throughput: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p = newTestProgress(start)
+			if tt.events != nil {
+				tt.events(p)
+			}
+			p.now = func() time.Time { return start.Add(tt.elapsed) }
+
+			if got := p.Metrics(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Progress.Metrics() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressSubscribe(t *testing.T) {
+	var now = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var someErr = errors.New("some error")
+	var p = newTestProgress(now)
+
+	events, unsubscribe := p.Subscribe(10)
+	// A subscriber with a full buffer doesn't block the progress.
+	full, unsubscribeFull := p.Subscribe(0)
+	defer unsubscribeFull()
+
+	p.record(ItemQueued, 1, nil)
+	p.record(ItemStarted, 1, nil)
+	p.recordResult(1, someErr)
+	unsubscribe()
+	unsubscribe()
+	p.record(ItemQueued, 2, nil)
+
+	var got []Event
+	for e := range events {
+		got = append(got, e)
+	}
+
+	var want = []Event{
+		{Type: ItemQueued, Item: 1, Time: now},
+		{Type: ItemStarted, Item: 1, Time: now},
+		{Type: ItemFailed, Item: 1, Err: someErr, Time: now},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Progress.Subscribe() events = %+v, want %+v", got, want)
+	}
+
+	if len(full) != 0 {
+		t.Errorf("Progress.Subscribe() unbuffered subscriber got %d events", len(full))
+	}
+}
+
+func TestPoolProgress(t *testing.T) {
+	var someErr = errors.New("some error")
+	p, err := NewPool(Params{
+		Size:    2,
+		Run:     func(v Validator) error { return v.Validate() },
+		Timeout: DefaultTimeout,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, unsubscribe := p.Progress().Subscribe(32)
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.Add(
+		errValidator{id: 1},
+		errValidator{id: 2, err: someErr},
+		errValidator{id: 3},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	var wantErr = multierror.Append(nil, someErr)
+	if err := p.Wait(); !reflect.DeepEqual(err, wantErr) {
+		t.Errorf("Pool.Wait() error = %v, wantErr %v", err, wantErr)
+	}
+	if err := p.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	unsubscribe()
+
+	var got = p.Progress().Metrics()
+	got.Elapsed, got.Throughput, got.ETA = 0, 0, 0
+	var want = Metrics{Queued: 3, Done: 2, Failed: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pool.Progress().Metrics() = %+v, want %+v", got, want)
+	}
+
+	var gotEvents = make(map[EventType]int)
+	for e := range events {
+		gotEvents[e.Type]++
+	}
+	var wantEvents = map[EventType]int{
+		ItemQueued: 3, ItemStarted: 3, ItemFinished: 2, ItemFailed: 1,
+	}
+	if !reflect.DeepEqual(gotEvents, wantEvents) {
+		t.Errorf("Pool.Progress() events = %v, want %v", gotEvents, wantEvents)
+	}
+}
+
+func TestPoolProgressLeftovers(t *testing.T) {
+	var release = make(chan struct{})
+	var started = make(chan struct{}, 1)
+	p, err := NewPool(Params{
+		Size: 1,
+		Run: func(v Validator) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+		Timeout: Timeout{Add: time.Second, Stop: time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, unsubscribe := p.Progress().Subscribe(32)
+	if err := p.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.Add(errValidator{id: 1}, errValidator{id: 2}, errValidator{id: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	<-started
+	if err := p.Stop(); err != ErrStopOperationTimedOut {
+		t.Errorf("Pool.Stop() error = %v, wantErr %v", err, ErrStopOperationTimedOut)
+	}
+
+	// The result of the timed out item is discarded once it completes.
+	close(release)
+	<-time.After(10 * time.Millisecond)
+	unsubscribe()
+
+	var got = p.Progress().Metrics()
+	got.Elapsed, got.Throughput, got.ETA = 0, 0, 0
+	var want = Metrics{Queued: 3, Failed: 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pool.Progress().Metrics() = %+v, want %+v", got, want)
+	}
+
+	var gotEvents = make(map[EventType]int)
+	for e := range events {
+		if e.Type == ItemFailed && e.Err != ErrWorkLeftover {
+			t.Errorf("Pool.Progress() failed event error = %v, want %v", e.Err, ErrWorkLeftover)
+		}
+		gotEvents[e.Type]++
+	}
+	var wantEvents = map[EventType]int{
+		ItemQueued: 3, ItemStarted: 1, ItemFailed: 3,
+	}
+	if !reflect.DeepEqual(gotEvents, wantEvents) {
+		t.Errorf("Pool.Progress() events = %v, want %v", gotEvents, wantEvents)
+	}
+}
+
+func TestPoolProgressAddTimeout(t *testing.T) {
+	p, err := NewPool(Params{
+		Size:    1,
+		Run:     func(v Validator) error { return nil },
+		Timeout: Timeout{Add: time.Millisecond, Stop: time.Second},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The pool isn't started, so the items above the queue buffer time out.
+	var work = make([]Validator, cap(p.queue)+1)
+	for i := range work {
+		work[i] = errValidator{id: i}
+	}
+
+	leftover, err := p.Add(work...)
+	if err != ErrAddOperationTimedOut {
+		t.Errorf("Pool.Add() error = %v, wantErr %v", err, ErrAddOperationTimedOut)
+	}
+	if len(leftover) != 1 {
+		t.Errorf("Pool.Add() leftover = %d items, want 1", len(leftover))
+	}
+
+	var got = p.Progress().Metrics()
+	got.Elapsed, got.Throughput, got.ETA = 0, 0, 0
+	var want = Metrics{Queued: uint32(len(work)), Failed: 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Pool.Progress().Metrics() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNilProgress(t *testing.T) {
+	var p *Progress
+	p.record(ItemQueued, 1, nil)
+	p.recordResult(1, nil)
+
+	if got := p.Metrics(); !reflect.DeepEqual(got, Metrics{}) {
+		t.Errorf("Progress.Metrics() = %+v, want empty metrics", got)
+	}
+
+	events, unsubscribe := p.Subscribe(1)
+	unsubscribe()
+	if _, ok := <-events; ok {
+		t.Error("Progress.Subscribe() channel isn't closed")
+	}
+}
+
+func TestTypedPoolProgress(t *testing.T) {
+	var someErr = errors.New("some error")
+	var progress = NewProgress()
+	p, err := NewTypedPool(TypedParams[int, int]{
+		Size:     1,
+		Run:      flakyRun(1, someErr),
+		FailFast: true,
+		Progress: progress,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := p.Progress(); got != progress {
+		t.Errorf("TypedPool.Progress() = %p, want %p", got, progress)
+	}
+
+	p.Run(context.Background(), 1, 2, 3)
+
+	var got = progress.Metrics()
+	got.Elapsed, got.Throughput, got.ETA = 0, 0, 0
+	var want = Metrics{Queued: 3, Failed: 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TypedPool.Progress().Metrics() = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewProgressBar(t *testing.T) {
+	tests := []struct {
+		name string
+		args ProgressBarParams
+		want ProgressBarParams
+		err  error
+	}{
+		{
+			name: "fails due to parameter validation",
+			err: &multierror.Error{Errors: []error{
+				errProgressBarWriterCannotBeNil,
+				errProgressBarProgressCannotBeNil,
+			}},
+		},
+		{
+			name: "fills the defaults",
+			args: ProgressBarParams{
+				Writer:   new(bytes.Buffer),
+				Progress: NewProgress(),
+			},
+			want: ProgressBarParams{
+				Width:     DefaultProgressBarWidth,
+				Frequency: DefaultProgressBarFrequency,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewProgressBar(tt.args)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("NewProgressBar() error = %v, wantErr %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if got.params.Width != tt.want.Width || got.params.Frequency != tt.want.Frequency {
+				t.Errorf("NewProgressBar() params = %+v, want %+v", got.params, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatProgress(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics Metrics
+		want    string
+	}{
+		{
+			name: "formats an empty progress",
+			want: "[>         ] 0/0 0% (0 failed, 0 processing) ETA -",
+		},
+		{
+			name: "formats an ongoing progress",
+			metrics: Metrics{
+				Queued: 10, Processing: 2, Done: 2, Failed: 1,
+				ETA: 70*time.Second + 300*time.Millisecond,
+			},
+			want: "[===>      ] 3/10 30% (1 failed, 2 processing) ETA 1m10s",
+		},
+		{
+			name:    "formats a completed progress",
+			metrics: Metrics{Queued: 4, Done: 4},
+			want:    "[==========] 4/4 100% (0 failed, 0 processing) ETA -",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatProgress(tt.metrics, 10); got != tt.want {
+				t.Errorf("formatProgress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	var progress = NewProgress()
+	bar, err := NewProgressBar(ProgressBarParams{
+		Writer:    output.NewDevice(buf),
+		Progress:  progress,
+		Width:     10,
+		Frequency: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bar.Start()
+	bar.Start()
+	progress.record(ItemQueued, 1, nil)
+	progress.record(ItemStarted, 1, nil)
+	progress.recordResult(1, nil)
+	bar.Stop()
+	bar.Stop()
+
+	var want = "\r[==========] 1/1 100% (0 failed, 0 processing) ETA -\n"
+	if got := buf.String(); !strings.HasSuffix(got, want) {
+		t.Errorf("ProgressBar output = %q, want suffix %q", got, want)
+	}
+}
+
+func TestProgressBarStopWithoutStart(t *testing.T) {
+	var buf = new(bytes.Buffer)
+	bar, err := NewProgressBar(ProgressBarParams{
+		Writer:   buf,
+		Progress: NewProgress(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var done = make(chan struct{})
+	go func() {
+		bar.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ProgressBar.Stop() blocked without calling Start()")
+	}
+
+	if got := buf.String(); got != "" {
+		t.Errorf("ProgressBar output = %q, want %q", got, "")
+	}
+}
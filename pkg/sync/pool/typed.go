@@ -0,0 +1,245 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNotProcessed is set as the result error of the items which weren't
+	// processed because a previous item failed and FailFast is set.
+	ErrNotProcessed = errors.New("pool: item not processed, a previous item failed")
+
+	errPoolRetriesCannotBeNegative = errors.New("pool: retries cannot be negative")
+)
+
+// TypedRunFunc is the function that a TypedPool uses to process each item,
+// returning the item's result value. The context is cancelled when the pool
+// run is cancelled, so long running functions should honour it.
+type TypedRunFunc[T, R any] func(ctx context.Context, item T) (R, error)
+
+// Result pairs a work item with the value and error returned by processing
+// it, along with the number of attempts which were made.
+type Result[T, R any] struct {
+	Item     T
+	Value    R
+	Err      error
+	Attempts int
+}
+
+// TypedParams is used to configure a TypedPool.
+type TypedParams[T, R any] struct {
+	// Size controls how many concurrent operations are running at the same
+	// time.
+	Size uint16
+
+	// Run is the function that is run by the workers on each item.
+	Run TypedRunFunc[T, R]
+
+	// Retries is the number of times that a failed item is retried.
+	Retries int
+
+	// RetryBackoff is the time to wait before retrying a failed item.
+	RetryBackoff time.Duration
+
+	// Retryable can be set to only retry certain errors, when nil all the
+	// errors are retried.
+	Retryable func(error) bool
+
+	// FailFast can be set to stop processing items when any of the items
+	// returns an error after its retries.
+	FailFast bool
+
+	// Progress can be set to track the progress of the items, when nil a
+	// new Progress is created, which can be obtained through
+	// TypedPool.Progress.
+	Progress *Progress
+}
+
+// Validate verifies that the parameters are valid and returns a multierror if
+// any invalid parameters are found.
+func (params TypedParams[T, R]) Validate() error {
+	var merr = new(multierror.Error)
+
+	if params.Size == 0 {
+		merr = multierror.Append(merr, errPoolSizeCannotBeZero)
+	}
+
+	if params.Run == nil {
+		merr = multierror.Append(merr, errPoolRunFuncCannotBeNil)
+	}
+
+	if params.Retries < 0 {
+		merr = multierror.Append(merr, errPoolRetriesCannotBeNegative)
+	}
+
+	return merr.ErrorOrNil()
+}
+
+// TypedPool is a worker pool which processes typed items and returns their
+// results. Unlike Pool, it's driven by a context.Context and doesn't install
+// any signal handlers, to stop it on interrupt signals use a context from
+// signal.NotifyContext. A TypedPool can be run multiple times.
+type TypedPool[T, R any] struct {
+	size      int
+	run       TypedRunFunc[T, R]
+	retries   int
+	backoff   time.Duration
+	retryable func(error) bool
+	failFast  bool
+	progress  *Progress
+}
+
+// NewTypedPool initializes a new TypedPool from a set of parameters.
+func NewTypedPool[T, R any](params TypedParams[T, R]) (*TypedPool[T, R], error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	var retryable = params.Retryable
+	if retryable == nil {
+		retryable = func(error) bool { return true }
+	}
+
+	var progress = params.Progress
+	if progress == nil {
+		progress = NewProgress()
+	}
+
+	return &TypedPool[T, R]{
+		size:      int(params.Size),
+		run:       params.Run,
+		retries:   params.Retries,
+		backoff:   params.RetryBackoff,
+		retryable: retryable,
+		failFast:  params.FailFast,
+		progress:  progress,
+	}, nil
+}
+
+// Progress returns the Progress of the pool, which can be used to subscribe
+// to the item events or to obtain its metrics.
+func (p *TypedPool[T, R]) Progress() *Progress { return p.progress }
+
+// Run processes the items concurrently and blocks until all of them have been
+// processed, returning their results in the same order as the items. When the
+// context is cancelled, the items which haven't been picked up by a worker
+// are returned with the context error.
+func (p *TypedPool[T, R]) Run(ctx context.Context, items ...T) []Result[T, R] {
+	var results = make([]Result[T, R], len(items))
+	for i := range items {
+		results[i].Item = items[i]
+		p.progress.record(ItemQueued, items[i], nil)
+	}
+
+	// The run context is only cancelled on its own when FailFast is set.
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var notProcessed = func() error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return ErrNotProcessed
+	}
+
+	var workers = p.size
+	if len(items) < workers {
+		workers = len(items)
+	}
+
+	var queue = make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				if runCtx.Err() != nil {
+					results[i].Err = notProcessed()
+					p.progress.record(ItemFailed, items[i], results[i].Err)
+					continue
+				}
+
+				p.progress.record(ItemStarted, items[i], nil)
+				p.process(runCtx, &results[i])
+				p.progress.recordResult(items[i], results[i].Err)
+				if results[i].Err != nil && p.failFast {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for i := range items {
+		select {
+		case queue <- i:
+			continue
+		case <-runCtx.Done():
+		}
+
+		var err = notProcessed()
+		for ; i < len(items); i++ {
+			results[i].Err = err
+			p.progress.record(ItemFailed, items[i], err)
+		}
+		break
+	}
+
+	close(queue)
+	wg.Wait()
+
+	return results
+}
+
+// process runs the item until it succeeds, its retries are exhausted, the
+// error isn't retryable or the context is cancelled.
+func (p *TypedPool[T, R]) process(ctx context.Context, result *Result[T, R]) {
+	for {
+		result.Value, result.Err = p.run(ctx, result.Item)
+		result.Attempts++
+
+		if result.Err == nil || result.Attempts > p.retries || !p.retryable(result.Err) {
+			return
+		}
+
+		select {
+		case <-time.After(p.backoff):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ResultsError returns the errors of the results as a multierror, or nil
+// when all of the items succeeded.
+func ResultsError[T, R any](results []Result[T, R]) error {
+	var merr = new(multierror.Error)
+	for _, r := range results {
+		if r.Err != nil {
+			merr = multierror.Append(merr, r.Err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
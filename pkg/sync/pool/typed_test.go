@@ -0,0 +1,245 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pool
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+func square(_ context.Context, i int) (int, error) { return i * i, nil }
+
+func TestNewTypedPool(t *testing.T) {
+	tests := []struct {
+		name   string
+		params TypedParams[int, int]
+		err    error
+	}{
+		{
+			name:   "fails due to parameter validation",
+			params: TypedParams[int, int]{Retries: -1},
+			err: &multierror.Error{Errors: []error{
+				errPoolSizeCannotBeZero,
+				errPoolRunFuncCannotBeNil,
+				errPoolRetriesCannotBeNegative,
+			}},
+		},
+		{
+			name:   "succeeds",
+			params: TypedParams[int, int]{Size: 1, Run: square},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTypedPool(tt.params)
+			if !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("NewTypedPool() error = %v, wantErr %v", err, tt.err)
+			}
+			if tt.err == nil && got == nil {
+				t.Error("NewTypedPool() returned a nil pool")
+			}
+		})
+	}
+}
+
+// flakyRun returns a TypedRunFunc which fails the specified number of times
+// for each item before succeeding.
+func flakyRun(failures int, err error) TypedRunFunc[int, int] {
+	var mu sync.Mutex
+	var attempts = make(map[int]int)
+	return func(ctx context.Context, i int) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts[i]++
+		if attempts[i] <= failures {
+			return 0, err
+		}
+		return i * i, nil
+	}
+}
+
+func TestTypedPoolRun(t *testing.T) {
+	var errFailed = errors.New("failed")
+	var errPermanent = errors.New("permanent")
+	var cancelled, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		params TypedParams[int, int]
+		items  []int
+		want   []Result[int, int]
+	}{
+		{
+			name:   "returns the results in the items order",
+			params: TypedParams[int, int]{Size: 2, Run: square},
+			items:  []int{1, 2, 3, 4},
+			want: []Result[int, int]{
+				{Item: 1, Value: 1, Attempts: 1},
+				{Item: 2, Value: 4, Attempts: 1},
+				{Item: 3, Value: 9, Attempts: 1},
+				{Item: 4, Value: 16, Attempts: 1},
+			},
+		},
+		{
+			name:   "retries the failed items",
+			params: TypedParams[int, int]{Size: 2, Run: flakyRun(2, errFailed), Retries: 2},
+			items:  []int{1, 2},
+			want: []Result[int, int]{
+				{Item: 1, Value: 1, Attempts: 3},
+				{Item: 2, Value: 4, Attempts: 3},
+			},
+		},
+		{
+			name: "returns the error when the retries are exhausted",
+			params: TypedParams[int, int]{
+				Size: 1, Run: flakyRun(5, errFailed), Retries: 1, RetryBackoff: time.Millisecond,
+			},
+			items: []int{1},
+			want: []Result[int, int]{
+				{Item: 1, Err: errFailed, Attempts: 2},
+			},
+		},
+		{
+			name: "doesn't retry errors which aren't retryable",
+			params: TypedParams[int, int]{
+				Size: 1, Run: flakyRun(1, errPermanent), Retries: 3,
+				Retryable: func(err error) bool { return err != errPermanent },
+			},
+			items: []int{1},
+			want: []Result[int, int]{
+				{Item: 1, Err: errPermanent, Attempts: 1},
+			},
+		},
+		{
+			name: "stops processing items on the first failure with fail fast",
+			params: TypedParams[int, int]{
+				Size: 1, FailFast: true,
+				Run: func(ctx context.Context, i int) (int, error) {
+					if i == 2 {
+						return 0, errFailed
+					}
+					return i * i, nil
+				},
+			},
+			items: []int{1, 2, 3, 4},
+			want: []Result[int, int]{
+				{Item: 1, Value: 1, Attempts: 1},
+				{Item: 2, Err: errFailed, Attempts: 1},
+				{Item: 3, Err: ErrNotProcessed},
+				{Item: 4, Err: ErrNotProcessed},
+			},
+		},
+		{
+			name:   "doesn't process any items with a cancelled context",
+			ctx:    cancelled,
+			params: TypedParams[int, int]{Size: 2, Run: square},
+			items:  []int{1, 2},
+			want: []Result[int, int]{
+				{Item: 1, Err: context.Canceled},
+				{Item: 2, Err: context.Canceled},
+			},
+		},
+		{
+			name:   "returns no results without items",
+			params: TypedParams[int, int]{Size: 2, Run: square},
+			want:   []Result[int, int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ctx = tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			p, err := NewTypedPool(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := p.Run(ctx, tt.items...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TypedPool.Run() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypedPoolRunCancel(t *testing.T) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	var started = make(chan struct{})
+	p, err := NewTypedPool(TypedParams[int, int]{
+		Size: 1,
+		Run: func(ctx context.Context, i int) (int, error) {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	var want = []Result[int, int]{
+		{Item: 1, Err: context.Canceled, Attempts: 1},
+		{Item: 2, Err: context.Canceled},
+	}
+	if got := p.Run(ctx, 1, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("TypedPool.Run() = %+v, want %+v", got, want)
+	}
+}
+
+func TestResultsError(t *testing.T) {
+	var errFailed = errors.New("failed")
+	tests := []struct {
+		name    string
+		results []Result[int, int]
+		err     error
+	}{
+		{
+			name:    "returns nil without errors",
+			results: []Result[int, int]{{Item: 1}, {Item: 2}},
+		},
+		{
+			name:    "returns the result errors",
+			results: []Result[int, int]{{Item: 1, Err: errFailed}, {Item: 2}, {Item: 3, Err: ErrNotProcessed}},
+			err:     &multierror.Error{Errors: []error{errFailed, ErrNotProcessed}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ResultsError(tt.results); !reflect.DeepEqual(err, tt.err) {
+				t.Errorf("ResultsError() error = %v, wantErr %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -49,6 +49,9 @@ type Worker struct {
 	// timeout is exceeded before the work is completed the current work will be
 	// sent to the leftover queue.
 	StopTimeout time.Duration
+	// Progress is an optional progress tracker which is notified when the
+	// work items are started and completed.
+	Progress *Progress
 }
 
 // StopParams is consumed by StopWorkers so a set of workers can be stopped.
@@ -71,23 +74,29 @@ func Work(worker Worker) {
 	var timedout bool
 	defer func() { worker.Stopped <- timedout }()
 
-	var done = make(chan struct{})
 	for {
 		select {
 		case task := <-worker.Queue:
+			// The result is buffered so the goroutine doesn't block when the
+			// worker has already returned due to the stop timeout.
+			var done = make(chan error, 1)
+			worker.Progress.record(ItemStarted, task, nil)
+
 			// This is necessary to allow the work to happen in the background
 			// and still react to any sent to worker.stop and be able to clean
 			// shutdown the worker even if the work is in flight.
-			go func(w Validator, d chan<- struct{}) {
-				if err := worker.Run(w); err != nil {
+			go func(w Validator, d chan<- error) {
+				err := worker.Run(w)
+				if err != nil {
 					worker.Errors <- err
 				}
-				d <- struct{}{}
+				d <- err
 			}(task, done)
 
 			select {
 			// Receives the done signal when the work is done.
-			case <-done:
+			case err := <-done:
+				worker.Progress.recordResult(task, err)
 				worker.Finished <- struct{}{}
 
 			// Handles the case where the worker is processing a work item and
@@ -95,12 +104,14 @@ func Work(worker Worker) {
 			case <-worker.Stop:
 				// This gives one last chance to the worker to complete the
 				// work before returning with a timeout and sending the item
-				// to the leftover queue.
+				// to the leftover queue, where its result is discarded.
 				select {
-				case <-done:
+				case err := <-done:
+					worker.Progress.recordResult(task, err)
 					worker.Finished <- struct{}{}
 				case <-time.After(worker.StopTimeout):
 					worker.Leftovers <- task
+					worker.Progress.recordResult(task, ErrWorkLeftover)
 					timedout = true
 				}
 				return